@@ -1,6 +1,8 @@
 package check
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/output"
@@ -21,27 +23,89 @@ const StatusFail = "FAIL"
 // StatusError means the result could not be obtained
 const StatusError = "ERROR"
 
+// StatusTimeout means the check did not finish within its time limit
+const StatusTimeout = "TIMEOUT"
+
+// Severity returns how serious a result status is, so that results can be
+// compared. A timeout is as severe as an error, since in both cases the result
+// could not be obtained. Unknown statuses are treated as informational.
+func Severity(status string) int {
+	switch status {
+	case StatusPass:
+		return 1
+	case StatusWarn:
+		return 2
+	case StatusFail:
+		return 3
+	case StatusError, StatusTimeout:
+		return 4
+	default:
+		return 0
+	}
+}
+
 // Check represent a single operation (API call, external program execution,
 // etc.) that returns one or more result.
 type Check interface {
 	Describe() string
+
+	// ID returns a stable, machine-readable identifier for the check, made of
+	// dot-separated segments from the most general to the most specific (e.g.
+	// "disk.fio.iops"). Checks that run against a container runtime end with
+	// the runtime name (e.g. "container.logs.docker").
+	ID() string
+
 	Run(*RunContext) []Result
 }
 
+// Dependent is implemented by checks that must run after other checks in the
+// same report have finished (e.g. to use results cached in the RunContext).
+type Dependent interface {
+	// DependsOn returns whether this check must run after the given check.
+	DependsOn(other Check) bool
+}
+
+// Exclusive is implemented by checks that must not run concurrently with any
+// other check, such as performance tests that would skew or be skewed by other
+// activity on the host.
+type Exclusive interface {
+	Exclusive() bool
+}
+
+// Timeout is implemented by checks that are expected to run longer than the
+// report's default check timeout, such as performance tests.
+type Timeout interface {
+	// Timeout returns how long the check may run before it is canceled. It
+	// takes precedence over the report's default check timeout, unless it is
+	// zero.
+	Timeout() time.Duration
+}
+
 // RunContext is container of other services available to checks within the
 // context of a particular report run.
 type RunContext struct {
+	// Context is done when the check should stop, either because it has run
+	// longer than its timeout or because the report was canceled. Checks pass
+	// it to any commands or network calls they make.
+	Context context.Context
+
+	// Cleanups collects the cleanup hooks for anything the check changes on
+	// the host or in the container, so they run even if the check is
+	// interrupted.
+	Cleanups *Cleanups
+
 	OutputStore output.Store
 
 	ContainerID string
 	Since       time.Duration
 
+	// Requirements are the thresholds checks use to determine the status of
+	// their results. If nil, the default requirements are used.
+	Requirements Requirements
+
 	// ContainerRuntimeAvailability caches the availability status of container runtimes
 	// Maps provider names (e.g., "docker", "podman") to availability status and error
 	ContainerRuntimeAvailability map[string]RuntimeAvailability
-
-	// VerboseErrors controls whether to report errors for unavailable container runtimes
-	VerboseErrors bool
 }
 
 // RuntimeAvailability represents the availability status of a container runtime
@@ -53,10 +117,36 @@ type RuntimeAvailability struct {
 // Result is the outcome of a particular check. A check may produce multiple
 // results.
 type Result struct {
+	// CheckID is the ID of the check that produced the result. It is set by
+	// the report, so checks don't need to set it themselves.
+	CheckID string `json:"check_id"`
+
 	Title   string `json:"title"`
 	Value   string `json:"value"`
 	Status  string `json:"status"`
 	Message string `json:"message"`
+
+	// Metrics are the measurements behind the result's value, if any, so that
+	// tools reading the JSON output don't need to parse the formatted value
+	Metrics []Metric `json:"metrics,omitempty"`
+
+	// Remediation suggests how to resolve a WARN or FAIL result, and DocURL
+	// links to documentation about it
+	Remediation string `json:"remediation,omitempty"`
+	DocURL      string `json:"doc_url,omitempty"`
+
+	// Suppressed marks a result that is only displayed when verbose errors are
+	// requested (e.g. errors for an unavailable container runtime). Suppressed
+	// results are always included in the archived report.
+	Suppressed bool `json:"suppressed,omitempty"`
+}
+
+// Metric is a single named measurement with its unit (e.g. "bytes", "ms").
+// Unitless counts have an empty unit.
+type Metric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
 }
 
 // ErrorResult returns a single result with an error message.
@@ -70,3 +160,55 @@ func ErrorResult(c Check, err error) []Result {
 		},
 	}
 }
+
+// TimeoutResult returns a single result reporting that the check did not
+// finish within the given timeout.
+func TimeoutResult(c Check, timeout time.Duration) []Result {
+	return []Result{
+		{
+			Title:   c.Describe(),
+			Status:  StatusTimeout,
+			Value:   "N/A",
+			Message: fmt.Sprintf("check did not finish within %s", timeout),
+		},
+	}
+}
+
+// SuppressedErrorResult returns a single result with an error message that is
+// only displayed when verbose errors are requested.
+func SuppressedErrorResult(c Check, err error) []Result {
+	return Suppress(ErrorResult(c, err))
+}
+
+// Suppress marks each of the given results as suppressed and returns them.
+func Suppress(results []Result) []Result {
+	for i := range results {
+		results[i].Suppressed = true
+	}
+
+	return results
+}
+
+// Remediate sets the remediation and documentation link of a result whose
+// status is WARN or FAIL, and returns it. Other results are returned
+// unchanged, so checks may call it regardless of the status.
+func Remediate(result Result, remediation string, docURL string) Result {
+	if result.Status == StatusWarn || result.Status == StatusFail {
+		result.Remediation = remediation
+		result.DocURL = docURL
+	}
+
+	return result
+}
+
+// Unsuppressed returns only the results that are not marked as suppressed.
+func Unsuppressed(results []Result) []Result {
+	filtered := []Result{}
+	for _, result := range results {
+		if !result.Suppressed {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
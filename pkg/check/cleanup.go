@@ -0,0 +1,47 @@
+package check
+
+import (
+	"context"
+	"sync"
+)
+
+// Cleanups holds the cleanup hooks registered by a check while it runs, such
+// as stopping a process it started or removing temporary files. The report
+// runs them once the check has finished, timed out or been interrupted, so
+// that intrusive checks never leave changes behind.
+type Cleanups struct {
+	mutex sync.Mutex
+	hooks []func(context.Context)
+	ran   bool
+}
+
+// Add registers a cleanup hook. The hook receives a context that is not
+// canceled when the check is, but that limits how long cleanup may take. If
+// the hooks have already been run, for example because the check was abandoned
+// after timing out, the hook runs immediately.
+func (cleanups *Cleanups) Add(hook func(context.Context)) {
+	cleanups.mutex.Lock()
+	ran := cleanups.ran
+	if !ran {
+		cleanups.hooks = append(cleanups.hooks, hook)
+	}
+	cleanups.mutex.Unlock()
+
+	if ran {
+		hook(context.Background())
+	}
+}
+
+// Run runs the registered cleanup hooks in the reverse order they were added,
+// in the same way as deferred functions. Hooks only run once.
+func (cleanups *Cleanups) Run(ctx context.Context) {
+	cleanups.mutex.Lock()
+	hooks := cleanups.hooks
+	cleanups.hooks = nil
+	cleanups.ran = true
+	cleanups.mutex.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i](ctx)
+	}
+}
@@ -0,0 +1,143 @@
+package check
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Threshold defines the values of a metric at which a result is at risk for
+// production operation (WARN) or unacceptable for it (FAIL). Unset values are
+// ignored.
+type Threshold struct {
+	FailBelow *float64 `json:"fail_below,omitempty" yaml:"fail_below,omitempty"`
+	WarnBelow *float64 `json:"warn_below,omitempty" yaml:"warn_below,omitempty"`
+	WarnAbove *float64 `json:"warn_above,omitempty" yaml:"warn_above,omitempty"`
+	FailAbove *float64 `json:"fail_above,omitempty" yaml:"fail_above,omitempty"`
+}
+
+// Status returns the status of the given metric value. If the threshold has
+// no values set, the metric is informational only.
+func (threshold Threshold) Status(value float64) string {
+	switch {
+	case threshold.FailBelow != nil && value < *threshold.FailBelow,
+		threshold.FailAbove != nil && value > *threshold.FailAbove:
+		return StatusFail
+	case threshold.WarnBelow != nil && value < *threshold.WarnBelow,
+		threshold.WarnAbove != nil && value > *threshold.WarnAbove:
+		return StatusWarn
+	case threshold == Threshold{}:
+		return StatusInfo
+	}
+
+	return StatusPass
+}
+
+// Requirements holds the thresholds for each metric, keyed by check ID and
+// then by metric name.
+type Requirements map[string]map[string]Threshold
+
+// Status returns the status of a metric value for the given check. Metrics
+// without a threshold are informational only. A nil Requirements uses the
+// default requirements.
+func (requirements Requirements) Status(
+	checkID string,
+	metric string,
+	value float64,
+) string {
+	if requirements == nil {
+		requirements = DefaultRequirements()
+	}
+
+	return requirements[checkID][metric].Status(value)
+}
+
+// SystemRequirementsDocURL links to the Conjur Enterprise system
+// requirements, which the default CPU, memory and disk space requirements are
+// based on
+const SystemRequirementsDocURL = "https://docs.cyberark.com/conjur-enterprise/" +
+	"latest/en/content/deployment/system-requirements.htm"
+
+// DefaultRequirements returns the built-in requirements. These are based on
+// the Conjur Enterprise hardware requirements, where a value below the
+// minimum fails and a value below the recommendation warns, and on the etcd
+// hardware recommendations for disk performance
+// (https://etcd.io/docs/v3.3/op-guide/hardware/).
+//
+// Every metric that may be configured is included, with an empty threshold if
+// it is informational by default.
+func DefaultRequirements() Requirements {
+	return Requirements{
+		"host.cpu": {
+			"cores": {FailBelow: limit(2), WarnBelow: limit(4)},
+		},
+		"host.memory": {
+			"total_gb":     {FailBelow: limit(8), WarnBelow: limit(16)},
+			"used_percent": {},
+		},
+		"disk.space": {
+			"free_percent": {WarnBelow: limit(10)},
+		},
+		"disk.fio.iops": {
+			"read_iops":  {WarnBelow: limit(50)},
+			"write_iops": {WarnBelow: limit(50)},
+		},
+		"disk.fio.latency": {
+			"read_p99_ms":  {WarnAbove: limit(10)},
+			"write_p99_ms": {WarnAbove: limit(10)},
+			"sync_p99_ms":  {WarnAbove: limit(10)},
+		},
+		"host.ulimit": {
+			"open_files":    {},
+			"max_processes": {},
+		},
+	}
+}
+
+// LoadRequirements reads a YAML or JSON requirements file and returns the
+// default requirements with the thresholds from the file applied. A threshold
+// in the file replaces the default threshold for that metric entirely.
+func LoadRequirements(path string) (Requirements, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read requirements file: %w", err)
+	}
+
+	// JSON is valid YAML, so this handles both formats
+	fileRequirements := Requirements{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&fileRequirements)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse requirements file: %w", err)
+	}
+
+	requirements := DefaultRequirements()
+	for checkID, metrics := range fileRequirements {
+		if _, ok := requirements[checkID]; !ok {
+			return nil, fmt.Errorf("unknown check ID in requirements: %s", checkID)
+		}
+
+		for metric, threshold := range metrics {
+			if _, ok := requirements[checkID][metric]; !ok {
+				return nil, fmt.Errorf(
+					"unknown metric in requirements for %s: %s",
+					checkID,
+					metric,
+				)
+			}
+
+			requirements[checkID][metric] = threshold
+		}
+	}
+
+	return requirements, nil
+}
+
+func limit(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,114 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdStatus(t *testing.T) {
+	threshold := Threshold{
+		FailBelow: limit(2),
+		WarnBelow: limit(4),
+		WarnAbove: limit(10),
+		FailAbove: limit(20),
+	}
+
+	assert.Equal(t, StatusFail, threshold.Status(1))
+	assert.Equal(t, StatusWarn, threshold.Status(3))
+	assert.Equal(t, StatusPass, threshold.Status(4))
+	assert.Equal(t, StatusPass, threshold.Status(10))
+	assert.Equal(t, StatusWarn, threshold.Status(15))
+	assert.Equal(t, StatusFail, threshold.Status(21))
+
+	assert.Equal(t, StatusInfo, Threshold{}.Status(0))
+}
+
+func TestRequirementsStatus(t *testing.T) {
+	// Nil requirements use the defaults
+	var requirements Requirements
+	assert.Equal(t, StatusFail, requirements.Status("host.cpu", "cores", 1))
+
+	// Metrics without a threshold are informational
+	assert.Equal(t, StatusInfo, requirements.Status("host.cpu", "unknown", 1))
+	assert.Equal(t, StatusInfo, requirements.Status("unknown", "cores", 1))
+}
+
+func TestLoadRequirementsYAML(t *testing.T) {
+	path := writeRequirementsFile(
+		t,
+		"requirements.yml",
+		"host.cpu:\n  cores:\n    fail_below: 8\n",
+	)
+
+	requirements, err := LoadRequirements(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusFail, requirements.Status("host.cpu", "cores", 4))
+	assert.Equal(t, StatusPass, requirements.Status("host.cpu", "cores", 8))
+
+	// Thresholds not in the file keep their defaults
+	assert.Equal(t, StatusFail, requirements.Status("host.memory", "total_gb", 4))
+}
+
+func TestLoadRequirementsJSON(t *testing.T) {
+	path := writeRequirementsFile(
+		t,
+		"requirements.json",
+		`{"disk.fio.latency": {"sync_p99_ms": {"fail_above": 20}}}`,
+	)
+
+	requirements, err := LoadRequirements(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusPass, requirements.Status("disk.fio.latency", "sync_p99_ms", 15))
+	assert.Equal(t, StatusFail, requirements.Status("disk.fio.latency", "sync_p99_ms", 25))
+}
+
+func TestLoadRequirementsEmpty(t *testing.T) {
+	path := writeRequirementsFile(t, "requirements.yml", "")
+
+	requirements, err := LoadRequirements(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRequirements(), requirements)
+}
+
+func TestLoadRequirementsErrors(t *testing.T) {
+	testCases := []struct {
+		contents string
+		err      string
+	}{
+		{
+			contents: "gpu:\n  cores:\n    fail_below: 1\n",
+			err:      "unknown check ID in requirements: gpu",
+		},
+		{
+			contents: "host.cpu:\n  threads:\n    fail_below: 1\n",
+			err:      "unknown metric in requirements for host.cpu: threads",
+		},
+		{
+			contents: "host.cpu:\n  cores:\n    minimum: 1\n",
+			err:      "unable to parse requirements file",
+		},
+	}
+
+	for _, testCase := range testCases {
+		path := writeRequirementsFile(t, "requirements.yml", testCase.contents)
+
+		_, err := LoadRequirements(path)
+		assert.ErrorContains(t, err, testCase.err)
+	}
+
+	_, err := LoadRequirements(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.ErrorContains(t, err, "unable to read requirements file")
+}
+
+func writeRequirementsFile(t *testing.T, name, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	return path
+}
@@ -0,0 +1,17 @@
+package checks
+
+import (
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/container"
+)
+
+// providerCheckID returns the ID of a check that runs against a container
+// runtime, by appending the provider name to the check's base ID (e.g.
+// "container.logs.docker").
+func providerCheckID(id string, provider container.ContainerProvider) string {
+	providerName := strings.ToLower(provider.Name())
+	providerName = strings.ReplaceAll(providerName, " ", "-")
+
+	return id + "." + providerName
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/checks/sanitize"
 	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/provenance"
 )
 
 // userHomeDirFunc is a mockable version of os.UserHomeDir for testing
@@ -25,6 +26,11 @@ func (*CommandHistory) Describe() string {
 	return "Command History"
 }
 
+// ID provides a stable identifier for this check
+func (*CommandHistory) ID() string {
+	return "host.command-history"
+}
+
 // Run performs the command history collection
 func (ch *CommandHistory) Run(runContext *check.RunContext) []check.Result {
 	homeDir, err := userHomeDirFunc()
@@ -70,10 +76,11 @@ func (ch *CommandHistory) Run(runContext *check.RunContext) []check.Result {
 	// Sanitize the history to redact sensitive values
 	redactor := sanitize.NewRedactor()
 	sanitizedContent := redactor.RedactLines(historyContent.String())
+	provenance.FromContext(runContext.Context).RecordRedactions(redactor.Redactions())
 
 	// Save history to output store
 	_, err = runContext.OutputStore.Save(
-		"host-command-history.txt",
+		"command-history.txt",
 		strings.NewReader(sanitizedContent),
 	)
 	if err != nil {
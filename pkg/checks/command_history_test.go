@@ -56,7 +56,7 @@ func TestCommandHistoryRunZshHistoryFound(t *testing.T) {
 	require.Len(t, items, 1)
 	info, err := items[0].Info()
 	require.NoError(t, err)
-	assert.Equal(t, "host-command-history.txt", info.Name())
+	assert.Equal(t, "command-history.txt", info.Name())
 }
 
 func TestCommandHistoryRunBashHistoryFallback(t *testing.T) {
@@ -96,7 +96,7 @@ func TestCommandHistoryRunBashHistoryFallback(t *testing.T) {
 	require.Len(t, items, 1)
 	info, err := items[0].Info()
 	require.NoError(t, err)
-	assert.Equal(t, "host-command-history.txt", info.Name())
+	assert.Equal(t, "command-history.txt", info.Name())
 }
 
 func TestCommandHistoryRunZshHistoryPreferredOverBash(t *testing.T) {
@@ -137,7 +137,7 @@ func TestCommandHistoryRunZshHistoryPreferredOverBash(t *testing.T) {
 	require.Len(t, items, 1)
 	info, err := items[0].Info()
 	require.NoError(t, err)
-	assert.Equal(t, "host-command-history.txt", info.Name())
+	assert.Equal(t, "command-history.txt", info.Name())
 }
 
 func TestCommandHistoryRunNoHistoryFiles(t *testing.T) {
@@ -3,7 +3,6 @@
 package checks
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"strings"
@@ -11,6 +10,7 @@ import (
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/container"
 	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/output"
 	"github.com/cyberark/conjur-inspect/pkg/shell"
 )
 
@@ -19,6 +19,8 @@ var readAllFunc = io.ReadAll
 
 // ConjurConfig collects the contents of Conjur's config files
 type ConjurConfig struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -27,6 +29,11 @@ func (cc *ConjurConfig) Describe() string {
 	return fmt.Sprintf("Conjur Config (%s)", cc.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (cc *ConjurConfig) ID() string {
+	return providerCheckID("conjur.config", cc.Provider)
+}
+
 // Run performs the Conjur configuration check
 func (cc *ConjurConfig) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -37,13 +44,10 @@ func (cc *ConjurConfig) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(cc.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cc,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cc,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := cc.Provider.Container(runContext.ContainerID)
@@ -81,14 +85,23 @@ func (cc *ConjurConfig) collectConfigFile(
 	container container.Container,
 	runContext *check.RunContext,
 ) *check.Result {
-	stdout, stderr, err := container.Exec(
-		"cat", path,
+	// Save the file under its path in the container, e.g.
+	// "etc/conjur/config/conjur.yml"
+	outputFilename := strings.TrimPrefix(path, "/")
+
+	// Stream the file straight to the output store, rather than reading it
+	// into memory first
+	var stderr io.Reader
+	var err error
+	_, saveErr := output.SaveStream(
+		runContext.OutputStore,
+		outputFilename,
+		func(writer io.Writer) {
+			stderr, err = container.ExecTo(runContext.Context, writer, "cat", path)
+		},
 	)
 
 	if err != nil {
-		if !runContext.VerboseErrors {
-			return nil
-		}
 		return &check.Result{
 			Title:  cc.Describe(),
 			Status: check.StatusError,
@@ -99,34 +112,16 @@ func (cc *ConjurConfig) collectConfigFile(
 				err,
 				strings.TrimSpace(shell.ReadOrDefault(stderr, "N/A")),
 			),
+			Suppressed: true,
 		}
 	}
 
-	// Read the stdout data to save it
-	fileBytes, err := readAllFunc(stdout)
-	if err != nil {
-		return &check.Result{
-			Title:   cc.Describe(),
-			Status:  check.StatusError,
-			Value:   "N/A",
-			Message: fmt.Sprintf("failed to read '%s': %s", path, err),
-		}
-	}
-
-	// replace path separates in path to underscores
-	outputFilename := strings.TrimPrefix(strings.ReplaceAll(path, "/", "_"), "_")
-
-	// Save raw health output before parsing, in case there are parsing errors
-	_, err = runContext.OutputStore.Save(
-		outputFilename,
-		bytes.NewReader(fileBytes),
-	)
-	if err != nil {
+	if saveErr != nil {
 		log.Warn(
 			"Failed to save %s '%s': %s",
 			cc.Provider.Name(),
 			path,
-			err,
+			saveErr,
 		)
 	}
 
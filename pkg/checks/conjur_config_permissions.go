@@ -16,6 +16,8 @@ import (
 // ConjurConfigPermissions collects the permissions of the Conjur configuration
 // file (conjur.yml) and containing directory
 type ConjurConfigPermissions struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -24,6 +26,11 @@ func (ccp *ConjurConfigPermissions) Describe() string {
 	return fmt.Sprintf("Conjur Config Permissions (%s)", ccp.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (ccp *ConjurConfigPermissions) ID() string {
+	return providerCheckID("conjur.config-permissions", ccp.Provider)
+}
+
 // Run performs the Conjur configuration check
 func (ccp *ConjurConfigPermissions) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -34,13 +41,10 @@ func (ccp *ConjurConfigPermissions) Run(runContext *check.RunContext) []check.Re
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(ccp.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ccp,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ccp,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := ccp.Provider.Container(runContext.ContainerID)
@@ -60,6 +64,7 @@ func (ccp *ConjurConfigPermissions) collectConjurConfigPermissions(
 	runContext *check.RunContext,
 ) *check.Result {
 	stdout, stderr, err := container.Exec(
+		runContext.Context,
 		"ls", "-la", "/etc/conjur/config",
 	)
 
@@ -89,7 +94,7 @@ func (ccp *ConjurConfigPermissions) collectConjurConfigPermissions(
 
 	// Save raw health output before parsing, in case there are parsing errors
 	_, err = runContext.OutputStore.Save(
-		"conjur_config_permissions.txt",
+		"config-permissions.txt",
 		bytes.NewReader(fileBytes),
 	)
 	if err != nil {
@@ -51,7 +51,7 @@ func TestConjurConfigPermissions_Run_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(
 		t,
-		"conjur_config_permissions.txt",
+		"config-permissions.txt",
 		outputStoreItemInfo.Name(),
 	)
 }
@@ -2,9 +2,9 @@ package checks
 
 import (
 	"errors"
-	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/test"
@@ -69,9 +69,7 @@ func TestConjurConfig_Run_Success(t *testing.T) {
 	// Verify config file saved
 	assert.Condition(t, func() bool {
 		for _, item := range items {
-			info, err := item.Info()
-			assert.NoError(t, err)
-			if strings.Contains(info.Name(), "etc_conjur_config_conjur.yml") {
+			if item.Name() == "etc/conjur/config/conjur.yml" {
 				return true
 			}
 		}
@@ -92,7 +90,6 @@ func TestConjurConfig_Run_FileReadError(t *testing.T) {
 	cc := &ConjurConfig{Provider: provider}
 
 	runContext := test.NewRunContext("test-container")
-	runContext.VerboseErrors = true
 	results := cc.Run(&runContext)
 
 	assert.NotEmpty(t, results)
@@ -113,27 +110,19 @@ func TestConjurConfig_Run_FileReadErrorNoVerboseErrors(t *testing.T) {
 	cc := &ConjurConfig{Provider: provider}
 
 	runContext := test.NewRunContext("test-container")
-	runContext.VerboseErrors = false
 	results := cc.Run(&runContext)
 
-	// Expect no results when VerboseErrors is false
-	assert.Empty(t, results)
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 }
 
-func TestConjurConfig_Run_ReadAllError(t *testing.T) {
-	// Save original readAllFunc to restore after test
-	originalReadAllFunc := readAllFunc
-	defer func() { readAllFunc = originalReadAllFunc }()
-
-	readAllError := errors.New("read error")
-	readAllFunc = func(r io.Reader) ([]byte, error) {
-		return nil, readAllError
-	}
+func TestConjurConfig_Run_StreamError(t *testing.T) {
+	streamError := errors.New("read error")
 
 	provider := &test.ContainerProvider{
 		ExecResponses: map[string]test.ExecResponse{
 			"cat /etc/conjur/config/conjur.yml": {
-				Stdout: strings.NewReader("test content"),
+				Stdout: iotest.ErrReader(streamError),
 			},
 		},
 	}
@@ -145,6 +134,6 @@ func TestConjurConfig_Run_ReadAllError(t *testing.T) {
 
 	assert.NotEmpty(t, results)
 	assert.Equal(t, check.StatusError, results[0].Status)
-	assert.Contains(t, results[0].Message, "failed to read")
-	assert.Contains(t, results[0].Message, readAllError.Error())
+	assert.Contains(t, results[0].Message, "failed to collect '/etc/conjur/config/conjur.yml'")
+	assert.Contains(t, results[0].Message, streamError.Error())
 }
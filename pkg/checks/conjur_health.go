@@ -17,6 +17,8 @@ import (
 
 // ConjurHealth collects the output of Conjur's health API (/health)
 type ConjurHealth struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -32,6 +34,11 @@ func (ch *ConjurHealth) Describe() string {
 	return fmt.Sprintf("Conjur Health (%s)", ch.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (ch *ConjurHealth) ID() string {
+	return providerCheckID("conjur.health", ch.Provider)
+}
+
 // Run performs the Conjur health check
 func (ch *ConjurHealth) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -42,17 +49,15 @@ func (ch *ConjurHealth) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(ch.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ch,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ch,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := ch.Provider.Container(runContext.ContainerID)
 	stdout, stderr, err := container.Exec(
+		runContext.Context,
 		"curl", "-k", "https://localhost/health",
 	)
 
@@ -77,10 +82,7 @@ func (ch *ConjurHealth) Run(runContext *check.RunContext) []check.Result {
 	}
 
 	// Save raw health output before parsing, in case there are parsing errors
-	outputFileName := fmt.Sprintf(
-		"conjur-health-%s.json",
-		strings.ToLower(ch.Provider.Name()),
-	)
+	outputFileName := "health.json"
 	_, err = runContext.OutputStore.Save(
 		outputFileName,
 		bytes.NewReader(healthJSONBytes),
@@ -58,7 +58,7 @@ func TestConjurHealthRun(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(
 		t,
-		"conjur-health-test container provider.json",
+		"health.json",
 		outputStoreItemInfo.Name(),
 	)
 
@@ -175,7 +175,7 @@ func TestConjurHealthRun_UnmarshalError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(
 		t,
-		"conjur-health-test container provider.json",
+		"health.json",
 		outputStoreItemInfo.Name(),
 	)
 
@@ -17,6 +17,8 @@ import (
 
 // ConjurInfo collects the output of the Conjur Info API (/info)
 type ConjurInfo struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -32,6 +34,11 @@ func (ci *ConjurInfo) Describe() string {
 	return fmt.Sprintf("Conjur Info (%s)", ci.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (ci *ConjurInfo) ID() string {
+	return providerCheckID("conjur.info", ci.Provider)
+}
+
 // Run retrieves and parses the Conjur /info API endpoint
 func (ci *ConjurInfo) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -42,18 +49,16 @@ func (ci *ConjurInfo) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(ci.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ci,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ci,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := ci.Provider.Container(runContext.ContainerID)
 
 	stdout, stderr, err := container.Exec(
+		runContext.Context,
 		"curl", "-k", "https://localhost/info",
 	)
 
@@ -78,10 +83,7 @@ func (ci *ConjurInfo) Run(runContext *check.RunContext) []check.Result {
 	}
 
 	// Save raw info output
-	outputFileName := fmt.Sprintf(
-		"conjur-info-%s.json",
-		strings.ToLower(ci.Provider.Name()),
-	)
+	outputFileName := "info.json"
 	_, err = runContext.OutputStore.Save(
 		outputFileName,
 		bytes.NewReader(infoJSONBytes),
@@ -58,7 +58,7 @@ func TestConjurInfoRun(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(
 		t,
-		"conjur-info-test container provider.json",
+		"info.json",
 		outputStoreItemInfo.Name(),
 	)
 
@@ -175,7 +175,7 @@ func TestConjurInfoRun_UnmarshalError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(
 		t,
-		"conjur-info-test container provider.json",
+		"info.json",
 		outputStoreItemInfo.Name(),
 	)
 
@@ -18,6 +18,11 @@ func (ca *ContainerAvailability) Describe() string {
 	return "Container runtime availability"
 }
 
+// ID provides a stable identifier for this check
+func (*ContainerAvailability) ID() string {
+	return "container.availability"
+}
+
 // Run checks the availability of Docker and Podman runtimes
 func (ca *ContainerAvailability) Run(runContext *check.RunContext) []check.Result {
 	// If not already initialized, this shouldn't happen but be safe
@@ -48,32 +53,41 @@ func (ca *ContainerAvailability) Run(runContext *check.RunContext) []check.Resul
 		log.Debug("Podman runtime is not available: %v", podmanAvailability.Error)
 	}
 
-	// Only return results in verbose mode or if no runtimes are available
-	if runContext.VerboseErrors {
-		if !dockerAvailability.Available {
-			results = append(results, check.Result{
-				Title:   "Docker availability",
-				Status:  check.StatusWarn,
-				Value:   "N/A",
-				Message: fmt.Sprintf("Docker is not available: %v", dockerAvailability.Error),
-			})
-		}
+	// Per-runtime availability warnings are only displayed in verbose mode
+	if !dockerAvailability.Available {
+		results = append(results, check.Result{
+			Title:       "Docker availability",
+			Status:      check.StatusWarn,
+			Value:       "N/A",
+			Message:     fmt.Sprintf("Docker is not available: %v", dockerAvailability.Error),
+			Remediation: "If Conjur runs in Docker, install Docker or add it to the PATH",
+			DocURL:      "https://docs.docker.com/engine/install/",
+			Suppressed:  true,
+		})
+	}
 
-		if !podmanAvailability.Available {
-			results = append(results, check.Result{
-				Title:   "Podman availability",
-				Status:  check.StatusWarn,
-				Value:   "N/A",
-				Message: fmt.Sprintf("Podman is not available: %v", podmanAvailability.Error),
-			})
-		}
-	} else if !dockerAvailability.Available && !podmanAvailability.Available {
-		// Warn if no container runtimes are available
+	if !podmanAvailability.Available {
+		results = append(results, check.Result{
+			Title:       "Podman availability",
+			Status:      check.StatusWarn,
+			Value:       "N/A",
+			Message:     fmt.Sprintf("Podman is not available: %v", podmanAvailability.Error),
+			Remediation: "If Conjur runs in Podman, install Podman or add it to the PATH",
+			DocURL:      "https://podman.io/docs/installation",
+			Suppressed:  true,
+		})
+	}
+
+	// Always warn if no container runtimes are available. With verbose errors,
+	// this follows the per-runtime warnings above.
+	if !dockerAvailability.Available && !podmanAvailability.Available {
 		results = append(results, check.Result{
 			Title:   "Container runtimes",
 			Status:  check.StatusWarn,
 			Value:   "N/A",
 			Message: "No container runtimes (Docker or Podman) are available. Container-related checks will be skipped.",
+			Remediation: "Run conjur-inspect as the user that runs the Conjur " +
+				"container, with Docker or Podman in its PATH",
 		})
 	}
 
@@ -95,6 +109,17 @@ func (ca *ContainerAvailability) checkRuntimeAvailability(runtimeName string) ch
 	}
 }
 
+// requiresContainerAvailability is embedded in checks that use a container
+// runtime, so that they run after ContainerAvailability has cached the runtime
+// availability in the RunContext.
+type requiresContainerAvailability struct{}
+
+// DependsOn returns true for the ContainerAvailability check
+func (requiresContainerAvailability) DependsOn(other check.Check) bool {
+	_, ok := other.(*ContainerAvailability)
+	return ok
+}
+
 // IsRuntimeAvailable is a helper function to check if a runtime is available
 // from any check
 func IsRuntimeAvailable(runContext *check.RunContext, runtimeName string) bool {
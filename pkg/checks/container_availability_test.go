@@ -0,0 +1,32 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerAvailabilityNoRuntimes(t *testing.T) {
+	// Neither docker nor podman can be found in an empty PATH
+	t.Setenv("PATH", t.TempDir())
+
+	runContext := &check.RunContext{Context: context.Background()}
+	results := (&ContainerAvailability{}).Run(runContext)
+
+	assert.False(t, IsRuntimeAvailable(runContext, "docker"))
+	assert.False(t, IsRuntimeAvailable(runContext, "podman"))
+
+	// The per-runtime warnings are only shown with verbose errors, while the
+	// summary warning is always shown
+	require.Len(t, results, 3)
+	assert.Equal(t, "Docker availability", results[0].Title)
+	assert.True(t, results[0].Suppressed)
+	assert.Equal(t, "Podman availability", results[1].Title)
+	assert.True(t, results[1].Suppressed)
+	assert.Equal(t, "Container runtimes", results[2].Title)
+	assert.Equal(t, check.StatusWarn, results[2].Status)
+	assert.False(t, results[2].Suppressed)
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/cyberark/conjur-inspect/pkg/checks/sanitize"
 	"github.com/cyberark/conjur-inspect/pkg/container"
 	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/provenance"
 )
 
 // ContainerCommandHistory collects recent command history from inside a container
 type ContainerCommandHistory struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -23,6 +26,11 @@ func (cch *ContainerCommandHistory) Describe() string {
 	return fmt.Sprintf("%s command history", cch.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (cch *ContainerCommandHistory) ID() string {
+	return providerCheckID("container.command-history", cch.Provider)
+}
+
 // Run performs the container command history collection
 func (cch *ContainerCommandHistory) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -33,13 +41,10 @@ func (cch *ContainerCommandHistory) Run(runContext *check.RunContext) []check.Re
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(cch.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cch,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cch,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	containerInstance := cch.Provider.Container(runContext.ContainerID)
@@ -47,6 +52,7 @@ func (cch *ContainerCommandHistory) Run(runContext *check.RunContext) []check.Re
 	// Execute tail command to get last 100 lines of bash history
 	// Use a shell command that won't fail if the file doesn't exist
 	stdout, stderr, err := containerInstance.Exec(
+		runContext.Context,
 		"sh", "-c", "tail -n 100 /root/.bash_history 2>/dev/null || true",
 	)
 	if err != nil {
@@ -76,12 +82,10 @@ func (cch *ContainerCommandHistory) Run(runContext *check.RunContext) []check.Re
 	// Sanitize the history to redact sensitive values
 	redactor := sanitize.NewRedactor()
 	sanitizedContent := redactor.RedactLines(historyContent)
+	provenance.FromContext(runContext.Context).RecordRedactions(redactor.Redactions())
 
 	// Save history to output store
-	outputFileName := fmt.Sprintf(
-		"%s-command-history.txt",
-		strings.ToLower(cch.Provider.Name()),
-	)
+	outputFileName := "command-history.txt"
 	_, err = runContext.OutputStore.Save(
 		outputFileName,
 		strings.NewReader(sanitizedContent),
@@ -49,7 +49,7 @@ func TestContainerCommandHistoryRunSuccessful(t *testing.T) {
 	info, err := items[0].Info()
 	require.NoError(t, err)
 	// Provider name is "Test Container Provider" and gets ToLower() -> "test container provider"
-	assert.Equal(t, "test container provider-command-history.txt", info.Name())
+	assert.Equal(t, "command-history.txt", info.Name())
 }
 
 func TestContainerCommandHistorySanitization(t *testing.T) {
@@ -15,6 +15,8 @@ import (
 
 // ContainerEtcHosts collects the contents of /etc/hosts from inside a container
 type ContainerEtcHosts struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -23,6 +25,11 @@ func (ceh *ContainerEtcHosts) Describe() string {
 	return fmt.Sprintf("%s /etc/hosts", ceh.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (ceh *ContainerEtcHosts) ID() string {
+	return providerCheckID("container.etc-hosts", ceh.Provider)
+}
+
 // Run performs the container /etc/hosts collection
 func (ceh *ContainerEtcHosts) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -33,49 +40,37 @@ func (ceh *ContainerEtcHosts) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(ceh.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ceh,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ceh,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := ceh.Provider.Container(runContext.ContainerID)
 
 	// Execute cat /etc/hosts inside the container
-	stdout, stderr, err := container.Exec("cat", "/etc/hosts")
+	stdout, stderr, err := container.Exec(runContext.Context, "cat", "/etc/hosts")
 	if err != nil {
-		if runContext.VerboseErrors {
-			stderrBytes, _ := io.ReadAll(stderr)
-			return check.ErrorResult(
-				ceh,
-				fmt.Errorf("failed to read /etc/hosts: %w (stderr: %s)", err, string(stderrBytes)),
-			)
-		}
 		log.Warn("failed to read /etc/hosts from container: %s", err)
-		return []check.Result{}
+		stderrBytes, _ := io.ReadAll(stderr)
+		return check.SuppressedErrorResult(
+			ceh,
+			fmt.Errorf("failed to read /etc/hosts: %w (stderr: %s)", err, string(stderrBytes)),
+		)
 	}
 
 	// Read the stdout content
 	fileBytes, err := io.ReadAll(stdout)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ceh,
-				fmt.Errorf("failed to read command output: %w", err),
-			)
-		}
 		log.Warn("failed to read /etc/hosts output: %s", err)
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ceh,
+			fmt.Errorf("failed to read command output: %w", err),
+		)
 	}
 
-	// Save the file contents to output store with runtime-specific filename
-	outputFilename := fmt.Sprintf(
-		"%s-etc-hosts.txt",
-		strings.ToLower(ceh.Provider.Name()),
-	)
+	// Save the file contents to output store
+	outputFilename := "etc-hosts.txt"
 	_, err = runContext.OutputStore.Save(outputFilename, bytes.NewReader(fileBytes))
 	if err != nil {
 		log.Warn("failed to save /etc/hosts output: %s", err)
@@ -45,7 +45,7 @@ func TestContainerEtcHostsRunSuccessful(t *testing.T) {
 	info, err := items[0].Info()
 	require.NoError(t, err)
 	// Test provider name is "Test Container Provider" -> lowercase -> "test container provider"
-	assert.Equal(t, "test container provider-etc-hosts.txt", info.Name())
+	assert.Equal(t, "etc-hosts.txt", info.Name())
 }
 
 func TestContainerEtcHostsEmptyContainerID(t *testing.T) {
@@ -69,7 +69,7 @@ func TestContainerEtcHostsRuntimeNotAvailable(t *testing.T) {
 
 	ceh := &ContainerEtcHosts{Provider: provider}
 	runContext := test.NewRunContext("container123")
-	
+
 	// Set runtime as not available
 	runContext.ContainerRuntimeAvailability = map[string]check.RuntimeAvailability{
 		"test container provider": {
@@ -80,8 +80,11 @@ func TestContainerEtcHostsRuntimeNotAvailable(t *testing.T) {
 
 	results := ceh.Run(&runContext)
 
-	// Should return empty results when runtime not available
-	assert.Empty(t, results)
+	// Expect only a suppressed error result
+	assert.Empty(t, check.Unsuppressed(results))
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, "container runtime not available")
 
 	// Verify no output was saved
 	items, err := runContext.OutputStore.Items()
@@ -89,29 +92,6 @@ func TestContainerEtcHostsRuntimeNotAvailable(t *testing.T) {
 	require.Len(t, items, 0)
 }
 
-func TestContainerEtcHostsRuntimeNotAvailableWithVerboseErrors(t *testing.T) {
-	provider := &test.ContainerProvider{}
-
-	ceh := &ContainerEtcHosts{Provider: provider}
-	runContext := test.NewRunContext("container123")
-	runContext.VerboseErrors = true
-	
-	// Set runtime as not available
-	runContext.ContainerRuntimeAvailability = map[string]check.RuntimeAvailability{
-		"test container provider": {
-			Available: false,
-			Error:     fmt.Errorf("runtime not found"),
-		},
-	}
-
-	results := ceh.Run(&runContext)
-
-	// Should return error result with verbose errors enabled
-	require.Len(t, results, 1)
-	assert.Equal(t, check.StatusError, results[0].Status)
-	assert.Contains(t, results[0].Message, "container runtime not available")
-}
-
 func TestContainerEtcHostsExecError(t *testing.T) {
 	provider := &test.ContainerProvider{
 		ExecResponses: map[string]test.ExecResponse{
@@ -128,8 +108,8 @@ func TestContainerEtcHostsExecError(t *testing.T) {
 
 	results := ceh.Run(&runContext)
 
-	// Should return empty results without verbose errors
-	assert.Empty(t, results)
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 
 	// Verify no output was saved
 	items, err := runContext.OutputStore.Items()
@@ -150,7 +130,6 @@ func TestContainerEtcHostsExecErrorWithVerboseErrors(t *testing.T) {
 
 	ceh := &ContainerEtcHosts{Provider: provider}
 	runContext := test.NewRunContext("container123")
-	runContext.VerboseErrors = true
 
 	results := ceh.Run(&runContext)
 
@@ -15,6 +15,8 @@ import (
 // ContainerInspect collects the output of the container runtime's
 // inspect API and saves it to the output store.
 type ContainerInspect struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -23,6 +25,11 @@ func (ci *ContainerInspect) Describe() string {
 	return fmt.Sprintf("%s inspect", ci.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (ci *ContainerInspect) ID() string {
+	return providerCheckID("container.inspect", ci.Provider)
+}
+
 // Run performs the Docker inspection checks
 func (ci *ContainerInspect) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -33,18 +40,15 @@ func (ci *ContainerInspect) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(ci.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ci,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ci,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := ci.Provider.Container(runContext.ContainerID)
 
-	inspectResult, err := container.Inspect()
+	inspectResult, err := container.Inspect(runContext.Context)
 	if err != nil {
 		return check.ErrorResult(
 			ci,
@@ -67,10 +71,7 @@ func (ci *ContainerInspect) saveOutput(
 	outputStore output.Store,
 	output io.Reader,
 ) error {
-	outputFileName := fmt.Sprintf(
-		"%s-inspect.json",
-		strings.ToLower(ci.Provider.Name()),
-	)
+	outputFileName := "inspect.json"
 	_, err := outputStore.Save(outputFileName, output)
 
 	return err
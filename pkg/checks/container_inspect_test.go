@@ -38,7 +38,7 @@ func TestContainerInspectRun(t *testing.T) {
 
 	itemInfo, err := outputStoreItems[0].Info()
 	assert.NoError(t, err)
-	assert.Equal(t, "test container provider-inspect.json", itemInfo.Name())
+	assert.Equal(t, "inspect.json", itemInfo.Name())
 
 	reader, cleanup, err := outputStoreItems[0].Open()
 	assert.NoError(t, err)
@@ -4,16 +4,20 @@ package checks
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/container"
 	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/output"
 )
 
 // ContainerLogs collects the logs of a given container and saves them to the
 // output store.
 type ContainerLogs struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -22,6 +26,11 @@ func (cl *ContainerLogs) Describe() string {
 	return fmt.Sprintf("%s logs", cl.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (cl *ContainerLogs) ID() string {
+	return providerCheckID("container.logs", cl.Provider)
+}
+
 // Run performs the Docker inspection checks
 func (cl *ContainerLogs) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -32,18 +41,26 @@ func (cl *ContainerLogs) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(cl.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cl,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cl,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := cl.Provider.Container(runContext.ContainerID)
 
-	inspectResult, err := container.Logs(runContext.Since)
+	// Stream the logs straight to the output store, since they may be much
+	// larger than we want to hold in memory
+	outputFileName := "container.log"
+
+	var err error
+	_, saveErr := output.SaveStream(
+		runContext.OutputStore,
+		outputFileName,
+		func(writer io.Writer) {
+			err = container.LogsTo(runContext.Context, writer, runContext.Since)
+		},
+	)
 	if err != nil {
 		return check.ErrorResult(
 			cl,
@@ -51,17 +68,11 @@ func (cl *ContainerLogs) Run(runContext *check.RunContext) []check.Result {
 		)
 	}
 
-	// Save raw container info output
-	outputFileName := fmt.Sprintf(
-		"%s-container.log",
-		strings.ToLower(cl.Provider.Name()),
-	)
-	_, err = runContext.OutputStore.Save(outputFileName, inspectResult)
-	if err != nil {
+	if saveErr != nil {
 		log.Warn(
 			"Failed to save %s container logs: %s",
 			cl.Provider.Name(),
-			err,
+			saveErr,
 		)
 	}
 
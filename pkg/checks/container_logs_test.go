@@ -38,7 +38,7 @@ func TestContainerLogsRun(t *testing.T) {
 
 	itemInfo, err := outputStoreItems[0].Info()
 	assert.NoError(t, err)
-	assert.Equal(t, "test container provider-container.log", itemInfo.Name())
+	assert.Equal(t, "container.log", itemInfo.Name())
 
 	reader, cleanup, err := outputStoreItems[0].Open()
 	assert.NoError(t, err)
@@ -15,6 +15,8 @@ import (
 // ContainerNetworkInspect collects the network inspection data from the
 // container runtime and saves it to the output store.
 type ContainerNetworkInspect struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -23,48 +25,41 @@ func (cni *ContainerNetworkInspect) Describe() string {
 	return fmt.Sprintf("%s network inspect", cni.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (cni *ContainerNetworkInspect) ID() string {
+	return providerCheckID("container.network-inspect", cni.Provider)
+}
+
 // Run performs the network inspection check
 func (cni *ContainerNetworkInspect) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(cni.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cni,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cni,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
-	networkInspectOutput, err := cni.Provider.NetworkInspect()
+	networkInspectOutput, err := cni.Provider.NetworkInspect(runContext.Context)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cni,
-				fmt.Errorf("failed to inspect networks: %w", err),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cni,
+			fmt.Errorf("failed to inspect networks: %w", err),
+		)
 	}
 
 	// Read the output to save it
 	outputBytes, err := io.ReadAll(networkInspectOutput)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cni,
-				fmt.Errorf("failed to read network inspect output: %w", err),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cni,
+			fmt.Errorf("failed to read network inspect output: %w", err),
+		)
 	}
 
 	// Save raw network inspect output
-	outputFileName := fmt.Sprintf(
-		"%s-network-inspect.json",
-		strings.ToLower(cni.Provider.Name()),
-	)
+	outputFileName := "network-inspect.json"
 	_, err = runContext.OutputStore.Save(outputFileName, strings.NewReader(string(outputBytes)))
 	if err != nil {
 		log.Warn(
@@ -11,6 +11,7 @@ import (
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestContainerNetworkInspectRun(t *testing.T) {
@@ -42,7 +43,7 @@ func TestContainerNetworkInspectRun(t *testing.T) {
 
 	itemInfo, err := outputStoreItems[0].Info()
 	assert.NoError(t, err)
-	assert.Equal(t, "test container provider-network-inspect.json", itemInfo.Name())
+	assert.Equal(t, "network-inspect.json", itemInfo.Name())
 
 	reader, cleanup, err := outputStoreItems[0].Open()
 	assert.NoError(t, err)
@@ -109,26 +110,8 @@ func TestContainerNetworkInspectRunError(t *testing.T) {
 		},
 	)
 
-	assert.Empty(t, results)
-}
-
-func TestContainerNetworkInspectRunErrorVerboseErrors(t *testing.T) {
-	testCheck := &ContainerNetworkInspect{
-		Provider: &test.ContainerProvider{
-			NetworkInspectError: errors.New("network inspect failed"),
-		},
-	}
-
-	results := testCheck.Run(
-		&check.RunContext{
-			VerboseErrors: true,
-			ContainerRuntimeAvailability: map[string]check.RuntimeAvailability{
-				"test container provider": {Available: true},
-			},
-		},
-	)
-
-	assert.Len(t, results, 1)
+	assert.Empty(t, check.Unsuppressed(results))
+	require.Len(t, results, 1)
 	assert.Equal(t, check.StatusError, results[0].Status)
 	assert.Contains(t, results[0].Message, "network inspect failed")
 }
@@ -146,24 +129,8 @@ func TestContainerNetworkInspectRuntimeNotAvailable(t *testing.T) {
 		},
 	)
 
-	assert.Empty(t, results)
-}
-
-func TestContainerNetworkInspectRuntimeNotAvailableVerboseErrors(t *testing.T) {
-	testCheck := &ContainerNetworkInspect{
-		Provider: &test.ContainerProvider{},
-	}
-
-	results := testCheck.Run(
-		&check.RunContext{
-			VerboseErrors: true,
-			ContainerRuntimeAvailability: map[string]check.RuntimeAvailability{
-				"test container provider": {Available: false},
-			},
-		},
-	)
-
-	assert.Len(t, results, 1)
+	assert.Empty(t, check.Unsuppressed(results))
+	require.Len(t, results, 1)
 	assert.Equal(t, check.StatusError, results[0].Status)
 	assert.Contains(t, results[0].Message, "runtime not available")
 }
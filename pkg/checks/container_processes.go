@@ -14,6 +14,8 @@ import (
 
 // ContainerProcesses collects the process list from inside a container
 type ContainerProcesses struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -22,6 +24,11 @@ func (cp *ContainerProcesses) Describe() string {
 	return fmt.Sprintf("Container processes (%s)", cp.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (cp *ContainerProcesses) ID() string {
+	return providerCheckID("container.processes", cp.Provider)
+}
+
 // Run performs the container process list collection
 func (cp *ContainerProcesses) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -32,19 +39,17 @@ func (cp *ContainerProcesses) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(cp.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cp,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cp,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	containerInstance := cp.Provider.Container(runContext.ContainerID)
 
 	// Execute ps command to get process list with tree view
 	stdout, stderr, err := containerInstance.Exec(
+		runContext.Context,
 		"ps", "-ef", "--forest",
 	)
 	if err != nil {
@@ -72,10 +77,7 @@ func (cp *ContainerProcesses) Run(runContext *check.RunContext) []check.Result {
 	processOutput := string(processBytes)
 
 	// Save process list to output store
-	outputFileName := fmt.Sprintf(
-		"%s-container-processes.log",
-		strings.ToLower(cp.Provider.Name()),
-	)
+	outputFileName := "processes.log"
 	_, err = runContext.OutputStore.Save(
 		outputFileName,
 		strings.NewReader(processOutput),
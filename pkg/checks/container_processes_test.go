@@ -50,7 +50,7 @@ func TestContainerProcessesRunSuccessful(t *testing.T) {
 	info, err := items[0].Info()
 	require.NoError(t, err)
 	// Provider name is "Test Container Provider" and gets ToLower() -> "test container provider"
-	assert.Equal(t, "test container provider-container-processes.log", info.Name())
+	assert.Equal(t, "processes.log", info.Name())
 
 	// Verify the content was saved correctly
 	outputStoreItemReader, cleanup, err := items[0].Open()
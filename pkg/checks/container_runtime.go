@@ -14,6 +14,8 @@ import (
 // ContainerRuntime collects the information on the version of the
 // container runtime on the system
 type ContainerRuntime struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -22,21 +24,23 @@ func (cr *ContainerRuntime) Describe() string {
 	return fmt.Sprintf("%s runtime", cr.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (cr *ContainerRuntime) ID() string {
+	return providerCheckID("container.runtime", cr.Provider)
+}
+
 // Run performs the Docker inspection checks
 func (cr *ContainerRuntime) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(cr.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				cr,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			cr,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
-	containerInfo, err := cr.Provider.Info()
+	containerInfo, err := cr.Provider.Info(runContext.Context)
 	if err != nil {
 		return check.ErrorResult(
 			cr,
@@ -45,10 +49,7 @@ func (cr *ContainerRuntime) Run(runContext *check.RunContext) []check.Result {
 	}
 
 	// Save raw container info output
-	outputFileName := fmt.Sprintf(
-		"%s-info.json",
-		strings.ToLower(cr.Provider.Name()),
-	)
+	outputFileName := "info.json"
 	_, err = runContext.OutputStore.Save(outputFileName, containerInfo.RawData())
 	if err != nil {
 		log.Warn(
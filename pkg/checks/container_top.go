@@ -14,6 +14,8 @@ import (
 
 // ContainerTop collects resource usage information from inside a container using top
 type ContainerTop struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -22,6 +24,11 @@ func (ct *ContainerTop) Describe() string {
 	return fmt.Sprintf("Container top (%s)", ct.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (ct *ContainerTop) ID() string {
+	return providerCheckID("container.top", ct.Provider)
+}
+
 // Run performs the container top resource usage collection
 func (ct *ContainerTop) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -32,13 +39,10 @@ func (ct *ContainerTop) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(ct.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ct,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ct,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	containerInstance := ct.Provider.Container(runContext.ContainerID)
@@ -47,6 +51,7 @@ func (ct *ContainerTop) Run(runContext *check.RunContext) []check.Result {
 	// -b flag: batch mode (non-interactive)
 	// -n 2: run for 2 iterations
 	stdout, stderr, err := containerInstance.Exec(
+		runContext.Context,
 		"top", "-b", "-c", "-H", "-w", "512", "-n", "1",
 	)
 	if err != nil {
@@ -74,10 +79,7 @@ func (ct *ContainerTop) Run(runContext *check.RunContext) []check.Result {
 	topOutput := string(topBytes)
 
 	// Save top output to output store
-	outputFileName := fmt.Sprintf(
-		"%s-container-top.log",
-		strings.ToLower(ct.Provider.Name()),
-	)
+	outputFileName := "top.log"
 	_, err = runContext.OutputStore.Save(
 		outputFileName,
 		strings.NewReader(topOutput),
@@ -57,7 +57,7 @@ func TestContainerTopRunSuccessful(t *testing.T) {
 	info, err := items[0].Info()
 	require.NoError(t, err)
 	// Provider name is "Test Container Provider" and gets ToLower() -> "test container provider"
-	assert.Equal(t, "test container provider-container-top.log", info.Name())
+	assert.Equal(t, "top.log", info.Name())
 
 	// Verify the content was saved correctly
 	outputStoreItemReader, cleanup, err := items[0].Open()
@@ -112,10 +112,13 @@ func TestContainerTopRuntimeUnavailable(t *testing.T) {
 		},
 	}
 
-	// Without VerboseErrors, should return empty results
-	runContext.VerboseErrors = false
+	// The error result is suppressed
 	results := ct.Run(&runContext)
-	assert.Empty(t, results)
+	assert.Empty(t, check.Unsuppressed(results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "Container top (Test Container Provider)", results[0].Title)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, "container runtime not available")
 
 	// Verify nothing was saved
 	items, err := runContext.OutputStore.Items()
@@ -123,29 +126,6 @@ func TestContainerTopRuntimeUnavailable(t *testing.T) {
 	assert.Len(t, items, 0)
 }
 
-func TestContainerTopRuntimeUnavailableVerboseErrors(t *testing.T) {
-	provider := &test.ContainerProvider{}
-	ct := &ContainerTop{Provider: provider}
-	runContext := test.NewRunContext("container123")
-
-	// Simulate runtime unavailability
-	runContext.ContainerRuntimeAvailability = map[string]check.RuntimeAvailability{
-		"test container provider": {
-			Available: false,
-			Error:     fmt.Errorf("docker not found"),
-		},
-	}
-
-	// With VerboseErrors, should return error result
-	runContext.VerboseErrors = true
-	results := ct.Run(&runContext)
-
-	require.Len(t, results, 1)
-	assert.Equal(t, "Container top (Test Container Provider)", results[0].Title)
-	assert.Equal(t, check.StatusError, results[0].Status)
-	assert.Contains(t, results[0].Message, "container runtime not available")
-}
-
 func TestContainerTopExecError(t *testing.T) {
 	provider := &test.ContainerProvider{
 		ExecResponses: map[string]test.ExecResponse{
@@ -7,6 +7,8 @@ import (
 	"github.com/cyberark/conjur-inspect/pkg/check"
 )
 
+var getNumCPU func() int = runtime.NumCPU
+
 // Cpu collects inspection information on the host machines CPU cores and
 // architecture
 type Cpu struct {
@@ -17,16 +19,30 @@ func (*Cpu) Describe() string {
 	return "CPU"
 }
 
+// ID provides a stable identifier for this check
+func (*Cpu) ID() string {
+	return "host.cpu"
+}
+
 // Run executes the CPU inspection checks
-func (cpu *Cpu) Run(_context *check.RunContext) []check.Result {
+func (cpu *Cpu) Run(runContext *check.RunContext) []check.Result {
+	cores := getNumCPU()
 
 	return []check.Result{
-		{
-			Title:   "CPU Cores",
-			Status:  check.StatusInfo,
-			Value:   strconv.Itoa(runtime.NumCPU()),
-			Message: "",
-		},
+		check.Remediate(
+			check.Result{
+				Title:   "CPU Cores",
+				Status:  runContext.Requirements.Status(cpu.ID(), "cores", float64(cores)),
+				Value:   strconv.Itoa(cores),
+				Message: "",
+				Metrics: []check.Metric{
+					{Name: "cores", Value: float64(cores)},
+				},
+			},
+			"Allocate more CPU cores to the host to meet the Conjur Enterprise "+
+				"hardware requirements",
+			check.SystemRequirementsDocURL,
+		),
 		{
 			Title:  "CPU Architecture",
 			Status: check.StatusInfo,
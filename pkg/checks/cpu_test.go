@@ -15,7 +15,7 @@ func TestCpuRun(t *testing.T) {
 	// Ensure the result includes a CPU Cores value
 	cpuCores := GetResultByTitle(results, "CPU Cores")
 	assert.NotNil(t, cpuCores, "CPU results includes 'CPU Cores'")
-	assert.Equal(t, check.StatusInfo, cpuCores.Status)
+	assert.NotEqual(t, check.StatusError, cpuCores.Status)
 	assert.Regexp(t, regexp.MustCompile(`\d+`), cpuCores.Value, "CPU cores are in the expected format")
 
 	// Ensure the result includes a CPU archiecture value
@@ -24,3 +24,45 @@ func TestCpuRun(t *testing.T) {
 	assert.Equal(t, check.StatusInfo, cpuArchitecture.Status)
 	assert.NotEmpty(t, cpuArchitecture.Value, "CPU architecture is not empty")
 }
+
+func TestCpuRunRequirements(t *testing.T) {
+	originalNumCPU := getNumCPU
+	defer func() {
+		getNumCPU = originalNumCPU
+	}()
+
+	testCases := []struct {
+		cores    int
+		expected string
+	}{
+		{cores: 1, expected: check.StatusFail},
+		{cores: 2, expected: check.StatusWarn},
+		{cores: 4, expected: check.StatusPass},
+	}
+
+	for _, testCase := range testCases {
+		getNumCPU = func() int { return testCase.cores }
+
+		results := (&Cpu{}).Run(&check.RunContext{})
+
+		cpuCores := GetResultByTitle(results, "CPU Cores")
+		assert.NotNil(t, cpuCores, "CPU results includes 'CPU Cores'")
+		assert.Equal(t, testCase.expected, cpuCores.Status)
+		// Only results that don't meet the requirements have a remediation
+		assert.Equal(
+			t,
+			testCase.expected != check.StatusPass,
+			cpuCores.Remediation != "",
+		)
+		assert.Equal(
+			t,
+			testCase.expected != check.StatusPass,
+			cpuCores.DocURL == check.SystemRequirementsDocURL,
+		)
+		assert.Equal(
+			t,
+			[]check.Metric{{Name: "cores", Value: float64(testCase.cores)}},
+			cpuCores.Metrics,
+		)
+	}
+}
@@ -0,0 +1,479 @@
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/container"
+	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/shell"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDeclarativeSection is the report section for declarative checks that
+// don't name one
+const DefaultDeclarativeSection = "Custom"
+
+var declarativeIDPattern = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)*$`)
+
+// DeclarativeDefinition defines a check that runs a command in the Conjur
+// container, saves its output and optionally derives results from it. See
+// LoadDeclarativeDefinitions for the file format.
+type DeclarativeDefinition struct {
+	// ID is the check ID, to which the container runtime name is appended
+	ID string `yaml:"id"`
+
+	// Section is the title of the report section to add the check to. If it
+	// matches a standard section, the check is added to that section.
+	Section string `yaml:"section"`
+
+	Title   string   `yaml:"title"`
+	Command []string `yaml:"command"`
+
+	// User runs the command as the given user, instead of the container's
+	// default user
+	User string `yaml:"user"`
+
+	// Output is the name of the file to save the command's output to. If
+	// empty, the output isn't saved.
+	Output string `yaml:"output"`
+
+	Rules []DeclarativeRule `yaml:"rules"`
+}
+
+// DeclarativeRule derives a result from a declarative check's output. The
+// value is extracted with either Regex (the first capture group, or the whole
+// match if there are none) or JSONPath (e.g. "$.services.database.ok"). The
+// result is FAIL if the value matches FailIf, WARN if it matches WarnIf and
+// PASS otherwise. Without either, the result is informational.
+type DeclarativeRule struct {
+	Title    string `yaml:"title"`
+	Regex    string `yaml:"regex"`
+	JSONPath string `yaml:"json_path"`
+	FailIf   string `yaml:"fail_if"`
+	WarnIf   string `yaml:"warn_if"`
+
+	// Message, Remediation and DocURL are included in WARN and FAIL results
+	Message     string `yaml:"message"`
+	Remediation string `yaml:"remediation"`
+	DocURL      string `yaml:"doc_url"`
+
+	// IfMissing is the status when the regex doesn't match or the JSON path
+	// doesn't exist. It defaults to WARN.
+	IfMissing string `yaml:"if_missing"`
+
+	regex    *regexp.Regexp
+	jsonPath []jsonPathSegment
+	failIf   *regexp.Regexp
+	warnIf   *regexp.Regexp
+}
+
+type jsonPathSegment struct {
+	key   string
+	index int
+
+	isIndex bool
+}
+
+// LoadDeclarativeDefinitions reads the declarative check definitions from a
+// YAML file, for example:
+//
+//	checks:
+//	  - id: conjur.nginx-tls
+//	    section: Conjur
+//	    title: Nginx TLS protocols
+//	    command: [sh, -c, "grep -h ssl_protocols /etc/nginx/sites-enabled/*"]
+//	    output: nginx-ssl-protocols.txt
+//	    rules:
+//	      - title: TLS protocols
+//	        regex: 'ssl_protocols\s+([^;]+);'
+//	        warn_if: 'TLSv1(\.1)?(\s|$)'
+//	        message: TLS 1.0 and 1.1 should be disabled
+func LoadDeclarativeDefinitions(path string) ([]DeclarativeDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read checks file: %w", err)
+	}
+
+	file := struct {
+		Checks []DeclarativeDefinition `yaml:"checks"`
+	}{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&file)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse checks file: %w", err)
+	}
+
+	ids := map[string]bool{}
+	for i := range file.Checks {
+		definition := &file.Checks[i]
+
+		err = definition.compile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid check %d in checks file: %w", i+1, err)
+		}
+
+		if ids[definition.ID] {
+			return nil, fmt.Errorf("duplicate check ID in checks file: %s", definition.ID)
+		}
+		ids[definition.ID] = true
+	}
+
+	return file.Checks, nil
+}
+
+// compile validates the definition and prepares its rules to run
+func (definition *DeclarativeDefinition) compile() error {
+	if !declarativeIDPattern.MatchString(definition.ID) {
+		return fmt.Errorf(
+			"id must be dot-separated lowercase letters, digits and dashes: '%s'",
+			definition.ID,
+		)
+	}
+
+	if definition.Title == "" {
+		return fmt.Errorf("%s: title is required", definition.ID)
+	}
+
+	if len(definition.Command) == 0 {
+		return fmt.Errorf("%s: command is required", definition.ID)
+	}
+
+	if definition.Output != "" &&
+		(filepath.Base(definition.Output) != definition.Output || definition.Output == "..") {
+		return fmt.Errorf(
+			"%s: output must be a file name, not a path: '%s'",
+			definition.ID,
+			definition.Output,
+		)
+	}
+
+	if definition.Section == "" {
+		definition.Section = DefaultDeclarativeSection
+	}
+
+	for i := range definition.Rules {
+		err := definition.Rules[i].compile()
+		if err != nil {
+			return fmt.Errorf("%s: rule %d: %w", definition.ID, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (rule *DeclarativeRule) compile() error {
+	var err error
+
+	if rule.Title == "" {
+		return errors.New("title is required")
+	}
+
+	switch {
+	case rule.Regex != "" && rule.JSONPath != "":
+		return errors.New("only one of regex and json_path may be set")
+	case rule.Regex != "":
+		rule.regex, err = regexp.Compile(rule.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	case rule.JSONPath != "":
+		rule.jsonPath, err = parseJSONPath(rule.JSONPath)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("one of regex and json_path is required")
+	}
+
+	if rule.FailIf != "" {
+		rule.failIf, err = regexp.Compile(rule.FailIf)
+		if err != nil {
+			return fmt.Errorf("invalid fail_if: %w", err)
+		}
+	}
+
+	if rule.WarnIf != "" {
+		rule.warnIf, err = regexp.Compile(rule.WarnIf)
+		if err != nil {
+			return fmt.Errorf("invalid warn_if: %w", err)
+		}
+	}
+
+	rule.IfMissing = strings.ToUpper(rule.IfMissing)
+	switch rule.IfMissing {
+	case "":
+		rule.IfMissing = check.StatusWarn
+	case check.StatusInfo, check.StatusPass, check.StatusWarn, check.StatusFail:
+	default:
+		return fmt.Errorf(
+			"invalid if_missing: %s (must be INFO, PASS, WARN or FAIL)",
+			rule.IfMissing,
+		)
+	}
+
+	return nil
+}
+
+// Declarative is a check defined by a DeclarativeDefinition, run against a
+// container runtime
+type Declarative struct {
+	requiresContainerAvailability
+
+	Definition DeclarativeDefinition
+	Provider   container.ContainerProvider
+}
+
+// Describe provides a textual description of what this check gathers info on
+func (d *Declarative) Describe() string {
+	return fmt.Sprintf("%s (%s)", d.Definition.Title, d.Provider.Name())
+}
+
+// ID provides a stable identifier for this check
+func (d *Declarative) ID() string {
+	return providerCheckID(d.Definition.ID, d.Provider)
+}
+
+// Run executes the defined command in the container and applies the rules to
+// its output
+func (d *Declarative) Run(runContext *check.RunContext) []check.Result {
+	// If there is no container ID, return
+	if strings.TrimSpace(runContext.ContainerID) == "" {
+		return []check.Result{}
+	}
+
+	// Check if the container runtime is available
+	runtimeKey := strings.ToLower(d.Provider.Name())
+	if !IsRuntimeAvailable(runContext, runtimeKey) {
+		return check.SuppressedErrorResult(
+			d,
+			fmt.Errorf("container runtime not available"),
+		)
+	}
+
+	containerInstance := d.Provider.Container(runContext.ContainerID)
+
+	var stdout, stderr io.Reader
+	var err error
+	if d.Definition.User != "" {
+		stdout, stderr, err = containerInstance.ExecAsUser(
+			runContext.Context,
+			d.Definition.User,
+			d.Definition.Command...,
+		)
+	} else {
+		stdout, stderr, err = containerInstance.Exec(
+			runContext.Context,
+			d.Definition.Command...,
+		)
+	}
+
+	if err != nil {
+		return check.ErrorResult(
+			d,
+			fmt.Errorf(
+				"failed to run command: %w (%s)",
+				err,
+				strings.TrimSpace(shell.ReadOrDefault(stderr, "N/A")),
+			),
+		)
+	}
+
+	outputBytes, err := readAllFunc(stdout)
+	if err != nil {
+		return check.ErrorResult(
+			d,
+			fmt.Errorf("failed to read command output: %w", err),
+		)
+	}
+
+	if d.Definition.Output != "" {
+		_, err = runContext.OutputStore.Save(
+			d.Definition.Output,
+			bytes.NewReader(outputBytes),
+		)
+		if err != nil {
+			log.Warn("Failed to save %s output: %s", d.Describe(), err)
+		}
+	}
+
+	results := []check.Result{}
+	for _, rule := range d.Definition.Rules {
+		result, err := rule.evaluate(outputBytes)
+		if err != nil {
+			return check.ErrorResult(d, err)
+		}
+
+		result.Title = fmt.Sprintf("%s (%s)", rule.Title, d.Provider.Name())
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// evaluate returns the result of the rule for the given command output
+func (rule *DeclarativeRule) evaluate(output []byte) (check.Result, error) {
+	value, found, err := rule.extract(output)
+	if err != nil {
+		return check.Result{}, err
+	}
+
+	if !found {
+		result := check.Result{
+			Value:   "N/A",
+			Status:  rule.IfMissing,
+			Message: fmt.Sprintf("no value found for %s", rule.source()),
+		}
+
+		return check.Remediate(result, rule.Remediation, rule.DocURL), nil
+	}
+
+	result := check.Result{Value: value}
+
+	switch {
+	case rule.failIf != nil && rule.failIf.MatchString(value):
+		result.Status = check.StatusFail
+		result.Message = rule.Message
+	case rule.warnIf != nil && rule.warnIf.MatchString(value):
+		result.Status = check.StatusWarn
+		result.Message = rule.Message
+	case rule.failIf != nil || rule.warnIf != nil:
+		result.Status = check.StatusPass
+	default:
+		result.Status = check.StatusInfo
+	}
+
+	return check.Remediate(result, rule.Remediation, rule.DocURL), nil
+}
+
+// extract returns the value selected by the rule's regex or JSON path, and
+// whether it was found
+func (rule *DeclarativeRule) extract(output []byte) (string, bool, error) {
+	if rule.regex != nil {
+		match := rule.regex.FindSubmatch(output)
+		switch {
+		case match == nil:
+			return "", false, nil
+		case len(match) > 1:
+			return strings.TrimSpace(string(match[1])), true, nil
+		default:
+			return strings.TrimSpace(string(match[0])), true, nil
+		}
+	}
+
+	var data any
+	err := json.Unmarshal(output, &data)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse command output as JSON: %w", err)
+	}
+
+	value, found := lookupJSONPath(data, rule.jsonPath)
+	if !found {
+		return "", false, nil
+	}
+
+	if text, ok := value.(string); ok {
+		return text, true, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(encoded), true, nil
+}
+
+func (rule *DeclarativeRule) source() string {
+	if rule.regex != nil {
+		return fmt.Sprintf("regex '%s'", rule.Regex)
+	}
+
+	return fmt.Sprintf("JSON path '%s'", rule.JSONPath)
+}
+
+// parseJSONPath parses the subset of JSONPath used by declarative rules: an
+// optional "$" followed by ".key" and "[index]" segments.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	rest := strings.TrimPrefix(path, "$")
+	segments := []jsonPathSegment{}
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("invalid json_path '%s': empty key", path)
+			}
+
+			segments = append(segments, jsonPathSegment{key: key})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("invalid json_path '%s': missing ']'", path)
+			}
+
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf(
+					"invalid json_path '%s': index must be a non-negative integer",
+					path,
+				)
+			}
+
+			segments = append(segments, jsonPathSegment{index: index, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf(
+				"invalid json_path '%s': expected '.' or '[' at '%s'",
+				path,
+				rest,
+			)
+		}
+	}
+
+	return segments, nil
+}
+
+func lookupJSONPath(data any, segments []jsonPathSegment) (any, bool) {
+	for _, segment := range segments {
+		if segment.isIndex {
+			array, ok := data.([]any)
+			if !ok || segment.index >= len(array) {
+				return nil, false
+			}
+
+			data = array[segment.index]
+			continue
+		}
+
+		object, ok := data.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		data, ok = object[segment.key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return data, true
+}
@@ -0,0 +1,313 @@
+package checks
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDeclarativeChecks = `
+checks:
+  - id: conjur.nginx-tls
+    section: Conjur
+    title: Nginx TLS
+    command: [sh, -c, "grep -h ssl_protocols /etc/nginx/sites-enabled/*"]
+    output: nginx-ssl-protocols.txt
+    rules:
+      - title: TLS protocols
+        regex: 'ssl_protocols\s+([^;]+);'
+        warn_if: 'TLSv1(\.1)?(\s|$)'
+        message: TLS 1.0 and 1.1 should be disabled
+        remediation: Remove TLSv1 and TLSv1.1 from ssl_protocols
+  - id: conjur.services
+    title: Services
+    command: [evoke, info]
+    user: conjur
+    rules:
+      - title: Database
+        json_path: $.services.database.ok
+        fail_if: '^false$'
+      - title: Replicas
+        json_path: $.database.replicas[1].name
+      - title: Version
+        json_path: $.version
+        if_missing: info
+`
+
+func TestLoadDeclarativeDefinitions(t *testing.T) {
+	definitions := loadTestDeclarativeDefinitions(t, testDeclarativeChecks)
+
+	require.Len(t, definitions, 2)
+
+	assert.Equal(t, "conjur.nginx-tls", definitions[0].ID)
+	assert.Equal(t, "Conjur", definitions[0].Section)
+	assert.Equal(t, check.StatusWarn, definitions[0].Rules[0].IfMissing)
+
+	// Checks without a section are added to the default section
+	assert.Equal(t, DefaultDeclarativeSection, definitions[1].Section)
+	assert.Equal(t, check.StatusInfo, definitions[1].Rules[2].IfMissing)
+}
+
+func TestLoadDeclarativeDefinitionsErrors(t *testing.T) {
+	testCases := []struct {
+		contents string
+		err      string
+	}{
+		{
+			contents: "checks:\n  - id: Bad ID\n    title: T\n    command: [ls]\n",
+			err:      "id must be dot-separated lowercase letters, digits and dashes: 'Bad ID'",
+		},
+		{
+			contents: "checks:\n  - id: a\n    command: [ls]\n",
+			err:      "a: title is required",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n",
+			err:      "a: command is required",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n    output: ../a.txt\n",
+			err:      "a: output must be a file name, not a path: '../a.txt'",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n  - id: a\n    title: T\n    command: [ls]\n",
+			err:      "duplicate check ID in checks file: a",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n    rules:\n      - title: R\n",
+			err:      "a: rule 1: one of regex and json_path is required",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n    rules:\n      - title: R\n        regex: x\n        json_path: $.x\n",
+			err:      "a: rule 1: only one of regex and json_path may be set",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n    rules:\n      - title: R\n        regex: '('\n",
+			err:      "a: rule 1: invalid regex",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n    rules:\n      - title: R\n        json_path: $.x[a]\n",
+			err:      "invalid json_path '$.x[a]': index must be a non-negative integer",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n    rules:\n      - title: R\n        regex: x\n        if_missing: ERROR\n",
+			err:      "invalid if_missing: ERROR (must be INFO, PASS, WARN or FAIL)",
+		},
+		{
+			contents: "checks:\n  - id: a\n    title: T\n    command: [ls]\n    timeout: 5\n",
+			err:      "unable to parse checks file",
+		},
+	}
+
+	for _, testCase := range testCases {
+		path := filepath.Join(t.TempDir(), "checks.yml")
+		require.NoError(t, os.WriteFile(path, []byte(testCase.contents), 0600))
+
+		_, err := LoadDeclarativeDefinitions(path)
+		assert.ErrorContains(t, err, testCase.err)
+	}
+
+	_, err := LoadDeclarativeDefinitions(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.ErrorContains(t, err, "unable to read checks file")
+}
+
+func TestDeclarativeRunRegex(t *testing.T) {
+	definitions := loadTestDeclarativeDefinitions(t, testDeclarativeChecks)
+
+	output := "ssl_protocols TLSv1 TLSv1.2;\n"
+	declarative := &Declarative{
+		Definition: definitions[0],
+		Provider: &test.ContainerProvider{
+			ExecResponses: map[string]test.ExecResponse{
+				"sh -c grep -h ssl_protocols /etc/nginx/sites-enabled/*": {
+					Stdout: strings.NewReader(output),
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, "conjur.nginx-tls.test-container-provider", declarative.ID())
+	assert.Equal(t, "Nginx TLS (Test Container Provider)", declarative.Describe())
+
+	runContext := test.NewRunContext("test-container-id")
+	results := declarative.Run(&runContext)
+
+	assert.Equal(
+		t,
+		[]check.Result{
+			{
+				Title:       "TLS protocols (Test Container Provider)",
+				Value:       "TLSv1 TLSv1.2",
+				Status:      check.StatusWarn,
+				Message:     "TLS 1.0 and 1.1 should be disabled",
+				Remediation: "Remove TLSv1 and TLSv1.1 from ssl_protocols",
+			},
+		},
+		results,
+	)
+
+	items, err := runContext.OutputStore.Items()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	info, err := items[0].Info()
+	require.NoError(t, err)
+	assert.Equal(t, "nginx-ssl-protocols.txt", info.Name())
+
+	reader, cleanup, err := items[0].Open()
+	require.NoError(t, err)
+	defer cleanup()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, output, string(data))
+}
+
+func TestDeclarativeRunJSONPath(t *testing.T) {
+	definitions := loadTestDeclarativeDefinitions(t, testDeclarativeChecks)
+
+	declarative := &Declarative{
+		Definition: definitions[1],
+		Provider: &test.ContainerProvider{
+			ExecAsUserResponses: map[string]test.ExecResponse{
+				"conjur evoke info": {
+					Stdout: strings.NewReader(`{
+						"services": {"database": {"ok": false}},
+						"database": {"replicas": [{"name": "a"}, {"name": "b"}]}
+					}`),
+				},
+			},
+		},
+	}
+
+	runContext := test.NewRunContext("test-container-id")
+	results := declarative.Run(&runContext)
+
+	assert.Equal(
+		t,
+		[]check.Result{
+			{
+				Title:  "Database (Test Container Provider)",
+				Value:  "false",
+				Status: check.StatusFail,
+			},
+			{
+				Title:  "Replicas (Test Container Provider)",
+				Value:  "b",
+				Status: check.StatusInfo,
+			},
+			{
+				Title:   "Version (Test Container Provider)",
+				Value:   "N/A",
+				Status:  check.StatusInfo,
+				Message: "no value found for JSON path '$.version'",
+			},
+		},
+		results,
+	)
+
+	// Without an output file name, the output isn't saved
+	items, err := runContext.OutputStore.Items()
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestDeclarativeRunInvalidJSON(t *testing.T) {
+	definitions := loadTestDeclarativeDefinitions(t, testDeclarativeChecks)
+
+	declarative := &Declarative{
+		Definition: definitions[1],
+		Provider: &test.ContainerProvider{
+			ExecAsUserResponses: map[string]test.ExecResponse{
+				"conjur evoke info": {Stdout: strings.NewReader("not json")},
+			},
+		},
+	}
+
+	runContext := test.NewRunContext("test-container-id")
+	results := declarative.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, "failed to parse command output as JSON")
+}
+
+func TestDeclarativeRunExecError(t *testing.T) {
+	definitions := loadTestDeclarativeDefinitions(t, testDeclarativeChecks)
+
+	declarative := &Declarative{
+		Definition: definitions[0],
+		Provider: &test.ContainerProvider{
+			ExecResponses: map[string]test.ExecResponse{
+				"sh -c grep -h ssl_protocols /etc/nginx/sites-enabled/*": {
+					Error:  errors.New("exit status 2"),
+					Stderr: strings.NewReader("No such file or directory"),
+				},
+			},
+		},
+	}
+
+	runContext := test.NewRunContext("test-container-id")
+	results := declarative.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Equal(
+		t,
+		"failed to run command: exit status 2 (No such file or directory)",
+		results[0].Message,
+	)
+}
+
+func TestDeclarativeRunNoContainerID(t *testing.T) {
+	definitions := loadTestDeclarativeDefinitions(t, testDeclarativeChecks)
+
+	declarative := &Declarative{
+		Definition: definitions[0],
+		Provider:   &test.ContainerProvider{},
+	}
+
+	runContext := test.NewRunContext("")
+	assert.Empty(t, declarative.Run(&runContext))
+}
+
+func TestDeclarativeRunRuntimeUnavailable(t *testing.T) {
+	definitions := loadTestDeclarativeDefinitions(t, testDeclarativeChecks)
+
+	declarative := &Declarative{
+		Definition: definitions[0],
+		Provider:   &test.ContainerProvider{},
+	}
+
+	runContext := test.NewRunContext("test-container-id")
+	runContext.ContainerRuntimeAvailability = map[string]check.RuntimeAvailability{
+		"test container provider": {Available: false},
+	}
+
+	results := declarative.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.True(t, results[0].Suppressed)
+}
+
+func loadTestDeclarativeDefinitions(
+	t *testing.T,
+	contents string,
+) []DeclarativeDefinition {
+	path := filepath.Join(t.TempDir(), "checks.yml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	definitions, err := LoadDeclarativeDefinitions(path)
+	require.NoError(t, err)
+
+	return definitions
+}
@@ -1,23 +1,28 @@
 package fio
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 
+	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/log"
 	"github.com/cyberark/conjur-inspect/pkg/shell"
 )
 
 const fioExecutable = "fio"
 
-var executeFioFunc func(args ...string) (stdout, stderr io.Reader, err error) = executeFio
+var executeFioFunc func(
+	ctx context.Context,
+	args ...string,
+) (stdout, stderr io.Reader, err error) = executeFio
 
 // Executable represents an operation that can produce an fio result and
 // emit raw output data.
 type Executable interface {
-	Exec() (*Result, error)
+	Exec(ctx context.Context, cleanups *check.Cleanups) (*Result, error)
 	OnRawOutput(func([]byte))
 }
 
@@ -43,19 +48,24 @@ func NewJob(name string, args []string) Executable {
 	}
 }
 
-// Exec runs the given fio job in a temporary directory
-func (job *Job) Exec() (*Result, error) {
+// Exec runs the given fio job in a temporary directory. The fio process is
+// killed if the context is done before the job completes. The directory is
+// removed when the given cleanup hooks run.
+func (job *Job) Exec(
+	ctx context.Context,
+	cleanups *check.Cleanups,
+) (*Result, error) {
 	// Create the directory for running the fio test. We have this return the
-	// cleanup method as well to simplify deferring this task when the function
-	// finishes.
+	// cleanup method as well to simplify registering it as a cleanup hook, so
+	// the directory is removed even if the run is interrupted.
 	cleanup, err := usingJobDirectory(job.Name)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create test directory: %w", err)
 	}
-	defer cleanup()
+	cleanups.Add(func(context.Context) { cleanup() })
 
 	// Run 'fio' command
-	stdout, stderr, err := executeFioFunc(job.Args...)
+	stdout, stderr, err := executeFioFunc(ctx, job.Args...)
 	if err != nil {
 		log.Debug("Unable to execute 'fio' job:")
 
@@ -108,6 +118,9 @@ func usingJobDirectory(jobName string) (func(), error) {
 	}, nil
 }
 
-func executeFio(args ...string) (stdout, stderr io.Reader, err error) {
-	return shell.NewCommandWrapper(fioExecutable, args...).Run()
+func executeFio(
+	ctx context.Context,
+	args ...string,
+) (stdout, stderr io.Reader, err error) {
+	return shell.NewCommandWrapper(fioExecutable, args...).Run(ctx)
 }
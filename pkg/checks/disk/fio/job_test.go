@@ -1,11 +1,13 @@
 package fio
 
 import (
+	"context"
 	"errors"
 	"io"
 	"strings"
 	"testing"
 
+	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,11 +33,17 @@ func TestJobExec(t *testing.T) {
 		},
 	}
 
-	result, err := exec.Exec()
+	cleanups := &check.Cleanups{}
+	result, err := exec.Exec(context.Background(), cleanups)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, expectedOutput, string(outputDestination))
+
+	// The job directory is removed by the cleanup hooks
+	assert.DirExists(t, "test_job")
+	cleanups.Run(context.Background())
+	assert.NoDirExists(t, "test_job")
 }
 
 func TestJobExecCommandError(t *testing.T) {
@@ -47,7 +55,10 @@ func TestJobExecCommandError(t *testing.T) {
 
 	exec := NewJob("test_job", testJobArgs)
 
-	_, err := exec.Exec()
+	cleanups := &check.Cleanups{}
+	defer cleanups.Run(context.Background())
+
+	_, err := exec.Exec(context.Background(), cleanups)
 	assert.ErrorContains(t, err, "unable to execute 'fio' job:")
 }
 
@@ -60,7 +71,10 @@ func TestJobExecParseError(t *testing.T) {
 
 	exec := NewJob("test_job", testJobArgs)
 
-	_, err := exec.Exec()
+	cleanups := &check.Cleanups{}
+	defer cleanups.Run(context.Background())
+
+	_, err := exec.Exec(context.Background(), cleanups)
 	assert.ErrorContains(t, err, "unable to parse 'fio' output:")
 }
 
@@ -76,8 +90,8 @@ func mockExecuteFioFunc(
 	stdout string,
 	stderr string,
 	err error,
-) func(args ...string) (io.Reader, io.Reader, error) {
-	return func(args ...string) (io.Reader, io.Reader, error) {
+) func(context.Context, ...string) (io.Reader, io.Reader, error) {
+	return func(context.Context, ...string) (io.Reader, io.Reader, error) {
 		return strings.NewReader(stdout), strings.NewReader(stderr), err
 	}
 }
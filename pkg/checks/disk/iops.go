@@ -4,15 +4,32 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/checks/disk/fio"
 	"github.com/cyberark/conjur-inspect/pkg/log"
-	"github.com/cyberark/conjur-inspect/pkg/output"
 )
 
+// iopsCheckID is the ID of the IOPS check and of its requirements
+const iopsCheckID = "disk.fio.iops"
+
 const iopsJobName = "conjur-fio-iops"
 
+// StorageRemediation is the remediation for disk performance that doesn't meet
+// the requirements. Conjur's database and etcd both write synchronously, so
+// slow storage affects the whole cluster.
+const StorageRemediation = "Use faster storage, such as local SSDs, for the " +
+	"Conjur data directory"
+
+// StorageDocURL links to the etcd hardware recommendations, which the default
+// disk performance requirements are based on
+const StorageDocURL = "https://etcd.io/docs/v3.3/op-guide/hardware/"
+
+// fioTimeout allows for fio laying out its test files on slow disks before
+// running the job
+const fioTimeout = 5 * time.Minute
+
 // IopsCheck is a inspection check to report the read and write IOPs for the
 // directory in which `conjur-inspect` is run.
 type IopsCheck struct {
@@ -36,26 +53,38 @@ func (*IopsCheck) Describe() string {
 	return "disk IOPs"
 }
 
+// ID provides a stable identifier for this check
+func (*IopsCheck) ID() string {
+	return iopsCheckID
+}
+
+// Exclusive ensures the fio job runs without other checks adding load to the
+// disk
+func (*IopsCheck) Exclusive() bool {
+	return true
+}
+
+// Timeout allows the fio job to run longer than other checks
+func (*IopsCheck) Timeout() time.Duration {
+	return fioTimeout
+}
+
 // Run executes the IopsCheck by running `fio` and processing its output
 func (iopsCheck *IopsCheck) Run(
 	runContext *check.RunContext,
 ) []check.Result {
-	fioResult, err := iopsCheck.runFioIopsTest(
-		runContext.OutputStore,
-	)
+	fioResult, err := iopsCheck.runFioIopsTest(runContext)
 
 	if err != nil {
-		if runContext.VerboseErrors {
-			return []check.Result{
-				{
-					Title:   "FIO IOPs",
-					Status:  check.StatusError,
-					Value:   "N/A",
-					Message: err.Error(),
-				},
-			}
+		return []check.Result{
+			{
+				Title:      "FIO IOPs",
+				Status:     check.StatusError,
+				Value:      "N/A",
+				Message:    err.Error(),
+				Suppressed: true,
+			},
 		}
-		return []check.Result{}
 	}
 
 	// Make sure a job exists in the fio results
@@ -71,18 +100,16 @@ func (iopsCheck *IopsCheck) Run(
 	}
 
 	return []check.Result{
-		fioReadIopsResult(&fioResult.Jobs[0]),
-		fioWriteIopsResult(&fioResult.Jobs[0]),
+		fioReadIopsResult(&fioResult.Jobs[0], runContext.Requirements),
+		fioWriteIopsResult(&fioResult.Jobs[0], runContext.Requirements),
 	}
 }
 
-func fioReadIopsResult(job *fio.JobResult) check.Result {
-
-	// 50 iops min from https://etcd.io/docs/v3.3/op-guide/hardware/
-	status := check.StatusInfo
-	if job.Read.Iops < 50 {
-		status = check.StatusWarn
-	}
+func fioReadIopsResult(
+	job *fio.JobResult,
+	requirements check.Requirements,
+) check.Result {
+	status := requirements.Status(iopsCheckID, "read_iops", job.Read.Iops)
 
 	// Format title
 	path, err := getWorkingDirectory()
@@ -101,20 +128,28 @@ func fioReadIopsResult(job *fio.JobResult) check.Result {
 		job.Read.IopsStddev,
 	)
 
-	return check.Result{
-		Title:  titleStr,
-		Status: status,
-		Value:  valueStr,
-	}
+	return check.Remediate(
+		check.Result{
+			Title:  titleStr,
+			Status: status,
+			Value:  valueStr,
+			Metrics: []check.Metric{
+				{Name: "read_iops", Value: job.Read.Iops, Unit: "iops"},
+				{Name: "read_iops_min", Value: float64(job.Read.IopsMin), Unit: "iops"},
+				{Name: "read_iops_max", Value: float64(job.Read.IopsMax), Unit: "iops"},
+				{Name: "read_iops_stddev", Value: job.Read.IopsStddev, Unit: "iops"},
+			},
+		},
+		StorageRemediation,
+		StorageDocURL,
+	)
 }
 
-func fioWriteIopsResult(job *fio.JobResult) check.Result {
-
-	// 50 iops min from https://etcd.io/docs/v3.3/op-guide/hardware/
-	status := check.StatusInfo
-	if job.Write.Iops < 50 {
-		status = check.StatusWarn
-	}
+func fioWriteIopsResult(
+	job *fio.JobResult,
+	requirements check.Requirements,
+) check.Result {
+	status := requirements.Status(iopsCheckID, "write_iops", job.Write.Iops)
 
 	// Format title
 	path, err := getWorkingDirectory()
@@ -133,15 +168,25 @@ func fioWriteIopsResult(job *fio.JobResult) check.Result {
 		job.Write.IopsStddev,
 	)
 
-	return check.Result{
-		Title:  titleStr,
-		Status: status,
-		Value:  valueStr,
-	}
+	return check.Remediate(
+		check.Result{
+			Title:  titleStr,
+			Status: status,
+			Value:  valueStr,
+			Metrics: []check.Metric{
+				{Name: "write_iops", Value: job.Write.Iops, Unit: "iops"},
+				{Name: "write_iops_min", Value: float64(job.Write.IopsMin), Unit: "iops"},
+				{Name: "write_iops_max", Value: float64(job.Write.IopsMax), Unit: "iops"},
+				{Name: "write_iops_stddev", Value: job.Write.IopsStddev, Unit: "iops"},
+			},
+		},
+		StorageRemediation,
+		StorageDocURL,
+	)
 }
 
 func (iopsCheck *IopsCheck) runFioIopsTest(
-	store output.Store,
+	runContext *check.RunContext,
 ) (*fio.Result, error) {
 	job := iopsCheck.fioNewJob(
 		iopsJobName,
@@ -164,8 +209,8 @@ func (iopsCheck *IopsCheck) runFioIopsTest(
 
 	// Save the full `fio` output to the results store
 	job.OnRawOutput(func(data []byte) {
-		store.Save(iopsJobName, bytes.NewReader(data))
+		runContext.OutputStore.Save("fio.json", bytes.NewReader(data))
 	})
 
-	return job.Exec()
+	return job.Exec(runContext.Context, runContext.Cleanups)
 }
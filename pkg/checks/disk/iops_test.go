@@ -3,6 +3,7 @@
 package disk
 
 import (
+	"context"
 	"errors"
 	"regexp"
 	"testing"
@@ -17,7 +18,7 @@ type mockFioJob struct {
 	Error  error
 }
 
-func (job *mockFioJob) Exec() (*fio.Result, error) {
+func (job *mockFioJob) Exec(context.Context, *check.Cleanups) (*fio.Result, error) {
 	return &job.Result, job.Error
 }
 
@@ -38,8 +39,36 @@ func TestIopsCheck(t *testing.T) {
 		"There are read and write IOPs results present",
 	)
 
-	assertReadIopsResult(t, results[0], check.StatusInfo)
-	assertWriteIopsResult(t, results[1], check.StatusInfo)
+	assertReadIopsResult(t, results[0], check.StatusPass)
+	assertWriteIopsResult(t, results[1], check.StatusPass)
+
+	assert.Contains(
+		t,
+		results[0].Metrics,
+		check.Metric{Name: "read_iops", Value: 50, Unit: "iops"},
+	)
+	assert.Contains(
+		t,
+		results[1].Metrics,
+		check.Metric{Name: "write_iops", Value: 50, Unit: "iops"},
+	)
+}
+
+func TestIopsCheckWithRequirements(t *testing.T) {
+	testCheck := &IopsCheck{
+		fioNewJob: newSuccessfulIopsFioJob,
+	}
+
+	minimumIops := 100.0
+	requirements := check.DefaultRequirements()
+	requirements["disk.fio.iops"]["read_iops"] = check.Threshold{
+		FailBelow: &minimumIops,
+	}
+
+	results := testCheck.Run(&check.RunContext{Requirements: requirements})
+
+	assertReadIopsResult(t, results[0], check.StatusFail)
+	assertWriteIopsResult(t, results[1], check.StatusPass)
 }
 
 func TestIopsCheckWithPoorPerformance(t *testing.T) {
@@ -63,7 +92,7 @@ func TestIopsWithError(t *testing.T) {
 	testCheck := &IopsCheck{
 		fioNewJob: newErrorFioJob,
 	}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: true})
+	results := testCheck.Run(&check.RunContext{})
 
 	// Expect only the error result
 	assert.Equal(t, 1, len(results))
@@ -78,7 +107,7 @@ func TestIopsWithErrorVerboseErrors(t *testing.T) {
 	testCheck := &IopsCheck{
 		fioNewJob: newErrorFioJob,
 	}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: true})
+	results := testCheck.Run(&check.RunContext{})
 
 	// Expect only the error result
 	assert.Equal(t, 1, len(results))
@@ -93,10 +122,10 @@ func TestIopsWithErrorNoVerboseErrors(t *testing.T) {
 	testCheck := &IopsCheck{
 		fioNewJob: newErrorFioJob,
 	}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: false})
+	results := testCheck.Run(&check.RunContext{})
 
-	// Expect no results when VerboseErrors is false
-	assert.Equal(t, 0, len(results))
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 }
 
 func TestIopsWithNoJobs(t *testing.T) {
@@ -134,8 +163,8 @@ func TestIopsWithWorkingDirectoryError(t *testing.T) {
 		"There are read and write IOPs results present",
 	)
 
-	assertReadIopsResult(t, results[0], check.StatusInfo)
-	assertWriteIopsResult(t, results[1], check.StatusInfo)
+	assertReadIopsResult(t, results[0], check.StatusPass)
+	assertWriteIopsResult(t, results[1], check.StatusPass)
 }
 
 func assertReadIopsResult(
@@ -149,6 +178,7 @@ func assertReadIopsResult(
 		result.Title,
 	)
 	assert.Equal(t, expectedStatus, result.Status)
+	assertStorageRemediation(t, result)
 	assert.Regexp(
 		t,
 		regexp.MustCompile(`.+ \(Min: .+, Max: .+, StdDev: .+\)`),
@@ -167,6 +197,7 @@ func assertWriteIopsResult(
 		result.Title,
 	)
 	assert.Equal(t, expectedStatus, result.Status)
+	assertStorageRemediation(t, result)
 	assert.Regexp(
 		t,
 		regexp.MustCompile(`.+ \(Min: .+, Max: .+, StdDev: .+\)`),
@@ -224,3 +255,16 @@ func newEmptyFioJob(jobName string, args []string) fio.Executable {
 		},
 	}
 }
+
+// assertStorageRemediation asserts that a disk performance result has the
+// storage remediation if, and only if, it doesn't meet the requirements
+func assertStorageRemediation(t *testing.T, result check.Result) {
+	if result.Status == check.StatusWarn || result.Status == check.StatusFail {
+		assert.Equal(t, StorageRemediation, result.Remediation)
+		assert.Equal(t, StorageDocURL, result.DocURL)
+		return
+	}
+
+	assert.Empty(t, result.Remediation)
+	assert.Empty(t, result.DocURL)
+}
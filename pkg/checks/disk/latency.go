@@ -3,13 +3,16 @@ package disk
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/checks/disk/fio"
 	"github.com/cyberark/conjur-inspect/pkg/log"
-	"github.com/cyberark/conjur-inspect/pkg/output"
 )
 
+// latencyCheckID is the ID of the latency check and of its requirements
+const latencyCheckID = "disk.fio.latency"
+
 // LatencyCheck is a inspection check to report the read, write, and sync
 // latency for the directory in which `conjur-inspect` is run.
 type LatencyCheck struct {
@@ -32,26 +35,38 @@ func (*LatencyCheck) Describe() string {
 	return "disk latency"
 }
 
+// ID provides a stable identifier for this check
+func (*LatencyCheck) ID() string {
+	return latencyCheckID
+}
+
+// Exclusive ensures the fio job runs without other checks adding load to the
+// disk
+func (*LatencyCheck) Exclusive() bool {
+	return true
+}
+
+// Timeout allows for slow synchronous writes on the disk under test
+func (*LatencyCheck) Timeout() time.Duration {
+	return fioTimeout
+}
+
 // Run executes the LatencyCheck by running `fio` and processing its output
 func (latencyCheck *LatencyCheck) Run(
 	runContext *check.RunContext,
 ) []check.Result {
-	fioResult, err := latencyCheck.runFioLatencyTest(
-		runContext.OutputStore,
-	)
+	fioResult, err := latencyCheck.runFioLatencyTest(runContext)
 
 	if err != nil {
-		if runContext.VerboseErrors {
-			return []check.Result{
-				{
-					Title:   "FIO Latency",
-					Status:  check.StatusError,
-					Value:   "N/A",
-					Message: err.Error(),
-				},
-			}
+		return []check.Result{
+			{
+				Title:      "FIO Latency",
+				Status:     check.StatusError,
+				Value:      "N/A",
+				Message:    err.Error(),
+				Suppressed: true,
+			},
 		}
-		return []check.Result{}
 	}
 
 	// Make sure a job exists in the fio results
@@ -67,22 +82,22 @@ func (latencyCheck *LatencyCheck) Run(
 	}
 
 	return []check.Result{
-		fioReadLatencyResult(&fioResult.Jobs[0]),
-		fioWriteLatencyResult(&fioResult.Jobs[0]),
-		fioSyncLatencyResult(&fioResult.Jobs[0]),
+		fioReadLatencyResult(&fioResult.Jobs[0], runContext.Requirements),
+		fioWriteLatencyResult(&fioResult.Jobs[0], runContext.Requirements),
+		fioSyncLatencyResult(&fioResult.Jobs[0], runContext.Requirements),
 	}
 }
 
-func fioReadLatencyResult(jobResult *fio.JobResult) check.Result {
+func fioReadLatencyResult(
+	jobResult *fio.JobResult,
+	requirements check.Requirements,
+) check.Result {
 	// Convert the nanosecond result to milliseconds for readability
 	latMs := float64(jobResult.Read.LatNs.Percentile.NinetyNinth) / 1e6
 
 	latMsStr := fmt.Sprintf("%0.2f ms", latMs)
 
-	status := check.StatusInfo
-	if latMs > 10.0 {
-		status = check.StatusWarn
-	}
+	status := requirements.Status(latencyCheckID, "read_p99_ms", latMs)
 
 	path, err := getWorkingDirectory()
 	if err != nil {
@@ -90,23 +105,30 @@ func fioReadLatencyResult(jobResult *fio.JobResult) check.Result {
 		path = "working directory"
 	}
 
-	return check.Result{
-		Title:  fmt.Sprintf("FIO - Read Latency (99%%, %s)", path),
-		Status: status,
-		Value:  latMsStr,
-	}
+	return check.Remediate(
+		check.Result{
+			Title:  fmt.Sprintf("FIO - Read Latency (99%%, %s)", path),
+			Status: status,
+			Value:  latMsStr,
+			Metrics: []check.Metric{
+				{Name: "read_p99_ms", Value: latMs, Unit: "ms"},
+			},
+		},
+		StorageRemediation,
+		StorageDocURL,
+	)
 }
 
-func fioWriteLatencyResult(jobResult *fio.JobResult) check.Result {
+func fioWriteLatencyResult(
+	jobResult *fio.JobResult,
+	requirements check.Requirements,
+) check.Result {
 	// Convert the nanosecond result to milliseconds for readability
 	latMs := float64(jobResult.Write.LatNs.Percentile.NinetyNinth) / 1e6
 
 	latMsStr := fmt.Sprintf("%0.2f ms", latMs)
 
-	status := check.StatusInfo
-	if latMs > 10.0 {
-		status = check.StatusWarn
-	}
+	status := requirements.Status(latencyCheckID, "write_p99_ms", latMs)
 
 	path, err := getWorkingDirectory()
 	if err != nil {
@@ -114,23 +136,30 @@ func fioWriteLatencyResult(jobResult *fio.JobResult) check.Result {
 		path = "working directory"
 	}
 
-	return check.Result{
-		Title:  fmt.Sprintf("FIO - Write Latency (99%%, %s)", path),
-		Status: status,
-		Value:  latMsStr,
-	}
+	return check.Remediate(
+		check.Result{
+			Title:  fmt.Sprintf("FIO - Write Latency (99%%, %s)", path),
+			Status: status,
+			Value:  latMsStr,
+			Metrics: []check.Metric{
+				{Name: "write_p99_ms", Value: latMs, Unit: "ms"},
+			},
+		},
+		StorageRemediation,
+		StorageDocURL,
+	)
 }
 
-func fioSyncLatencyResult(jobResult *fio.JobResult) check.Result {
+func fioSyncLatencyResult(
+	jobResult *fio.JobResult,
+	requirements check.Requirements,
+) check.Result {
 	// Convert the nanosecond result to milliseconds for readability
 	latMs := float64(jobResult.Sync.LatNs.Percentile.NinetyNinth) / 1e6
 
 	latMsStr := fmt.Sprintf("%0.2f ms", latMs)
 
-	status := check.StatusInfo
-	if latMs > 10.0 {
-		status = check.StatusWarn
-	}
+	status := requirements.Status(latencyCheckID, "sync_p99_ms", latMs)
 
 	path, err := getWorkingDirectory()
 	if err != nil {
@@ -138,15 +167,22 @@ func fioSyncLatencyResult(jobResult *fio.JobResult) check.Result {
 		path = "working directory"
 	}
 
-	return check.Result{
-		Title:  fmt.Sprintf("FIO - Sync Latency (99%%, %s)", path),
-		Status: status,
-		Value:  latMsStr,
-	}
+	return check.Remediate(
+		check.Result{
+			Title:  fmt.Sprintf("FIO - Sync Latency (99%%, %s)", path),
+			Status: status,
+			Value:  latMsStr,
+			Metrics: []check.Metric{
+				{Name: "sync_p99_ms", Value: latMs, Unit: "ms"},
+			},
+		},
+		StorageRemediation,
+		StorageDocURL,
+	)
 }
 
 func (latencyCheck *LatencyCheck) runFioLatencyTest(
-	store output.Store,
+	runContext *check.RunContext,
 ) (*fio.Result, error) {
 	job := latencyCheck.fioNewJob(
 		"conjur-fio-latency",
@@ -164,8 +200,8 @@ func (latencyCheck *LatencyCheck) runFioLatencyTest(
 
 	// Save the full `fio` output to the results store
 	job.OnRawOutput(func(data []byte) {
-		store.Save("conjur-fio-latency", bytes.NewReader(data))
+		runContext.OutputStore.Save("fio.json", bytes.NewReader(data))
 	})
 
-	return job.Exec()
+	return job.Exec(runContext.Context, runContext.Cleanups)
 }
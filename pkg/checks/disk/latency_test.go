@@ -20,8 +20,29 @@ func TestLatencyCheck(t *testing.T) {
 
 	assert.Equal(t, 3, len(results), "There are disk latency results present")
 
-	assertReadLatencyResult(t, results[0], check.StatusInfo)
-	assertWriteLatencyResult(t, results[1], check.StatusInfo)
+	assertReadLatencyResult(t, results[0], check.StatusPass)
+	assertWriteLatencyResult(t, results[1], check.StatusPass)
+	assertSyncLatencyResult(t, results[2], check.StatusPass)
+
+	assert.Equal(
+		t,
+		[]check.Metric{{Name: "sync_p99_ms", Value: 10, Unit: "ms"}},
+		results[2].Metrics,
+	)
+}
+
+func TestLatencyCheckWithRequirements(t *testing.T) {
+	testCheck := &LatencyCheck{
+		fioNewJob: newPoorLatencyPerformanceFioJob,
+	}
+
+	requirements := check.DefaultRequirements()
+	requirements["disk.fio.latency"]["sync_p99_ms"] = check.Threshold{}
+
+	results := testCheck.Run(&check.RunContext{Requirements: requirements})
+
+	assertReadLatencyResult(t, results[0], check.StatusWarn)
+	assertWriteLatencyResult(t, results[1], check.StatusWarn)
 	assertSyncLatencyResult(t, results[2], check.StatusInfo)
 }
 
@@ -42,7 +63,7 @@ func TestLatencyWithError(t *testing.T) {
 	testCheck := &LatencyCheck{
 		fioNewJob: newErrorFioJob,
 	}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: true})
+	results := testCheck.Run(&check.RunContext{})
 
 	// Expect only the error result
 	assert.Equal(t, 1, len(results))
@@ -57,7 +78,7 @@ func TestLatencyWithErrorVerboseErrors(t *testing.T) {
 	testCheck := &LatencyCheck{
 		fioNewJob: newErrorFioJob,
 	}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: true})
+	results := testCheck.Run(&check.RunContext{})
 
 	// Expect only the error result
 	assert.Equal(t, 1, len(results))
@@ -72,10 +93,10 @@ func TestLatencyWithErrorNoVerboseErrors(t *testing.T) {
 	testCheck := &LatencyCheck{
 		fioNewJob: newErrorFioJob,
 	}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: false})
+	results := testCheck.Run(&check.RunContext{})
 
-	// Expect no results when VerboseErrors is false
-	assert.Equal(t, 0, len(results))
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 }
 
 func TestLatencyWithNoJobs(t *testing.T) {
@@ -108,9 +129,9 @@ func TestLatencyWithWorkingDirectoryError(t *testing.T) {
 
 	assert.Equal(t, 3, len(results), "There are disk latency results present")
 
-	assertReadLatencyResult(t, results[0], check.StatusInfo)
-	assertWriteLatencyResult(t, results[1], check.StatusInfo)
-	assertSyncLatencyResult(t, results[2], check.StatusInfo)
+	assertReadLatencyResult(t, results[0], check.StatusPass)
+	assertWriteLatencyResult(t, results[1], check.StatusPass)
+	assertSyncLatencyResult(t, results[2], check.StatusPass)
 }
 
 func assertReadLatencyResult(
@@ -160,6 +181,7 @@ func assertLatencyResult(
 ) {
 	assert.Regexp(t, regexp.MustCompile(expectedTitleRegex), result.Title)
 	assert.Equal(t, expectedStatus, result.Status)
+	assertStorageRemediation(t, result)
 	assert.Regexp(t, regexp.MustCompile(`.+ ms`), result.Value)
 }
 
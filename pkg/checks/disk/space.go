@@ -2,6 +2,7 @@ package disk
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/log"
@@ -9,6 +10,10 @@ import (
 	"github.com/shirou/gopsutil/v3/disk"
 )
 
+// spaceCheckID is the ID of the disk space check, which also keys its
+// free space threshold in the requirements
+const spaceCheckID = "disk.space"
+
 // Aliasing our external dependencies like this allows to swap them out for
 // testing
 var getPartitions func(all bool) ([]disk.PartitionStat, error) = disk.Partitions
@@ -23,8 +28,13 @@ func (*SpaceCheck) Describe() string {
 	return "disk capacity"
 }
 
+// ID provides a stable identifier for this check
+func (*SpaceCheck) ID() string {
+	return spaceCheckID
+}
+
 // Run executes the disk checks and returns their results
-func (sc *SpaceCheck) Run(*check.RunContext) []check.Result {
+func (sc *SpaceCheck) Run(runContext *check.RunContext) []check.Result {
 	partitions, err := getPartitions(true)
 	// If we can't list the partitions, we exit early with the failure message
 	if err != nil {
@@ -53,7 +63,11 @@ func (sc *SpaceCheck) Run(*check.RunContext) []check.Result {
 
 		results = append(
 			results,
-			partitionDiskSpaceResult(partition, usage),
+			partitionDiskSpaceResult(
+				partition,
+				usage,
+				runContext.Requirements,
+			),
 		)
 	}
 
@@ -63,14 +77,26 @@ func (sc *SpaceCheck) Run(*check.RunContext) []check.Result {
 func partitionDiskSpaceResult(
 	partition disk.PartitionStat,
 	usage *disk.UsageStat,
+	requirements check.Requirements,
 ) check.Result {
-	return check.Result{
+	// Read-only filesystems (e.g. snap packages) are always full, so their
+	// free space is informational only
+	status := check.StatusInfo
+	if !slices.Contains(partition.Opts, "ro") {
+		status = requirements.Status(
+			spaceCheckID,
+			"free_percent",
+			100-usage.UsedPercent,
+		)
+	}
+
+	result := check.Result{
 		Title: fmt.Sprintf(
 			"Disk Space (%s, %s)",
 			usage.Fstype,
 			partition.Mountpoint,
 		),
-		Status: check.StatusInfo,
+		Status: status,
 		Value: fmt.Sprintf(
 			"%s Total, %s Used (%s), %s Free",
 			humanize.Bytes(usage.Total),
@@ -78,5 +104,19 @@ func partitionDiskSpaceResult(
 			fmt.Sprintf("%2.f%%", usage.UsedPercent),
 			humanize.Bytes(usage.Free),
 		),
+		Metrics: []check.Metric{
+			{Name: "total_bytes", Value: float64(usage.Total), Unit: "bytes"},
+			{Name: "used_bytes", Value: float64(usage.Used), Unit: "bytes"},
+			{Name: "free_bytes", Value: float64(usage.Free), Unit: "bytes"},
+			{Name: "used_percent", Value: usage.UsedPercent, Unit: "percent"},
+			{Name: "free_percent", Value: 100 - usage.UsedPercent, Unit: "percent"},
+		},
 	}
+
+	return check.Remediate(
+		result,
+		"Free up space on the filesystem or expand it, so that Conjur, its "+
+			"database and its logs don't run out of space",
+		check.SystemRequirementsDocURL,
+	)
 }
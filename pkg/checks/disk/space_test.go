@@ -23,7 +23,11 @@ func TestSpaceCheck(t *testing.T) {
 			result.Title,
 			"Disk space title matches the expected format",
 		)
-		assert.Equal(t, check.StatusInfo, result.Status)
+		assert.Contains(
+			t,
+			[]string{check.StatusPass, check.StatusWarn, check.StatusInfo},
+			result.Status,
+		)
 		assert.Regexp(
 			t,
 			regexp.MustCompile(`.+ Total, .+ Used \( ?\d+%\), .+ Free`),
@@ -33,6 +37,41 @@ func TestSpaceCheck(t *testing.T) {
 	}
 }
 
+func TestPartitionDiskSpaceResultStatus(t *testing.T) {
+	partition := disk.PartitionStat{Device: "/dev/sda1", Mountpoint: "/"}
+
+	result := partitionDiskSpaceResult(
+		partition,
+		&disk.UsageStat{UsedPercent: 50},
+		nil,
+	)
+	assert.Equal(t, check.StatusPass, result.Status)
+	assert.Empty(t, result.Remediation)
+
+	result = partitionDiskSpaceResult(
+		partition,
+		&disk.UsageStat{UsedPercent: 95},
+		nil,
+	)
+	assert.Equal(t, check.StatusWarn, result.Status)
+	assert.Contains(t, result.Remediation, "Free up space")
+
+	assert.Contains(
+		t,
+		result.Metrics,
+		check.Metric{Name: "free_percent", Value: 5, Unit: "percent"},
+	)
+
+	// Read-only partitions are always full, so their space isn't assessed
+	partition.Opts = []string{"ro"}
+	result = partitionDiskSpaceResult(
+		partition,
+		&disk.UsageStat{UsedPercent: 100},
+		nil,
+	)
+	assert.Equal(t, check.StatusInfo, result.Status)
+}
+
 func TestPartitionListError(t *testing.T) {
 	// Double the usage function to simulate an error
 	originalPartitionsFunc := getPartitions
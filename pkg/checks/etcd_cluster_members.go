@@ -3,6 +3,7 @@
 package checks
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -20,6 +21,8 @@ var readAllFuncClusterMembers = io.ReadAll
 // EtcdClusterMembers collects the current etcd cluster members by running
 // `evoke cluster member list` in an enrolled cluster node
 type EtcdClusterMembers struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -28,6 +31,11 @@ func (ecm *EtcdClusterMembers) Describe() string {
 	return fmt.Sprintf("Etcd Cluster Members (%s)", ecm.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (ecm *EtcdClusterMembers) ID() string {
+	return providerCheckID("etcd.cluster-members", ecm.Provider)
+}
+
 // Run executes the cluster member list command and saves the output
 func (ecm *EtcdClusterMembers) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -38,19 +46,16 @@ func (ecm *EtcdClusterMembers) Run(runContext *check.RunContext) []check.Result
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(ecm.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				ecm,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			ecm,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := ecm.Provider.Container(runContext.ContainerID)
 
 	// Check if node is enrolled in a cluster
-	isEnrolled, err := ecm.isNodeEnrolled(container)
+	isEnrolled, err := ecm.isNodeEnrolled(runContext.Context, container)
 	if err != nil {
 		return check.ErrorResult(ecm, err)
 	}
@@ -61,7 +66,7 @@ func (ecm *EtcdClusterMembers) Run(runContext *check.RunContext) []check.Result
 	}
 
 	// Run evoke cluster member list command
-	stdout, stderr, err := container.Exec("evoke", "cluster", "member", "list")
+	stdout, stderr, err := container.Exec(runContext.Context, "evoke", "cluster", "member", "list")
 	if err != nil {
 		stderrMsg := shell.ReadOrDefault(stderr, "N/A")
 		return check.ErrorResult(
@@ -80,8 +85,7 @@ func (ecm *EtcdClusterMembers) Run(runContext *check.RunContext) []check.Result
 	}
 
 	// Save raw output to OutputStore
-	providerSuffix := strings.ToLower(ecm.Provider.Name())
-	outputFileName := fmt.Sprintf("etcd-cluster-members-%s.txt", providerSuffix)
+	outputFileName := "cluster-members.txt"
 	_, saveErr := runContext.OutputStore.Save(outputFileName, strings.NewReader(string(memberListOutput)))
 	if saveErr != nil {
 		log.Warn("Failed to save cluster member list output: %w", saveErr)
@@ -92,8 +96,11 @@ func (ecm *EtcdClusterMembers) Run(runContext *check.RunContext) []check.Result
 
 // isNodeEnrolled checks if the node is enrolled in a cluster by reading
 // /etc/cinc/solo.json and verifying conjur.cluster_name exists and is non-empty
-func (ecm *EtcdClusterMembers) isNodeEnrolled(container container.Container) (bool, error) {
-	stdout, stderr, err := container.Exec("cat", "/etc/cinc/solo.json")
+func (ecm *EtcdClusterMembers) isNodeEnrolled(
+	ctx context.Context,
+	container container.Container,
+) (bool, error) {
+	stdout, stderr, err := container.Exec(ctx, "cat", "/etc/cinc/solo.json")
 	if err != nil {
 		stderrMsg := shell.ReadOrDefault(stderr, "N/A")
 		return false, fmt.Errorf("failed to read solo.json: %w (stderr: %s)", err, stderrMsg)
@@ -58,7 +58,7 @@ func TestEtcdClusterMembersRunEnrolled(t *testing.T) {
 
 	itemInfo, err := outputStoreItems[0].Info()
 	assert.NoError(t, err)
-	assert.Equal(t, "etcd-cluster-members-test container provider.txt", itemInfo.Name())
+	assert.Equal(t, "cluster-members.txt", itemInfo.Name())
 
 	reader, cleanup, err := outputStoreItems[0].Open()
 	assert.NoError(t, err)
@@ -3,12 +3,16 @@
 package checks
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/checks/disk"
 	"github.com/cyberark/conjur-inspect/pkg/container"
 	"github.com/cyberark/conjur-inspect/pkg/log"
 	"github.com/cyberark/conjur-inspect/pkg/shell"
@@ -16,17 +20,32 @@ import (
 
 const testDir = "/var/lib/conjur/etcd_performance_test"
 const etcdLogFile = testDir + "/server.log"
-const logFilePrefix = "etcdctl-perf-check"
+
+// Names of the raw outputs saved by the check, in its own output directory
+const (
+	etcdPerfStdoutFile = "stdout.txt"
+	etcdPerfStderrFile = "stderr.txt"
+	etcdPerfLogFile    = "etcd.log"
+)
+
+// etcdPerfTimeout allows for waiting up to a minute for etcd to start, followed
+// by the 60 second performance test
+const etcdPerfTimeout = 5 * time.Minute
+
+// Patterns for the measurements in the `etcdctl check perf` output
+var (
+	etcdThroughputPattern = regexp.MustCompile(`([0-9.]+) writes/s`)
+	etcdSecondsPattern    = regexp.MustCompile(`([0-9.]+)s\b`)
+)
 
 var _ check.Check = EtcdPerfCheck{}
 
 // EtcdPerfCheck runs etcdctl check perf in a container and parses its output.
 type EtcdPerfCheck struct {
-	Provider        container.ContainerProvider
-	RunContext      *check.RunContext
-	stdoutFileName  string
-	stderrFileName  string
-	etcdLogFileName string
+	requiresContainerAvailability
+
+	Provider   container.ContainerProvider
+	RunContext *check.RunContext
 }
 
 // Describe provides a textual description of what this check gathers info on
@@ -34,39 +53,45 @@ func (c EtcdPerfCheck) Describe() string {
 	return fmt.Sprintf("Etcd Performance Check (60s) (%s)", c.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (c EtcdPerfCheck) ID() string {
+	return providerCheckID("etcd.perf", c.Provider)
+}
+
+// Exclusive ensures the performance test runs without other checks adding load
+// to the host
+func (c EtcdPerfCheck) Exclusive() bool {
+	return true
+}
+
+// Timeout allows for the performance test to run longer than other checks
+func (c EtcdPerfCheck) Timeout() time.Duration {
+	return etcdPerfTimeout
+}
+
 // Run executes the etcdctl check perf command in the container and returns results.
 func (c EtcdPerfCheck) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(c.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				c,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			c,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	// store RunContext so we are not passing it to methods
 	c.RunContext = runContext
-	providerSuffix := strings.ToLower(c.Provider.Name())
-	c.stderrFileName = fmt.Sprintf("%s-stderr-%s.txt", logFilePrefix, providerSuffix)
-	c.stdoutFileName = fmt.Sprintf("%s-stdout-%s.txt", logFilePrefix, providerSuffix)
-	c.etcdLogFileName = fmt.Sprintf("%s-etcdLog-%s.log", logFilePrefix, providerSuffix)
 
 	// Verify that action is valid
 	validationErrors := c.validateAction()
 	if len(validationErrors) != 0 {
-		if runContext.VerboseErrors {
-			return validationErrors
-		}
-		return []check.Result{}
+		return check.Suppress(validationErrors)
 	}
 
-	// start etcd
-	etcdPid, err := c.startEtcd()
-	defer c.killEtcd(etcdPid)
+	// start etcd. This registers a cleanup hook to stop it and remove the test
+	// directory once the check finishes or is interrupted.
+	err := c.startEtcd()
 	if err != nil {
 		return err
 	}
@@ -76,13 +101,13 @@ func (c EtcdPerfCheck) Run(runContext *check.RunContext) []check.Result {
 	//       distinguish failed test from execution error. Therefore, even if we
 	//       get an error here we still need to parse an output from stdout.
 	container := c.Provider.Container(runContext.ContainerID)
-	stdout, stderr, etcdErr := container.Exec("env", "ETCDCTL_API=3", "etcdctl", "check", "perf",
+	stdout, stderr, etcdErr := container.Exec(runContext.Context, "env", "ETCDCTL_API=3", "etcdctl", "check", "perf",
 		"--prefix", "/etcdctl-check-perf/")
 
 	rawPerCheckResults := shell.ReadOrDefault(stdout, "")
 
 	// Save raw performance results to OutputStore
-	_, saveErr := runContext.OutputStore.Save(c.stdoutFileName,
+	_, saveErr := runContext.OutputStore.Save(etcdPerfStdoutFile,
 		strings.NewReader(rawPerCheckResults))
 	if saveErr != nil {
 		log.Warn("Failed to save etcdctl stdout: %w", saveErr)
@@ -90,7 +115,7 @@ func (c EtcdPerfCheck) Run(runContext *check.RunContext) []check.Result {
 
 	// If error was returned then also save stderr.
 	if etcdErr != nil {
-		_, saveErr = runContext.OutputStore.Save(c.stderrFileName, stderr)
+		_, saveErr = runContext.OutputStore.Save(etcdPerfStderrFile, stderr)
 		if saveErr != nil {
 			log.Warn("Failed to save etcdctl stderr: %w", saveErr)
 		}
@@ -101,7 +126,7 @@ func (c EtcdPerfCheck) Run(runContext *check.RunContext) []check.Result {
 	if errorResult != nil {
 		return errorResult
 	}
-	_, saveErr = runContext.OutputStore.Save(c.etcdLogFileName, out)
+	_, saveErr = runContext.OutputStore.Save(etcdPerfLogFile, out)
 	if saveErr != nil {
 		log.Warn("Failed to save etcd server log: %s", saveErr)
 	}
@@ -208,12 +233,19 @@ func (c EtcdPerfCheck) parse(output string) []check.Result {
 			}
 		}
 		if matchedFail != "" {
-			results = append(results, check.Result{
-				Title:   c.Describe(),
-				Value:   "BAD",
-				Status:  check.StatusFail,
-				Message: trimLine(line, matchedFail),
-			})
+			results = append(results, check.Remediate(
+				check.Result{
+					Title:   c.Describe(),
+					Value:   "BAD",
+					Status:  check.StatusFail,
+					Message: trimLine(line, matchedFail),
+					Metrics: etcdPerfMetrics(line),
+				},
+				"Make sure the Conjur data directory is on fast storage, such as "+
+					"local SSDs, and that the host isn't overloaded while etcd is "+
+					"running",
+				disk.StorageDocURL,
+			))
 			continue
 		}
 
@@ -223,6 +255,7 @@ func (c EtcdPerfCheck) parse(output string) []check.Result {
 				Value:   "GOOD",
 				Status:  check.StatusPass,
 				Message: trimLine(line, PassIndicator),
+				Metrics: etcdPerfMetrics(line),
 			})
 		} else if strings.Contains(line, ErrorIndicator) {
 			results = append(results, check.Result{
@@ -263,28 +296,64 @@ func (c EtcdPerfCheck) parse(output string) []check.Result {
 	}
 }
 
-func (c EtcdPerfCheck) startEtcd() (string, []check.Result) {
+// etcdPerfMetrics returns the measurement reported on a line of the `etcdctl
+// check perf` output, e.g. "PASS: Throughput is 150 writes/s" or "Stddev too
+// high: 0.2s".
+func etcdPerfMetrics(line string) []check.Metric {
+	var name, unit string
+	var pattern *regexp.Regexp
+	switch {
+	case strings.Contains(line, "Throughput"):
+		name, unit, pattern = "throughput", "writes/s", etcdThroughputPattern
+	case strings.Contains(line, "Slowest request"):
+		name, unit, pattern = "slowest_request", "s", etcdSecondsPattern
+	case strings.Contains(line, "Stddev"):
+		name, unit, pattern = "stddev", "s", etcdSecondsPattern
+	default:
+		return nil
+	}
+
+	match := pattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return nil
+	}
+
+	return []check.Metric{{Name: name, Value: value, Unit: unit}}
+}
+
+func (c EtcdPerfCheck) startEtcd() []check.Result {
 	// Create test directory to store etcd logs
 	_, errorResult := c.containerCall("rm", "-rf", testDir)
 	if errorResult != nil {
-		return "", errorResult
+		return errorResult
 	}
 	_, errorResult = c.containerCall("mkdir", "-p", testDir)
 	if errorResult != nil {
-		return "", errorResult
+		return errorResult
 	}
 
+	// Registered before etcd is started, so it runs after etcd is stopped
+	c.RunContext.Cleanups.Add(c.removeTestDir)
+
 	// Start etcd in the background
 	stdout, errorResult := c.containerCall("sh", "-c", fmt.Sprintf(
 		"ETCD_DATA_DIR=%s ETCD_DEBUG=true etcd >%s 2>&1 & echo $!", testDir, etcdLogFile))
 	if errorResult != nil {
-		return "", errorResult
+		return errorResult
 	}
 	etcdPid := strings.TrimSpace(shell.ReadOrDefault(stdout, ""))
+	c.RunContext.Cleanups.Add(func(ctx context.Context) {
+		c.killEtcd(ctx, etcdPid)
+	})
 
 	// Wait for etcd to be responsive
 	if !c.waitForEtcd() {
-		return etcdPid, []check.Result{
+		return []check.Result{
 			{
 				Title:   c.Describe(),
 				Status:  check.StatusError,
@@ -292,7 +361,7 @@ func (c EtcdPerfCheck) startEtcd() (string, []check.Result) {
 			},
 		}
 	}
-	return etcdPid, nil
+	return nil
 }
 
 func (c EtcdPerfCheck) waitForEtcd() bool {
@@ -304,6 +373,8 @@ func (c EtcdPerfCheck) waitForEtcd() bool {
 		select {
 		case <-timeout:
 			return false
+		case <-c.RunContext.Context.Done():
+			return false
 		case <-ticker.C:
 			stdout, errorResult := c.containerCall("curl", "-s", "-o", "/dev/null",
 				"-w", "%{http_code}", "http://127.0.0.1:2379/health")
@@ -317,23 +388,37 @@ func (c EtcdPerfCheck) waitForEtcd() bool {
 	}
 }
 
-func (c EtcdPerfCheck) killEtcd(etcdPid string) {
+func (c EtcdPerfCheck) killEtcd(ctx context.Context, etcdPid string) {
 	if etcdPid != "" {
-		_, errorResult := c.containerCall("kill", "-HUP", etcdPid)
+		_, errorResult := c.containerCallContext(ctx, "kill", "-HUP", etcdPid)
 		if len(errorResult) > 0 {
 			log.Warn("failed to kill etcd: %s", errorResult[0].Message)
 		}
 	}
 }
 
+func (c EtcdPerfCheck) removeTestDir(ctx context.Context) {
+	_, errorResult := c.containerCallContext(ctx, "rm", "-rf", testDir)
+	if len(errorResult) > 0 {
+		log.Warn("failed to remove etcd test directory: %s", errorResult[0].Message)
+	}
+}
+
 func (c EtcdPerfCheck) containerCall(args ...string) (io.Reader, []check.Result) {
+	return c.containerCallContext(c.RunContext.Context, args...)
+}
+
+func (c EtcdPerfCheck) containerCallContext(
+	ctx context.Context,
+	args ...string,
+) (io.Reader, []check.Result) {
 	container := c.Provider.Container(c.RunContext.ContainerID)
-	stdout, stderr, err := container.Exec(args...)
+	stdout, stderr, err := container.Exec(ctx, args...)
 	if err != nil {
 		rawStderr := shell.ReadOrDefault(stderr, "")
-		_, saveErr := c.RunContext.OutputStore.Save(c.stderrFileName, strings.NewReader(rawStderr))
+		_, saveErr := c.RunContext.OutputStore.Save(etcdPerfStderrFile, strings.NewReader(rawStderr))
 		if saveErr != nil {
-			log.Warn("Failed to save %s: %w", c.stderrFileName, saveErr)
+			log.Warn("Failed to save %s: %w", etcdPerfStderrFile, saveErr)
 		}
 		return nil, check.ErrorResult(
 			c, fmt.Errorf("%s: %s (error: %w) (stderr: %s)", "error during container call", args, err, rawStderr))
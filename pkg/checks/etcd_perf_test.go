@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"context"
 	"errors"
 	"io"
 	"strings"
@@ -17,6 +18,9 @@ import (
 type mockContainerProvider struct {
 	execMap     map[string]mockExecResult
 	containerID string
+
+	// execCalls records each command run in the container, if set
+	execCalls *[]string
 }
 
 type mockExecResult struct {
@@ -25,42 +29,70 @@ type mockExecResult struct {
 	err    error
 }
 
-func (m *mockContainerProvider) Name() string                                   { return "MockProvider" }
-func (m *mockContainerProvider) Info() (container.ContainerProviderInfo, error) { return nil, nil }
-func (m *mockContainerProvider) NetworkInspect() (io.Reader, error)            { return nil, nil }
+func (m *mockContainerProvider) Name() string { return "MockProvider" }
+func (m *mockContainerProvider) Info(context.Context) (container.ContainerProviderInfo, error) {
+	return nil, nil
+}
+func (m *mockContainerProvider) NetworkInspect(context.Context) (io.Reader, error) { return nil, nil }
 func (m *mockContainerProvider) Container(id string) container.Container {
 	return &mockContainer{
 		execMap:     m.execMap,
 		containerID: m.containerID,
+		execCalls:   m.execCalls,
 	}
 }
 
 type mockContainer struct {
 	execMap     map[string]mockExecResult
 	containerID string
+	execCalls   *[]string
 }
 
-func (m *mockContainer) ID() string                  { return m.containerID }
-func (m *mockContainer) Inspect() (io.Reader, error) { return nil, nil }
-func (m *mockContainer) Exec(args ...string) (io.Reader, io.Reader, error) {
+func (m *mockContainer) ID() string                                 { return m.containerID }
+func (m *mockContainer) Inspect(context.Context) (io.Reader, error) { return nil, nil }
+func (m *mockContainer) Exec(_ context.Context, args ...string) (io.Reader, io.Reader, error) {
 	key := strings.Join(args, " ")
+	if m.execCalls != nil {
+		*m.execCalls = append(*m.execCalls, key)
+	}
 	res, ok := m.execMap[key]
 	if !ok {
 		return nil, nil, errors.New("not found")
 	}
 	return res.stdout, res.stderr, res.err
 }
-func (m *mockContainer) ExecAsUser(user string, args ...string) (io.Reader, io.Reader, error) {
+func (m *mockContainer) ExecAsUser(ctx context.Context, user string, args ...string) (io.Reader, io.Reader, error) {
 	// For tests, treat ExecAsUser the same as Exec
-	return m.Exec(args...)
+	return m.Exec(ctx, args...)
+}
+func (m *mockContainer) Logs(ctx context.Context, since time.Duration) (io.Reader, error) {
+	return nil, nil
+}
+func (m *mockContainer) ExecTo(ctx context.Context, stdout io.Writer, args ...string) (io.Reader, error) {
+	execStdout, execStderr, err := m.Exec(ctx, args...)
+	if execStdout != nil {
+		_, _ = io.Copy(stdout, execStdout)
+	}
+	return execStderr, err
+}
+func (m *mockContainer) LogsTo(context.Context, io.Writer, time.Duration) error {
+	return nil
 }
-func (m *mockContainer) Logs(since time.Duration) (io.Reader, error) { return nil, nil }
 
 // helper to build SUT and run context
 func newEtcdPerfCheck(execMap map[string]mockExecResult, containerID string) (EtcdPerfCheck, *check.RunContext) {
-	provider := &mockContainerProvider{execMap: execMap, containerID: containerID}
+	provider := &mockContainerProvider{
+		execMap:     execMap,
+		containerID: containerID,
+		execCalls:   &[]string{},
+	}
 	sut := EtcdPerfCheck{Provider: provider}
-	runCtx := &check.RunContext{ContainerID: "mock", OutputStore: test.NewOutputStore()}
+	runCtx := &check.RunContext{
+		Context:     context.Background(),
+		Cleanups:    &check.Cleanups{},
+		ContainerID: "mock",
+		OutputStore: test.NewOutputStore(),
+	}
 	return sut, runCtx
 }
 
@@ -88,6 +120,56 @@ func TestEtcdPerfCheck_Run_Success(t *testing.T) {
 	assert.Equal(t, "GOOD", results[0].Value)
 	assert.Equal(t, check.StatusFail, results[1].Status)
 	assert.Equal(t, "BAD", results[1].Value)
+	assert.NotEmpty(t, results[1].Remediation)
+	assert.Empty(t, results[0].Remediation)
+
+	items, err := runCtx.OutputStore.Items()
+	assert.NoError(t, err)
+
+	names := []string{}
+	for _, item := range items {
+		info, err := item.Info()
+		assert.NoError(t, err)
+		names = append(names, info.Name())
+	}
+	assert.ElementsMatch(t, []string{"stdout.txt", "stderr.txt", "etcd.log"}, names)
+}
+
+func TestEtcdPerfCheck_Run_Cleanup(t *testing.T) {
+	execMap := map[string]mockExecResult{
+		"echo":             {},
+		"which etcd":       {},
+		"which etcdctl":    {},
+		"sv status conjur": {stdout: strings.NewReader("down: conjur")},
+		"sv status pg":     {stdout: strings.NewReader("down: pg")},
+		"sv status etcd":   {stdout: strings.NewReader("down: etcd")},
+		"pgrep etcd":       {err: errors.New("not running")},
+		"rm -rf /var/lib/conjur/etcd_performance_test":   {},
+		"mkdir -p /var/lib/conjur/etcd_performance_test": {},
+		"sh -c ETCD_DATA_DIR=/var/lib/conjur/etcd_performance_test ETCD_DEBUG=true etcd >/var/lib/conjur/etcd_performance_test/server.log 2>&1 & echo $!": {stdout: strings.NewReader("123")},
+		// etcd never becomes ready
+		"curl -s -o /dev/null -w %{http_code} http://127.0.0.1:2379/health": {err: errors.New("connection refused")},
+		"kill -HUP 123": {},
+	}
+	sut, runCtx := newEtcdPerfCheck(execMap, "mock")
+	results := sut.Run(runCtx)
+	assert.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+
+	// etcd is stopped and the test directory removed by the cleanup hooks,
+	// rather than by the check itself
+	execCalls := sut.Provider.(*mockContainerProvider).execCalls
+	assert.NotContains(t, *execCalls, "kill -HUP 123")
+
+	runCtx.Cleanups.Run(context.Background())
+	assert.Equal(
+		t,
+		[]string{
+			"kill -HUP 123",
+			"rm -rf /var/lib/conjur/etcd_performance_test",
+		},
+		(*execCalls)[len(*execCalls)-2:],
+	)
 }
 
 func TestEtcdPerfCheck_Run_ValidationError(t *testing.T) {
@@ -95,7 +177,6 @@ func TestEtcdPerfCheck_Run_ValidationError(t *testing.T) {
 		"echo": {err: errors.New("fail")},
 	}
 	sut, runCtx := newEtcdPerfCheck(execMap, "mock")
-	runCtx.VerboseErrors = true
 	results := sut.Run(runCtx)
 	assert.Len(t, results, 1)
 	assert.Equal(t, check.StatusError, results[0].Status)
@@ -106,7 +187,6 @@ func TestEtcdPerfCheck_Run_NoContainerId(t *testing.T) {
 		"echo": {err: errors.New("fail")},
 	}
 	sut, runCtx := newEtcdPerfCheck(execMap, "")
-	runCtx.VerboseErrors = true
 	results := sut.Run(runCtx)
 	assert.Len(t, results, 1)
 	assert.Equal(t, check.StatusError, results[0].Status)
@@ -121,7 +201,6 @@ func TestEtcdPerfCheck_Run_ServiceRunning(t *testing.T) {
 		"sv status conjur": {stdout: strings.NewReader("run: conjur")},
 	}
 	sut, runCtx := newEtcdPerfCheck(execMap, "mock")
-	runCtx.VerboseErrors = true
 	results := sut.Run(runCtx)
 	assert.Len(t, results, 1)
 	assert.Equal(t, check.StatusError, results[0].Status)
@@ -136,10 +215,9 @@ func TestEtcdPerfCheck_Run_ServiceRunningNoVerboseErrors(t *testing.T) {
 		"sv status conjur": {stdout: strings.NewReader("run: conjur")},
 	}
 	sut, runCtx := newEtcdPerfCheck(execMap, "mock")
-	runCtx.VerboseErrors = false
 	results := sut.Run(runCtx)
-	// Should return empty since the validation error is suppressed
-	assert.Len(t, results, 0)
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 }
 
 func TestEtcdPerfCheck_parse(t *testing.T) {
@@ -222,3 +300,31 @@ func TestEtcdPerfCheck_parse(t *testing.T) {
 		})
 	}
 }
+
+func TestEtcdPerfMetrics(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected []check.Metric
+	}{
+		{
+			line:     "PASS: Throughput is 150 writes/s",
+			expected: []check.Metric{{Name: "throughput", Value: 150, Unit: "writes/s"}},
+		},
+		{
+			line:     "PASS: Slowest request took 0.130829s",
+			expected: []check.Metric{{Name: "slowest_request", Value: 0.130829, Unit: "s"}},
+		},
+		{
+			line:     "Stddev too high: 0.2s",
+			expected: []check.Metric{{Name: "stddev", Value: 0.2, Unit: "s"}},
+		},
+		{
+			line:     "PASS: perf test passed",
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, etcdPerfMetrics(testCase.line))
+	}
+}
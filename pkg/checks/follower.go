@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -18,6 +19,11 @@ func (*Follower) Describe() string {
 	return "follower"
 }
 
+// ID provides a stable identifier for this check
+func (*Follower) ID() string {
+	return "follower.connectivity"
+}
+
 // LeaderPort is the port that the follower listens on
 type LeaderPort struct {
 	PortName string
@@ -30,13 +36,10 @@ func (f *Follower) Run(runContext *check.RunContext) []check.Result {
 	hostname := os.Getenv("MASTER_HOSTNAME")
 
 	if hostname == "" {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				f,
-				errors.New("Leader hostname is not set. Set the 'MASTER_HOSTNAME' environment variable to run this check"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			f,
+			errors.New("Leader hostname is not set. Set the 'MASTER_HOSTNAME' environment variable to run this check"),
+		)
 	}
 
 	// Initialize ports
@@ -63,7 +66,7 @@ func (f *Follower) Run(runContext *check.RunContext) []check.Result {
 			Title: leaderPort.PortName,
 		}
 
-		leaderPort, err := checkPort(hostname, &leaderPort)
+		leaderPort, err := checkPort(runContext.Context, hostname, &leaderPort)
 		if err != nil {
 			result.Status = check.StatusError
 			result.Value = "N/A"
@@ -83,12 +86,17 @@ func (f *Follower) Run(runContext *check.RunContext) []check.Result {
 	return results
 }
 
-func checkPort(host string, leaderPort *LeaderPort) (*LeaderPort, error) {
+func checkPort(
+	ctx context.Context,
+	host string,
+	leaderPort *LeaderPort,
+) (*LeaderPort, error) {
 	leaderPort.IsOpen = false
 
-	url := fmt.Sprintf("%s:%s", host, leaderPort.Port)
+	url := net.JoinHostPort(host, leaderPort.Port)
 
-	conn, err := net.Dial("tcp", url)
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", url)
 	if err != nil {
 		return leaderPort, fmt.Errorf("connection failed on port: %s", leaderPort.Port)
 	}
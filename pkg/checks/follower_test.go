@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
@@ -10,7 +11,7 @@ import (
 func TestFollowerRun(t *testing.T) {
 	t.Setenv("MASTER_HOSTNAME", "http://example.com")
 	testCheck := &Follower{}
-	results := testCheck.Run(&check.RunContext{})
+	results := testCheck.Run(&check.RunContext{Context: context.Background()})
 
 	leaderReplicationPort := GetResultByTitle(results, "Leader Replication Port")
 	assert.NotNil(t, leaderReplicationPort)
@@ -31,7 +32,7 @@ func TestFollowerRun(t *testing.T) {
 func TestFollowerRunWithoutMasterHostnameVerboseErrors(t *testing.T) {
 	t.Setenv("MASTER_HOSTNAME", "")
 	testCheck := &Follower{}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: true})
+	results := testCheck.Run(&check.RunContext{})
 
 	assert.NotEmpty(t, results)
 	assert.Equal(t, check.StatusError, results[0].Status)
@@ -43,7 +44,7 @@ func TestFollowerRunWithoutMasterHostnameVerboseErrors(t *testing.T) {
 func TestFollowerRunWithoutMasterHostnameNoVerboseErrors(t *testing.T) {
 	t.Setenv("MASTER_HOSTNAME", "")
 	testCheck := &Follower{}
-	results := testCheck.Run(&check.RunContext{VerboseErrors: false})
+	results := testCheck.Run(&check.RunContext{})
 
-	assert.Empty(t, results)
+	assert.Empty(t, check.Unsuppressed(results))
 }
@@ -20,6 +20,11 @@ func (*Host) Describe() string {
 	return "operating system"
 }
 
+// ID provides a stable identifier for this check
+func (*Host) ID() string {
+	return "host.os"
+}
+
 // Run executes the Host inspection checks
 func (h *Host) Run(*check.RunContext) []check.Result {
 	hostInfo, err := getHostInfo()
@@ -18,20 +18,22 @@ func (*HostEtcHosts) Describe() string {
 	return "Host /etc/hosts"
 }
 
+// ID provides a stable identifier for this check
+func (*HostEtcHosts) ID() string {
+	return "host.etc-hosts"
+}
+
 // Run performs the host /etc/hosts collection
 func (h *HostEtcHosts) Run(runContext *check.RunContext) []check.Result {
 	fileBytes, err := os.ReadFile("/etc/hosts")
 	if err != nil {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(h, err)
-		}
 		log.Warn("failed to read /etc/hosts: %s", err)
-		return []check.Result{}
+		return check.SuppressedErrorResult(h, err)
 	}
 
 	// Save the file contents to output store
 	_, err = runContext.OutputStore.Save(
-		"host-etc-hosts.txt",
+		"etc-hosts.txt",
 		bytes.NewReader(fileBytes),
 	)
 	if err != nil {
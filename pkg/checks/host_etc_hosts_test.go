@@ -28,7 +28,7 @@ func TestHostEtcHostsRunSuccessful(t *testing.T) {
 	require.Len(t, items, 1)
 	info, err := items[0].Info()
 	require.NoError(t, err)
-	assert.Equal(t, "host-etc-hosts.txt", info.Name())
+	assert.Equal(t, "etc-hosts.txt", info.Name())
 }
 
 func TestHostEtcHostsRunWithVerboseErrors(t *testing.T) {
@@ -36,7 +36,6 @@ func TestHostEtcHostsRunWithVerboseErrors(t *testing.T) {
 	// when the file is readable (which /etc/hosts typically is)
 	h := &HostEtcHosts{}
 	runContext := test.NewRunContext("")
-	runContext.VerboseErrors = true
 	results := h.Run(&runContext)
 
 	// Should still return empty results when file is readable
@@ -19,8 +19,13 @@ func (*Memory) Describe() string {
 	return "memory"
 }
 
+// ID provides a stable identifier for this check
+func (*Memory) ID() string {
+	return "host.memory"
+}
+
 // Run executes the Memory inspection checks
-func (memory *Memory) Run(*check.RunContext) []check.Result {
+func (memory *Memory) Run(runContext *check.RunContext) []check.Result {
 	v, err := getVirtualMemory()
 	if err != nil {
 		return check.ErrorResult(
@@ -30,24 +35,52 @@ func (memory *Memory) Run(*check.RunContext) []check.Result {
 	}
 
 	return []check.Result{
-		{
-			Title:  "Memory Total",
-			Status: check.StatusInfo,
-			Value:  humanize.Bytes(v.Total),
-		},
+		check.Remediate(
+			check.Result{
+				Title: "Memory Total",
+				Status: runContext.Requirements.Status(
+					memory.ID(),
+					"total_gb",
+					float64(v.Total)/1e9,
+				),
+				Value: humanize.Bytes(v.Total),
+				Metrics: []check.Metric{
+					{Name: "total_bytes", Value: float64(v.Total), Unit: "bytes"},
+				},
+			},
+			"Allocate more memory to the host to meet the Conjur Enterprise "+
+				"hardware requirements",
+			check.SystemRequirementsDocURL,
+		),
 		{
 			Title:  "Memory Free",
 			Status: check.StatusInfo,
 			Value:  humanize.Bytes(v.Free),
+			Metrics: []check.Metric{
+				{Name: "free_bytes", Value: float64(v.Free), Unit: "bytes"},
+			},
 		},
-		{
-			Title:  "Memory Used",
-			Status: check.StatusInfo,
-			Value: fmt.Sprintf(
-				"%s (%.1f %%)",
-				humanize.Bytes(v.Used),
-				v.UsedPercent,
-			),
-		},
+		check.Remediate(
+			check.Result{
+				Title: "Memory Used",
+				Status: runContext.Requirements.Status(
+					memory.ID(),
+					"used_percent",
+					v.UsedPercent,
+				),
+				Value: fmt.Sprintf(
+					"%s (%.1f %%)",
+					humanize.Bytes(v.Used),
+					v.UsedPercent,
+				),
+				Metrics: []check.Metric{
+					{Name: "used_bytes", Value: float64(v.Used), Unit: "bytes"},
+					{Name: "used_percent", Value: v.UsedPercent, Unit: "percent"},
+				},
+			},
+			"Stop other memory-intensive processes on the host, or allocate more "+
+				"memory to it",
+			check.SystemRequirementsDocURL,
+		),
 	}
 }
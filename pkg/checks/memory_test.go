@@ -15,7 +15,7 @@ func TestMemoryRun(t *testing.T) {
 
 	memoryTotal := GetResultByTitle(results, "Memory Total")
 	assert.NotNil(t, memoryTotal, "Includes 'Memory Total'")
-	assert.Equal(t, check.StatusInfo, memoryTotal.Status)
+	assert.NotEqual(t, check.StatusError, memoryTotal.Status)
 	assert.NotEmpty(t, memoryTotal.Value)
 
 	memoryFree := GetResultByTitle(results, "Memory Free")
@@ -29,6 +29,41 @@ func TestMemoryRun(t *testing.T) {
 	assert.NotEmpty(t, memoryUsed.Value)
 }
 
+func TestMemoryRunRequirements(t *testing.T) {
+	originalVirtualMemory := getVirtualMemory
+	defer func() {
+		getVirtualMemory = originalVirtualMemory
+	}()
+
+	testCases := []struct {
+		total    uint64
+		expected string
+	}{
+		{total: 4e9, expected: check.StatusFail},
+		{total: 12e9, expected: check.StatusWarn},
+		{total: 32e9, expected: check.StatusPass},
+	}
+
+	for _, testCase := range testCases {
+		getVirtualMemory = func() (*mem.VirtualMemoryStat, error) {
+			return &mem.VirtualMemoryStat{Total: testCase.total}, nil
+		}
+
+		results := (&Memory{}).Run(&check.RunContext{})
+
+		memoryTotal := GetResultByTitle(results, "Memory Total")
+		assert.NotNil(t, memoryTotal, "Includes 'Memory Total'")
+		assert.Equal(t, testCase.expected, memoryTotal.Status)
+		assert.Equal(
+			t,
+			[]check.Metric{
+				{Name: "total_bytes", Value: float64(testCase.total), Unit: "bytes"},
+			},
+			memoryTotal.Metrics,
+		)
+	}
+}
+
 func TestMemoryRunError(t *testing.T) {
 	// Double the virtual memory function to simulate an error
 	originalVirtualMemory := getVirtualMemory
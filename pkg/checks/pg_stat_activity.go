@@ -14,6 +14,8 @@ import (
 
 // PgStatActivity collects the output of PostgreSQL's pg_stat_activity view
 type PgStatActivity struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -22,6 +24,11 @@ func (psa *PgStatActivity) Describe() string {
 	return fmt.Sprintf("PostgreSQL pg_stat_activity (%s)", psa.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (psa *PgStatActivity) ID() string {
+	return providerCheckID("conjur.pg-stat-activity", psa.Provider)
+}
+
 // Run performs the PostgreSQL pg_stat_activity check
 func (psa *PgStatActivity) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -32,19 +39,17 @@ func (psa *PgStatActivity) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(psa.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				psa,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			psa,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	containerInstance := psa.Provider.Container(runContext.ContainerID)
 
 	// Execute psql command to get pg_stat_activity as the conjur user
 	stdout, stderr, err := containerInstance.ExecAsUser(
+		runContext.Context,
 		"conjur",
 		"psql",
 		"-c",
@@ -63,10 +68,7 @@ func (psa *PgStatActivity) Run(runContext *check.RunContext) []check.Result {
 
 		// Save the error output for reference, then return error result
 		if len(stderrText) > 0 && stderrText != "N/A" {
-			outputFileName := fmt.Sprintf(
-				"%s-pg-stat-activity-error.log",
-				strings.ToLower(psa.Provider.Name()),
-			)
+			outputFileName := "error.log"
 			_, _ = runContext.OutputStore.Save(
 				outputFileName,
 				strings.NewReader(fmt.Sprintf("Error: %s\n%s", err, stderrText)),
@@ -105,7 +105,7 @@ func TestPgStatActivityRunExecError(t *testing.T) {
 	info, err := items[0].Info()
 	require.NoError(t, err)
 	// Provider name is "Test Container Provider" and gets ToLower() -> "test container provider"
-	assert.Equal(t, "test container provider-pg-stat-activity-error.log", info.Name())
+	assert.Equal(t, "error.log", info.Name())
 }
 
 func TestPgStatActivityRunStderr(t *testing.T) {
@@ -0,0 +1,226 @@
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/shell"
+)
+
+// pluginStatuses are the statuses a plugin may report. TIMEOUT is reserved for
+// checks that don't finish within their time limit.
+var pluginStatuses = []string{
+	check.StatusInfo,
+	check.StatusPass,
+	check.StatusWarn,
+	check.StatusFail,
+	check.StatusError,
+}
+
+// Plugin runs an external executable as a check. The executable receives the
+// run context as JSON on its standard input (see PluginInput) and writes its
+// results and any raw outputs as JSON to its standard output (see
+// PluginOutput). A non-zero exit status is reported as an error result.
+type Plugin struct {
+	requiresContainerAvailability
+
+	// Name identifies the plugin in its check ID and raw output directory
+	Name string
+
+	// Path is the location of the plugin executable
+	Path string
+}
+
+// PluginInput is the JSON written to a plugin's standard input
+type PluginInput struct {
+	ContainerID  string `json:"container_id"`
+	SinceSeconds int64  `json:"since_seconds"`
+
+	// Provider is the name of the available container runtime ("docker" or
+	// "podman"), or empty if neither is available
+	Provider string `json:"provider"`
+}
+
+// PluginOutput is the JSON a plugin writes to its standard output
+type PluginOutput struct {
+	Results []check.Result `json:"results"`
+
+	// RawOutputs maps file names to contents to save in the raw data archive.
+	// The files are saved under their own names in the plugin's output
+	// directory.
+	RawOutputs map[string]string `json:"raw_outputs,omitempty"`
+}
+
+// DiscoverPlugins returns a plugin for each executable file in the given
+// directory, sorted by file name. A plugin is named after its file name
+// without the extension, so "proxy.sh" is the "proxy" plugin. Hidden files
+// and subdirectories are ignored.
+func DiscoverPlugins(directory string) ([]*Plugin, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read plugin directory: %w", err)
+	}
+
+	plugins := []*Plugin{}
+	names := map[string]string{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(directory, entry.Name())
+
+		// Follow symlinks, so plugins may be linked into the directory
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read plugin %s: %w", entry.Name(), err)
+		}
+
+		if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			log.Debug("Skipping non-executable file in plugin directory: %s", path)
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		name = strings.ReplaceAll(strings.ToLower(name), " ", "-")
+
+		if existing, ok := names[name]; ok {
+			return nil, fmt.Errorf(
+				"plugins %s and %s have the same name: %s",
+				existing,
+				entry.Name(),
+				name,
+			)
+		}
+		names[name] = entry.Name()
+
+		plugins = append(plugins, &Plugin{Name: name, Path: path})
+	}
+
+	return plugins, nil
+}
+
+// Describe provides a textual description of what this check gathers info on
+func (plugin *Plugin) Describe() string {
+	return fmt.Sprintf("Plugin (%s)", plugin.Name)
+}
+
+// ID provides a stable identifier for this check
+func (plugin *Plugin) ID() string {
+	return "plugin." + plugin.Name
+}
+
+// Run executes the plugin and returns the results it reports
+func (plugin *Plugin) Run(runContext *check.RunContext) []check.Result {
+	input, err := json.Marshal(PluginInput{
+		ContainerID:  runContext.ContainerID,
+		SinceSeconds: int64(runContext.Since.Seconds()),
+		Provider:     availableProvider(runContext),
+	})
+	if err != nil {
+		return check.ErrorResult(plugin, err)
+	}
+
+	stdout, stderr, err := shell.NewCommandWrapper(plugin.Path).RunWithInput(
+		runContext.Context,
+		bytes.NewReader(input),
+	)
+	if err != nil {
+		return check.ErrorResult(
+			plugin,
+			fmt.Errorf(
+				"failed to run plugin: %w (%s)",
+				err,
+				strings.TrimSpace(shell.ReadOrDefault(stderr, "N/A")),
+			),
+		)
+	}
+
+	output := PluginOutput{}
+	err = json.NewDecoder(stdout).Decode(&output)
+	if err != nil && err != io.EOF {
+		return check.ErrorResult(
+			plugin,
+			fmt.Errorf("failed to parse plugin output: %w", err),
+		)
+	}
+
+	err = plugin.saveRawOutputs(runContext, output.RawOutputs)
+	if err != nil {
+		return check.ErrorResult(plugin, err)
+	}
+
+	results := []check.Result{}
+	for _, result := range output.Results {
+		result.Status = strings.ToUpper(result.Status)
+		if result.Status == "" {
+			result.Status = check.StatusInfo
+		}
+
+		if !slices.Contains(pluginStatuses, result.Status) {
+			return check.ErrorResult(
+				plugin,
+				fmt.Errorf(
+					"invalid status from plugin for %q: %q "+
+						"(must be INFO, PASS, WARN, FAIL or ERROR)",
+					result.Title,
+					result.Status,
+				),
+			)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (plugin *Plugin) saveRawOutputs(
+	runContext *check.RunContext,
+	rawOutputs map[string]string,
+) error {
+	names := []string{}
+	for name := range rawOutputs {
+		// Plugins may only name a file in their output directory, not a path
+		if filepath.Base(name) != name || name == "." || name == ".." {
+			return fmt.Errorf("invalid raw output name from plugin: %q", name)
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, err := runContext.OutputStore.Save(
+			name,
+			strings.NewReader(rawOutputs[name]),
+		)
+		if err != nil {
+			log.Warn("Failed to save %s plugin output %s: %s", plugin.Name, name, err)
+		}
+	}
+
+	return nil
+}
+
+// availableProvider returns the name of the first available container
+// runtime, preferring Docker
+func availableProvider(runContext *check.RunContext) string {
+	for _, name := range []string{"docker", "podman"} {
+		if runContext.ContainerRuntimeAvailability[name].Available {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,192 @@
+package checks
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginRun(t *testing.T) {
+	// The plugin echoes its input as a raw output, so the test can verify both
+	// sides of the contract
+	plugin := writeTestPlugin(t, "proxy.sh", `input=$(cat)
+cat <<JSON
+{
+  "results": [
+    {"title": "Proxy reachable", "value": "yes", "status": "pass"},
+    {"title": "Proxy version", "value": "1.2"}
+  ],
+  "raw_outputs": {"input.json": $(printf '%s' "$input" | sed 's/"/\\"/g; s/^/"/; s/$/"/')}
+}
+JSON
+`)
+
+	runContext := test.NewRunContext("test-container-id")
+	runContext.Since = 2 * time.Hour
+	runContext.ContainerRuntimeAvailability = map[string]check.RuntimeAvailability{
+		"docker": {Available: false},
+		"podman": {Available: true},
+	}
+
+	results := plugin.Run(&runContext)
+
+	assert.Equal(
+		t,
+		[]check.Result{
+			{Title: "Proxy reachable", Value: "yes", Status: check.StatusPass},
+			{Title: "Proxy version", Value: "1.2", Status: check.StatusInfo},
+		},
+		results,
+	)
+
+	items, err := runContext.OutputStore.Items()
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	info, err := items[0].Info()
+	require.NoError(t, err)
+	assert.Equal(t, "input.json", info.Name())
+
+	reader, cleanup, err := items[0].Open()
+	require.NoError(t, err)
+	defer cleanup()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"container_id": "test-container-id", "since_seconds": 7200, "provider": "podman"}`,
+		string(data),
+	)
+}
+
+func TestPluginRunFailure(t *testing.T) {
+	plugin := writeTestPlugin(t, "failing.sh", "echo 'no HSM found' >&2\nexit 3\n")
+
+	runContext := test.NewRunContext("")
+	results := plugin.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, "failed to run plugin")
+	assert.Contains(t, results[0].Message, "no HSM found")
+}
+
+func TestPluginRunInvalidOutput(t *testing.T) {
+	plugin := writeTestPlugin(t, "invalid.sh", "echo 'not json'\n")
+
+	runContext := test.NewRunContext("")
+	results := plugin.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, "failed to parse plugin output")
+}
+
+func TestPluginRunInvalidStatus(t *testing.T) {
+	plugin := writeTestPlugin(
+		t,
+		"status.sh",
+		`echo '{"results": [{"title": "Proxy reachable", "status": "ok"}]}'`,
+	)
+
+	runContext := test.NewRunContext("")
+	results := plugin.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, `invalid status from plugin for "Proxy reachable": "OK"`)
+}
+
+func TestPluginRunInvalidRawOutputName(t *testing.T) {
+	plugin := writeTestPlugin(
+		t,
+		"escape.sh",
+		`echo '{"results": [], "raw_outputs": {"../escape.txt": "data"}}'`,
+	)
+
+	runContext := test.NewRunContext("")
+	results := plugin.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, "invalid raw output name")
+
+	items, err := runContext.OutputStore.Items()
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestPluginRunTimeout(t *testing.T) {
+	plugin := writeTestPlugin(t, "slow.sh", "exec sleep 10\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	runContext := test.NewRunContext("")
+	runContext.Context = ctx
+
+	results := plugin.Run(&runContext)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, context.DeadlineExceeded.Error())
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	directory := t.TempDir()
+
+	writeTestPluginFile(t, filepath.Join(directory, "b-hsm.py"), 0755)
+	writeTestPluginFile(t, filepath.Join(directory, "A Proxy.sh"), 0755)
+	writeTestPluginFile(t, filepath.Join(directory, "README.md"), 0644)
+	writeTestPluginFile(t, filepath.Join(directory, ".hidden.sh"), 0755)
+	require.NoError(t, os.Mkdir(filepath.Join(directory, "lib"), 0755))
+
+	plugins, err := DiscoverPlugins(directory)
+	require.NoError(t, err)
+
+	require.Len(t, plugins, 2)
+	assert.Equal(t, "plugin.a-proxy", plugins[0].ID())
+	assert.Equal(t, "Plugin (a-proxy)", plugins[0].Describe())
+	assert.Equal(t, filepath.Join(directory, "A Proxy.sh"), plugins[0].Path)
+	assert.Equal(t, "plugin.b-hsm", plugins[1].ID())
+}
+
+func TestDiscoverPluginsErrors(t *testing.T) {
+	_, err := DiscoverPlugins(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "unable to read plugin directory")
+
+	directory := t.TempDir()
+	writeTestPluginFile(t, filepath.Join(directory, "proxy.sh"), 0755)
+	writeTestPluginFile(t, filepath.Join(directory, "proxy.py"), 0755)
+
+	_, err = DiscoverPlugins(directory)
+	assert.EqualError(
+		t,
+		err,
+		"plugins proxy.py and proxy.sh have the same name: proxy",
+	)
+}
+
+func writeTestPlugin(t *testing.T, name, script string) *Plugin {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755))
+
+	plugins, err := DiscoverPlugins(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+
+	return plugins[0]
+}
+
+func writeTestPluginFile(t *testing.T, path string, mode os.FileMode) {
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), mode))
+}
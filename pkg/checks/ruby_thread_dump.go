@@ -14,6 +14,8 @@ import (
 
 // RubyThreadDump collects thread dumps from Ruby processes running in a container
 type RubyThreadDump struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -22,6 +24,11 @@ func (rtd *RubyThreadDump) Describe() string {
 	return fmt.Sprintf("Ruby service thread dumps (%s)", rtd.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (rtd *RubyThreadDump) ID() string {
+	return providerCheckID("conjur.ruby-thread-dump", rtd.Provider)
+}
+
 // Run performs the Ruby thread dump collection
 func (rtd *RubyThreadDump) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -32,41 +39,33 @@ func (rtd *RubyThreadDump) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(rtd.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				rtd,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			rtd,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	containerInstance := rtd.Provider.Container(runContext.ContainerID)
 
 	// Discover Ruby process PIDs
 	stdout, stderr, err := containerInstance.Exec(
+		runContext.Context,
 		"sh", "-c", "pgrep -f ruby || true",
 	)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				rtd,
-				fmt.Errorf("failed to discover Ruby processes: %w", err),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			rtd,
+			fmt.Errorf("failed to discover Ruby processes: %w", err),
+		)
 	}
 
 	// Read the PIDs from stdout
 	pidsBytes, err := io.ReadAll(stdout)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				rtd,
-				fmt.Errorf("failed to read Ruby PIDs: %w", err),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			rtd,
+			fmt.Errorf("failed to read Ruby PIDs: %w", err),
+		)
 	}
 
 	// Read any stderr for logging
@@ -117,31 +116,27 @@ func (rtd *RubyThreadDump) collectThreadDump(
 		pid, dumpPath, dumpPath,
 	)
 
-	stdout, stderr, err := containerInstance.Exec("sh", "-c", command)
+	stdout, stderr, err := containerInstance.Exec(runContext.Context, "sh", "-c", command)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return &check.Result{
-				Title:   rtd.Describe(),
-				Status:  check.StatusError,
-				Value:   "N/A",
-				Message: fmt.Sprintf("failed to collect thread dump for PID %s: %s", pid, err),
-			}
+		return &check.Result{
+			Title:      rtd.Describe(),
+			Status:     check.StatusError,
+			Value:      "N/A",
+			Message:    fmt.Sprintf("failed to collect thread dump for PID %s: %s", pid, err),
+			Suppressed: true,
 		}
-		return nil
 	}
 
 	// Read the thread dump from stdout
 	dumpBytes, err := io.ReadAll(stdout)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return &check.Result{
-				Title:   rtd.Describe(),
-				Status:  check.StatusError,
-				Value:   "N/A",
-				Message: fmt.Sprintf("failed to read thread dump for PID %s: %s", pid, err),
-			}
+		return &check.Result{
+			Title:      rtd.Describe(),
+			Status:     check.StatusError,
+			Value:      "N/A",
+			Message:    fmt.Sprintf("failed to read thread dump for PID %s: %s", pid, err),
+			Suppressed: true,
 		}
-		return nil
 	}
 
 	// Read any stderr for logging
@@ -152,15 +147,13 @@ func (rtd *RubyThreadDump) collectThreadDump(
 
 	// Check if we got any output
 	if len(dumpBytes) == 0 {
-		if runContext.VerboseErrors {
-			return &check.Result{
-				Title:   rtd.Describe(),
-				Status:  check.StatusError,
-				Value:   "N/A",
-				Message: fmt.Sprintf("no thread dump output for PID %s (sigdump may not be installed or enabled)", pid),
-			}
+		return &check.Result{
+			Title:      rtd.Describe(),
+			Status:     check.StatusError,
+			Value:      "N/A",
+			Message:    fmt.Sprintf("no thread dump output for PID %s (sigdump may not be installed or enabled)", pid),
+			Suppressed: true,
 		}
-		return nil
 	}
 
 	// Save thread dump to output store
@@ -170,15 +163,13 @@ func (rtd *RubyThreadDump) collectThreadDump(
 		strings.NewReader(string(dumpBytes)),
 	)
 	if err != nil {
-		if runContext.VerboseErrors {
-			return &check.Result{
-				Title:   rtd.Describe(),
-				Status:  check.StatusError,
-				Value:   "N/A",
-				Message: fmt.Sprintf("failed to save thread dump for PID %s: %s", pid, err),
-			}
+		return &check.Result{
+			Title:      rtd.Describe(),
+			Status:     check.StatusError,
+			Value:      "N/A",
+			Message:    fmt.Sprintf("failed to save thread dump for PID %s: %s", pid, err),
+			Suppressed: true,
 		}
-		return nil
 	}
 
 	log.Debug("successfully collected thread dump for PID %s", pid)
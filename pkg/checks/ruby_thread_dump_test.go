@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -198,8 +199,8 @@ func TestRubyThreadDumpRunIndividualPIDFailure(t *testing.T) {
 	runContext := test.NewRunContext("container123")
 	results := rtd.Run(&runContext)
 
-	// Should return empty results (failures are logged, not returned as errors)
-	assert.Empty(t, results)
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 
 	// Verify only two thread dump files were saved (PID 5678 failed)
 	items, err := runContext.OutputStore.Items()
@@ -239,8 +240,8 @@ func TestRubyThreadDumpRunEmptyThreadDump(t *testing.T) {
 	runContext := test.NewRunContext("container123")
 	results := rtd.Run(&runContext)
 
-	// Should return empty results
-	assert.Empty(t, results)
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 
 	// Should not have saved any files (empty output is logged as warning)
 	items, err := runContext.OutputStore.Items()
@@ -263,8 +264,8 @@ func TestRubyThreadDumpRunPgrepFailure(t *testing.T) {
 	runContext := test.NewRunContext("container123")
 	results := rtd.Run(&runContext)
 
-	// Should return empty results (failure is logged as warning)
-	assert.Empty(t, results)
+	// Expect only suppressed results
+	assert.Empty(t, check.Unsuppressed(results))
 
 	// Should not have saved any files
 	items, err := runContext.OutputStore.Items()
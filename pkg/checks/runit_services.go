@@ -13,6 +13,8 @@ import (
 
 // RunItServices collects the status of runit services running in the container
 type RunItServices struct {
+	requiresContainerAvailability
+
 	Provider container.ContainerProvider
 }
 
@@ -21,6 +23,11 @@ func (rs *RunItServices) Describe() string {
 	return fmt.Sprintf("Runit Services (%s)", rs.Provider.Name())
 }
 
+// ID provides a stable identifier for this check
+func (rs *RunItServices) ID() string {
+	return providerCheckID("conjur.runit-services", rs.Provider)
+}
+
 // Run performs the runit services status check
 func (rs *RunItServices) Run(runContext *check.RunContext) []check.Result {
 	// If there is no container ID, return
@@ -31,19 +38,17 @@ func (rs *RunItServices) Run(runContext *check.RunContext) []check.Result {
 	// Check if the container runtime is available
 	runtimeKey := strings.ToLower(rs.Provider.Name())
 	if !IsRuntimeAvailable(runContext, runtimeKey) {
-		if runContext.VerboseErrors {
-			return check.ErrorResult(
-				rs,
-				fmt.Errorf("container runtime not available"),
-			)
-		}
-		return []check.Result{}
+		return check.SuppressedErrorResult(
+			rs,
+			fmt.Errorf("container runtime not available"),
+		)
 	}
 
 	container := rs.Provider.Container(runContext.ContainerID)
 
 	// Execute sv status command with shell globbing
 	stdout, stderr, err := container.Exec(
+		runContext.Context,
 		"sh", "-c", "sv status /etc/service/*",
 	)
 
@@ -65,6 +65,9 @@ var SensitivePatterns = []Pattern{
 // Redactor redacts sensitive values from text
 type Redactor struct {
 	patterns []Pattern
+
+	// redactions is the number of values redacted so far
+	redactions int
 }
 
 // NewRedactor creates a new Redactor with default sensitive patterns
@@ -78,11 +81,25 @@ func NewRedactor() *Redactor {
 func (r *Redactor) RedactString(input string) string {
 	result := input
 	for _, pattern := range r.patterns {
+		// Patterns may match a value that an earlier pattern already
+		// redacted, so only count the matches that are changed
+		for _, match := range pattern.Regex.FindAllStringSubmatchIndex(result, -1) {
+			replacement := pattern.Regex.ExpandString(nil, pattern.Replace, result, match)
+			if string(replacement) != result[match[0]:match[1]] {
+				r.redactions++
+			}
+		}
+
 		result = pattern.Regex.ReplaceAllString(result, pattern.Replace)
 	}
 	return result
 }
 
+// Redactions returns the number of sensitive values redacted by this Redactor
+func (r *Redactor) Redactions() int {
+	return r.redactions
+}
+
 // RedactLines redacts sensitive values from each line in the input string
 // Returns the redacted content
 func (r *Redactor) RedactLines(input string) string {
@@ -171,3 +171,14 @@ func TestRedactorNonMatchingPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestRedactorRedactions(t *testing.T) {
+	redactor := NewRedactor()
+	assert.Equal(t, 0, redactor.Redactions())
+
+	redactor.RedactString("api_key=sk_live_1234567890abcdef")
+	redactor.RedactString("password=SuperSecurePass123!")
+	redactor.RedactString("this is just normal text with no secrets")
+
+	assert.Equal(t, 2, redactor.Redactions())
+}
@@ -2,15 +2,18 @@ package checks
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/shell"
 )
 
-var executeUlimitInfoFunc func() (stderr, stdout io.Reader, err error) = executeUlimitInfo
+var executeUlimitInfoFunc func(context.Context) (stderr, stdout io.Reader, err error) = executeUlimitInfo
 
 // Ulimit collects information on the systems avalible resources.
 type Ulimit struct{}
@@ -20,9 +23,14 @@ func (*Ulimit) Describe() string {
 	return "ulimit"
 }
 
+// ID provides a stable identifier for this check
+func (*Ulimit) ID() string {
+	return "host.ulimit"
+}
+
 // Run performs the Ulimit collection
-func (ulimit *Ulimit) Run(*check.RunContext) []check.Result {
-	ulimitOutput, stderr, err := executeUlimitInfoFunc()
+func (ulimit *Ulimit) Run(runContext *check.RunContext) []check.Result {
+	ulimitOutput, stderr, err := executeUlimitInfoFunc(runContext.Context)
 
 	// In case of an error, return a check result with an error status.
 	if err != nil {
@@ -57,16 +65,68 @@ func (ulimit *Ulimit) Run(*check.RunContext) []check.Result {
 			Value:  resourceValue,
 		}
 
+		metric, ok := ulimitMetric(resourceName, resourceValue)
+		if ok {
+			result.Status = runContext.Requirements.Status(
+				ulimit.ID(),
+				metric.Name,
+				metric.Value,
+			)
+
+			// JSON can't represent an unlimited value, so it has no metric
+			if !math.IsInf(metric.Value, 1) {
+				result.Metrics = []check.Metric{metric}
+			}
+
+			result = check.Remediate(
+				result,
+				"Raise the limit for the user that runs the Conjur container "+
+					"(e.g. in /etc/security/limits.conf), or set it with the "+
+					"container runtime's --ulimit option",
+				check.SystemRequirementsDocURL,
+			)
+		}
+
 		results = append(results, result)
 	}
 
 	return results
 }
 
-func executeUlimitInfo() (stdout, stderr io.Reader, err error) {
+// ulimitMetric returns the metric for a ulimit resource, if it is one that
+// requirements may be set for. Resource names differ between shells, so they
+// are matched by the option flag where possible. Unlimited resources have an
+// infinite value.
+func ulimitMetric(resourceName, resourceValue string) (check.Metric, bool) {
+	var name string
+	switch {
+	case strings.Contains(resourceName, "-n)"),
+		strings.Contains(resourceName, "open files"),
+		strings.Contains(resourceName, "nofiles"):
+		name = "open_files"
+	case strings.Contains(resourceName, "-u)"),
+		strings.Contains(resourceName, "processes"):
+		name = "max_processes"
+	default:
+		return check.Metric{}, false
+	}
+
+	value := math.Inf(1)
+	if resourceValue != "unlimited" {
+		parsed, err := strconv.ParseFloat(resourceValue, 64)
+		if err != nil {
+			return check.Metric{}, false
+		}
+		value = parsed
+	}
+
+	return check.Metric{Name: name, Value: value}, true
+}
+
+func executeUlimitInfo(ctx context.Context) (stdout, stderr io.Reader, err error) {
 	return shell.NewCommandWrapper(
 		"sh",
 		"-c",
 		"ulimit -a",
-	).Run()
+	).Run(ctx)
 }
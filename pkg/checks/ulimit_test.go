@@ -1,10 +1,12 @@
 package checks
 
 import (
+	"context"
 	"io"
 	"strings"
 	"testing"
 
+	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,7 +15,7 @@ import (
 func TestUlimitRun(t *testing.T) {
 	// Mock dependencies
 	oldFunc := executeUlimitInfoFunc
-	executeUlimitInfoFunc = func() (stderr, stdout io.Reader, err error) {
+	executeUlimitInfoFunc = func(context.Context) (stderr, stdout io.Reader, err error) {
 		stdout = strings.NewReader(
 			"core file size      (blocks, -c) 0\npipe size      (512 bytes, -p) 1\nopen files      (-n) 6140\n",
 		)
@@ -43,3 +45,47 @@ func TestUlimitRun(t *testing.T) {
 	assert.Equal(t, "INFO", openFiles.Status)
 	assert.Equal(t, "6140", openFiles.Value)
 }
+
+func TestUlimitRunWithRequirements(t *testing.T) {
+	// Mock dependencies
+	oldFunc := executeUlimitInfoFunc
+	executeUlimitInfoFunc = func(context.Context) (stderr, stdout io.Reader, err error) {
+		stdout = strings.NewReader(
+			"open files      (-n) 1024\nmax user processes      (-u) unlimited\n",
+		)
+		return stdout, stderr, err
+	}
+	defer func() {
+		executeUlimitInfoFunc = oldFunc
+	}()
+
+	minimum := 65536.0
+	runContext := test.NewRunContext("")
+	runContext.Requirements = check.DefaultRequirements()
+	runContext.Requirements["host.ulimit"]["open_files"] = check.Threshold{
+		WarnBelow: &minimum,
+	}
+	runContext.Requirements["host.ulimit"]["max_processes"] = check.Threshold{
+		WarnBelow: &minimum,
+	}
+
+	ulimit := &Ulimit{}
+	results := ulimit.Run(&runContext)
+
+	openFiles := GetResultByTitle(results, "open files (-n)")
+	require.NotNil(t, openFiles, "Includes 'open files (-n)'")
+	assert.Equal(t, check.StatusWarn, openFiles.Status)
+	assert.Contains(t, openFiles.Remediation, "Raise the limit")
+	assert.Equal(t, check.SystemRequirementsDocURL, openFiles.DocURL)
+	assert.Equal(
+		t,
+		[]check.Metric{{Name: "open_files", Value: 1024}},
+		openFiles.Metrics,
+	)
+
+	// Unlimited values always meet a minimum
+	maxProcesses := GetResultByTitle(results, "max user processes (-u)")
+	require.NotNil(t, maxProcesses, "Includes 'max user processes (-u)'")
+	assert.Equal(t, check.StatusPass, maxProcesses.Status)
+	assert.Empty(t, maxProcesses.Metrics)
+}
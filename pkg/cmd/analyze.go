@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/cyberark/conjur-inspect/pkg/encryption"
+	"github.com/cyberark/conjur-inspect/pkg/output"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+// reportFileName is the name of the report result in the raw data archive
+const reportFileName = "conjur-inspect.json"
+
+// archiveContents is the report result and raw output files read from a raw
+// data archive
+type archiveContents struct {
+	Result report.Result
+
+	// Files maps the name of each raw output file to the SHA-256 hash of its
+	// contents
+	Files map[string]string
+}
+
+func newAnalyzeCommand() *cobra.Command {
+	var filter statusFilter
+
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze <archive>",
+		Short: "Display the report saved in a raw data archive",
+		Long: "Display the report saved in a raw data archive (<report-id>.tar.gz), " +
+			"with the raw output files saved by each section.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := reportFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			verboseErrors, err := cmd.Flags().GetBool("verbose-errors")
+			if err != nil {
+				return err
+			}
+
+			statuses, err := filter.displayStatuses(outputFormat)
+			if err != nil {
+				return err
+			}
+
+			junitWarnAsFailure, err := junitWarnAsFailure(cmd)
+			if err != nil {
+				return err
+			}
+
+			contents, err := readArchive(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read archive: %w", err)
+			}
+
+			// The archived report includes the suppressed results
+			result := contents.Result
+			if !verboseErrors {
+				result = result.Unsuppressed()
+			}
+
+			// Archives from older versions don't record which section saved
+			// each raw output, so list any remaining files separately
+			if outputFormat != formatJSON {
+				unattributed := unattributedFiles(&result, contents.Files)
+				if len(unattributed) > 0 {
+					result.Sections = append(result.Sections, report.ResultSection{
+						Title:      "Other Raw Outputs",
+						RawOutputs: unattributed,
+					})
+				}
+			}
+
+			writer := newReportWriter(cmd.OutOrStdout(), reportWriterOptions{
+				format:             outputFormat,
+				showRawOutputs:     true,
+				statuses:           statuses,
+				junitWarnAsFailure: junitWarnAsFailure,
+			})
+			return writer.Write(cmd.OutOrStdout(), &result)
+		},
+	}
+
+	filter.addFlags(analyzeCmd)
+
+	return analyzeCmd
+}
+
+// readArchive reads the report result and the raw output files from a raw
+// data archive
+func readArchive(archivePath string) (*archiveContents, error) {
+	encrypted, err := isEncryptedArchive(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		return nil, fmt.Errorf(
+			"%s is encrypted, decrypt it first with 'conjur-inspect decrypt'",
+			archivePath,
+		)
+	}
+
+	contents := &archiveContents{Files: map[string]string{}}
+	foundReport := false
+
+	err = output.WalkTarGzipArchive(
+		archivePath,
+		func(name string, reader io.Reader) error {
+			// Report copies and the manifest describe the run rather than the
+			// system it inspected
+			if isArchiveCopy(name) || name == report.ManifestFileName {
+				return nil
+			}
+
+			if name != reportFileName {
+				hash := sha256.New()
+				_, err := io.Copy(hash, reader)
+				if err != nil {
+					return fmt.Errorf("unable to read %s: %w", name, err)
+				}
+
+				contents.Files[name] = hex.EncodeToString(hash.Sum(nil))
+				return nil
+			}
+
+			foundReport = true
+			err := json.NewDecoder(reader).Decode(&contents.Result)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s: %w", reportFileName, err)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !foundReport {
+		return nil, fmt.Errorf("%s not found in %s", reportFileName, archivePath)
+	}
+
+	return contents, nil
+}
+
+// isEncryptedArchive returns whether the archive was written with
+// '--encrypt-to'
+func isEncryptedArchive(archivePath string) (bool, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	return encryption.IsEncrypted(file), nil
+}
+
+// unattributedFiles returns the sorted names of the files that aren't listed
+// as a raw output of any section of the result
+func unattributedFiles(result *report.Result, files map[string]string) []string {
+	attributed := map[string]bool{}
+	for _, section := range result.Sections {
+		for _, name := range section.RawOutputs {
+			attributed[name] = true
+		}
+	}
+
+	unattributed := []string{}
+	for name := range files {
+		if !attributed[name] {
+			unattributed = append(unattributed, name)
+		}
+	}
+	slices.Sort(unattributed)
+
+	return unattributed
+}
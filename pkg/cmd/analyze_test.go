@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/output"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeCommand(t *testing.T) {
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.0.0",
+			Sections: []report.ResultSection{
+				{
+					Title: "Container",
+					Results: []check.Result{
+						{Title: "Docker inspect", Status: check.StatusInfo, Value: "ok"},
+						{
+							Title:      "Podman inspect",
+							Status:     check.StatusError,
+							Value:      "N/A",
+							Suppressed: true,
+						},
+					},
+					RawOutputs: []string{"docker-inspect.json"},
+				},
+			},
+		},
+		map[string]string{
+			"docker-inspect.json": "{}",
+			"legacy.log":          "log",
+		},
+	)
+
+	stdout, err := executeCommand("analyze", archivePath)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "Version: 1.0.0")
+	assert.Contains(t, stdout, "INFO - Docker inspect: ok")
+	assert.Contains(t, stdout, "Raw outputs: docker-inspect.json")
+	assert.Contains(t, stdout, "Other Raw Outputs")
+	assert.Contains(t, stdout, "Raw outputs: legacy.log")
+
+	// Suppressed results are only shown with verbose errors
+	assert.NotContains(t, stdout, "Podman inspect")
+
+	stdout, err = executeCommand("analyze", archivePath, "--verbose-errors")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "ERROR - Podman inspect: N/A")
+}
+
+func TestAnalyzeCommandJSON(t *testing.T) {
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.0.0",
+			Sections: []report.ResultSection{
+				{
+					Title:      "Host",
+					Results:    []check.Result{{Title: "OS", Value: "linux"}},
+					RawOutputs: []string{"history.txt"},
+				},
+			},
+		},
+		map[string]string{"history.txt": "ls"},
+	)
+
+	stdout, err := executeCommand("analyze", archivePath, "--json")
+	require.NoError(t, err)
+
+	result := report.Result{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, "1.0.0", result.Version)
+	assert.Equal(t, []string{"history.txt"}, result.Sections[0].RawOutputs)
+
+	// '--json' is an alias for '--format json'
+	formatStdout, err := executeCommand("analyze", archivePath, "--format", "json")
+	require.NoError(t, err)
+	assert.Equal(t, stdout, formatStdout)
+}
+
+func TestAnalyzeCommandOnlyProblems(t *testing.T) {
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.0.0",
+			Sections: []report.ResultSection{
+				{
+					Title: "Disk",
+					Results: []check.Result{
+						{Title: "Read IOPs", Status: check.StatusWarn, Value: "40"},
+						{Title: "Write IOPs", Status: check.StatusPass, Value: "120"},
+					},
+				},
+				{
+					Title:      "Host",
+					Results:    []check.Result{{Title: "OS", Status: check.StatusInfo, Value: "linux"}},
+					RawOutputs: []string{"history.txt"},
+				},
+			},
+		},
+		map[string]string{"history.txt": "ls"},
+	)
+
+	stdout, err := executeCommand("analyze", "--only-problems", archivePath)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "WARN - Read IOPs: 40")
+	assert.NotContains(t, stdout, "PASS - Write IOPs")
+
+	// Sections left empty by the filter are hidden
+	assert.NotContains(t, stdout, "Host\n----")
+	assert.Contains(
+		t,
+		stdout,
+		"2 results hidden by the status filter (showing WARN, FAIL, ERROR, TIMEOUT)",
+	)
+
+	stdout, err = executeCommand("analyze", "--status", "pass,info", archivePath)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "PASS - Write IOPs: 120")
+	assert.Contains(t, stdout, "INFO - OS: linux")
+	assert.NotContains(t, stdout, "WARN - Read IOPs")
+}
+
+func TestAnalyzeCommandHTML(t *testing.T) {
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.0.0",
+			ID:      "test-report",
+			Sections: []report.ResultSection{
+				{
+					Title:      "Host",
+					Results:    []check.Result{{Title: "OS", Status: check.StatusInfo, Value: "linux"}},
+					RawOutputs: []string{"history.txt"},
+				},
+			},
+		},
+		map[string]string{
+			"history.txt":         "ls",
+			"conjur-inspect.html": "<html></html>",
+			"manifest.json":       "{}",
+		},
+	)
+
+	stdout, err := executeCommand("analyze", "--format", "html", archivePath)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(stdout, "<!DOCTYPE html>"))
+	assert.Contains(t, stdout, "Report ID: test-report")
+	assert.Contains(t, stdout, "Raw outputs: history.txt")
+
+	// The archived HTML report and manifest aren't listed as raw outputs
+	assert.NotContains(t, stdout, "Other Raw Outputs")
+}
+
+func TestAnalyzeCommandJUnit(t *testing.T) {
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.0.0",
+			Sections: []report.ResultSection{
+				{
+					Title: "Disk",
+					Results: []check.Result{
+						{Title: "Read IOPs", Status: check.StatusWarn, Value: "40"},
+					},
+				},
+			},
+		},
+		map[string]string{},
+	)
+
+	stdout, err := executeCommand("analyze", "--format", "junit", archivePath)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `<skipped message="WARN: 40" type="WARN"></skipped>`)
+
+	stdout, err = executeCommand("analyze", "--format", "junit", "--junit-warn", "failure", archivePath)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `<failure message="WARN: 40" type="WARN"></failure>`)
+}
+
+func TestAnalyzeCommandMissingReport(t *testing.T) {
+	dir := t.TempDir()
+	store := output.NewDirectoryStore(dir)
+	_, err := store.Save("other.txt", strings.NewReader("other"))
+	require.NoError(t, err)
+
+	archive := &output.TarGzipArchive{OutputDir: t.TempDir()}
+	require.NoError(t, archive.Archive("test", store))
+
+	_, err = executeCommand("analyze", path.Join(archive.OutputDir, "test.tar.gz"))
+	assert.ErrorContains(t, err, "conjur-inspect.json not found")
+}
+
+// writeTestArchive writes a raw data archive with the given report result and
+// raw output files, and returns its path
+func writeTestArchive(
+	t *testing.T,
+	result *report.Result,
+	files map[string]string,
+) string {
+	store := output.NewDirectoryStore(t.TempDir())
+
+	resultJSON, err := json.Marshal(result)
+	require.NoError(t, err)
+	_, err = store.Save(reportFileName, bytes.NewReader(resultJSON))
+	require.NoError(t, err)
+
+	for name, contents := range files {
+		_, err = store.Save(name, strings.NewReader(contents))
+		require.NoError(t, err)
+	}
+
+	archive := &output.TarGzipArchive{OutputDir: t.TempDir()}
+	require.NoError(t, archive.Archive("test-report", store))
+
+	return path.Join(archive.OutputDir, "test-report.tar.gz")
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// executeCommand runs conjur-inspect with the given arguments, e.g. a
+// subcommand and its flags, and returns what it wrote to stdout
+func executeCommand(args ...string) (string, error) {
+	var stdout bytes.Buffer
+
+	rootCmd := newRootCommand()
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(args)
+
+	err := rootCmd.Execute()
+
+	return stdout.String(), err
+}
+
+// writeTestKeys writes a PEM encoded key pair of the given type, "x25519" for
+// encryption or "ed25519" for signing, and returns the paths of the public and
+// private key files
+func writeTestKeys(t *testing.T, keyType string) (string, string) {
+	var privateKey crypto.PrivateKey
+	var publicKey crypto.PublicKey
+
+	switch keyType {
+	case "x25519":
+		key, err := ecdh.X25519().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		privateKey, publicKey = key, key.PublicKey()
+	case "ed25519":
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		privateKey, publicKey = private, public
+	default:
+		require.FailNow(t, "unsupported key type", keyType)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	publicKeyFile := filepath.Join(dir, "public.pem")
+	privateKeyFile := filepath.Join(dir, "private.pem")
+
+	require.NoError(t, os.WriteFile(
+		publicKeyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}),
+		0644,
+	))
+	require.NoError(t, os.WriteFile(
+		privateKeyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+		0600,
+	))
+
+	return publicKeyFile, privateKeyFile
+}
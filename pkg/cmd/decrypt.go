@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/encryption"
+
+	"github.com/spf13/cobra"
+)
+
+// encryptedArchiveExtension is added to the name of encrypted raw data
+// archives
+const encryptedArchiveExtension = ".enc"
+
+func newDecryptCommand() *cobra.Command {
+	var keyFile string
+	var outputPath string
+
+	decryptCmd := &cobra.Command{
+		Use:   "decrypt --key <private-key-file> <archive>",
+		Short: "Decrypt a raw data archive written with '--encrypt-to'",
+		Long: "Decrypt a raw data archive (<report-id>.tar.gz.enc) with the " +
+			"private key matching the public key it was encrypted to. The " +
+			"archive is written next to the encrypted one, without the .enc " +
+			"extension, unless '--output' is given.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archivePath := args[0]
+
+			privateKey, err := encryption.LoadPrivateKey(keyFile)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--key': %w", err)
+			}
+
+			if outputPath == "" {
+				if !strings.HasSuffix(archivePath, encryptedArchiveExtension) {
+					return fmt.Errorf(
+						"'--output' is required when the archive name doesn't end with %s",
+						encryptedArchiveExtension,
+					)
+				}
+				outputPath = strings.TrimSuffix(archivePath, encryptedArchiveExtension)
+			}
+
+			err = decryptArchive(archivePath, outputPath, privateKey)
+			if err != nil {
+				return fmt.Errorf("unable to decrypt %s: %w", archivePath, err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), outputPath)
+			return nil
+		},
+	}
+
+	decryptCmd.Flags().StringVarP(
+		&keyFile,
+		"key",
+		"k",
+		"",
+		"PEM encoded private key matching the key the archive was encrypted to",
+	)
+	_ = decryptCmd.MarkFlagRequired("key")
+
+	decryptCmd.Flags().StringVarP(
+		&outputPath,
+		"output",
+		"o",
+		"", // Default is the archive path without the .enc extension
+		"Where to write the decrypted archive",
+	)
+
+	return decryptCmd
+}
+
+// decryptArchive decrypts the archive to the output path. The archive is
+// decrypted to a temporary file first, so a failed decryption never leaves
+// unauthenticated data at the output path.
+func decryptArchive(
+	archivePath string,
+	outputPath string,
+	privateKey crypto.PrivateKey,
+) (err error) {
+	_, err = os.Stat(outputPath)
+	if err == nil {
+		return fmt.Errorf("%s already exists", outputPath)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	in, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.CreateTemp(
+		filepath.Dir(outputPath),
+		"."+filepath.Base(outputPath)+".*",
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := out.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(out.Name(), outputPath)
+		}
+		if err != nil {
+			os.Remove(out.Name())
+		}
+	}()
+
+	return encryption.Decrypt(out, in, privateKey)
+}
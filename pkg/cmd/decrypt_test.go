@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/encryption"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecryptCommand(t *testing.T) {
+	publicKeyFile, privateKeyFile := writeTestKeys(t, "x25519")
+	encryptedPath := writeTestEncryptedArchive(t, publicKeyFile)
+
+	// Encrypted archives can't be analyzed until they're decrypted
+	_, err := executeCommand("analyze", encryptedPath)
+	assert.ErrorContains(t, err, "decrypt it first with 'conjur-inspect decrypt'")
+
+	stdout, err := executeCommand("decrypt", "--key", privateKeyFile, encryptedPath)
+	require.NoError(t, err)
+
+	decryptedPath := strings.TrimSuffix(encryptedPath, ".enc")
+	assert.Equal(t, decryptedPath+"\n", stdout)
+
+	stdout, err = executeCommand("analyze", decryptedPath)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "INFO - OS: linux")
+
+	// The decrypted archive isn't overwritten
+	_, err = executeCommand("decrypt", "--key", privateKeyFile, encryptedPath)
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestDecryptCommandOutput(t *testing.T) {
+	publicKeyFile, privateKeyFile := writeTestKeys(t, "x25519")
+	encryptedPath := writeTestEncryptedArchive(t, publicKeyFile)
+
+	renamedPath := filepath.Join(t.TempDir(), "archive")
+	require.NoError(t, os.Rename(encryptedPath, renamedPath))
+
+	// Without the .enc extension, there is no default output path
+	_, err := executeCommand("decrypt", "--key", privateKeyFile, renamedPath)
+	assert.ErrorContains(t, err, "'--output' is required")
+
+	outputPath := filepath.Join(t.TempDir(), "decrypted.tar.gz")
+	_, err = executeCommand(
+		"decrypt",
+		"--key", privateKeyFile,
+		"--output", outputPath,
+		renamedPath,
+	)
+	require.NoError(t, err)
+	assert.FileExists(t, outputPath)
+}
+
+func TestDecryptCommandWrongKey(t *testing.T) {
+	publicKeyFile, _ := writeTestKeys(t, "x25519")
+	_, otherPrivateKeyFile := writeTestKeys(t, "x25519")
+	encryptedPath := writeTestEncryptedArchive(t, publicKeyFile)
+
+	_, err := executeCommand("decrypt", "--key", otherPrivateKeyFile, encryptedPath)
+	assert.ErrorIs(t, err, encryption.ErrWrongKey)
+
+	// Nothing is left behind by the failed decryption
+	entries, err := os.ReadDir(filepath.Dir(encryptedPath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestDecryptCommandInvalidKey(t *testing.T) {
+	_, err := executeCommand(
+		"decrypt",
+		"--key", filepath.Join(t.TempDir(), "missing.pem"),
+		"archive.tar.gz.enc",
+	)
+	assert.ErrorContains(t, err, "invalid value for '--key'")
+}
+
+func TestRootCommandEncryptToInvalid(t *testing.T) {
+	rootCmd := newRootCommand()
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{
+		"--encrypt-to", filepath.Join(t.TempDir(), "missing.pem"),
+	})
+
+	err := rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid value for '--encrypt-to'")
+}
+
+// writeTestEncryptedArchive writes a raw data archive encrypted to the given
+// public key, alone in its own directory, and returns its path
+func writeTestEncryptedArchive(t *testing.T, publicKeyFile string) string {
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.0.0",
+			Sections: []report.ResultSection{
+				{
+					Title:   "Host",
+					Results: []check.Result{{Title: "OS", Status: check.StatusInfo, Value: "linux"}},
+				},
+			},
+		},
+		nil,
+	)
+
+	publicKey, err := encryption.LoadPublicKey(publicKeyFile)
+	require.NoError(t, err)
+
+	plaintext, err := os.Open(archivePath)
+	require.NoError(t, err)
+	defer plaintext.Close()
+
+	encryptedPath := filepath.Join(t.TempDir(), "test-report.tar.gz.enc")
+	encrypted, err := os.Create(encryptedPath)
+	require.NoError(t, err)
+	defer encrypted.Close()
+
+	require.NoError(t, encryption.Encrypt(encrypted, plaintext, publicKey))
+
+	return encryptedPath
+}
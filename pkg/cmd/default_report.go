@@ -2,8 +2,14 @@
 package cmd
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
 	"os"
 	"path"
+	"slices"
+	"strings"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/checks"
@@ -14,25 +20,99 @@ import (
 	"github.com/cyberark/conjur-inspect/pkg/reports"
 )
 
-// NewDefaultReport returns a report containing the standard inspection checks
+// DefaultReportOptions configures the checks included in the default report
+type DefaultReportOptions struct {
+	// PluginDir is the directory of check plugins to run, each in its own
+	// section after the standard checks. If empty, no plugins are run.
+	PluginDir string
+
+	// ChecksFile is a YAML file of declarative checks to run against the
+	// Conjur container. If empty, only the standard checks are run.
+	ChecksFile string
+
+	// Selection limits the report to the selected checks
+	Selection report.Selection
+
+	// EncryptTo is the public key to encrypt the raw data archive to. If nil,
+	// the archive isn't encrypted.
+	EncryptTo crypto.PublicKey
+
+	// SignKey is the ed25519 private key to sign the raw data archive with. If
+	// nil, the archive isn't signed.
+	SignKey ed25519.PrivateKey
+}
+
+// NewDefaultReport returns a report containing the standard inspection checks,
+// along with any declarative checks and plugins, that match the selection
 func NewDefaultReport(
 	id string,
 	rawDataDir string,
+	options DefaultReportOptions,
 ) (report.Report, error) {
 
+	selection := options.Selection
+	err := selection.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	allSections := defaultReportSections()
+
+	if options.ChecksFile != "" {
+		definitions, err := checks.LoadDeclarativeDefinitions(options.ChecksFile)
+		if err != nil {
+			return nil, err
+		}
+
+		allSections, err = addDeclarativeChecks(allSections, definitions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.PluginDir != "" {
+		plugins, err := checks.DiscoverPlugins(options.PluginDir)
+		if err != nil {
+			return nil, err
+		}
+
+		allSections = append(allSections, pluginSections(plugins)...)
+	}
+
+	sections := selection.Filter(allSections)
+	if len(sections) == 0 {
+		return nil, errors.New("no checks match the '--only' and '--skip' selection")
+	}
+
 	storeDirectory := path.Join(rawDataDir, id)
 
-	err := os.MkdirAll(storeDirectory, 0755)
+	err = os.MkdirAll(storeDirectory, 0755)
 	if err != nil {
 		return nil, err
 	}
 
 	outputStore := output.NewDirectoryStore(storeDirectory)
-	outputArchive := &output.TarGzipArchive{OutputDir: rawDataDir}
+	var outputArchive output.Archive = &output.TarGzipArchive{OutputDir: rawDataDir}
+	if options.EncryptTo != nil {
+		outputArchive = &output.EncryptedArchive{
+			Unencrypted: outputArchive,
+			PublicKey:   options.EncryptTo,
+		}
+	}
+
+	// The archive is signed as it is shared, after it is encrypted, so it can
+	// be verified without decrypting it
+	if options.SignKey != nil {
+		outputArchive = &output.SignedArchive{
+			Unsigned:     outputArchive,
+			PrivateKey:   options.SignKey,
+			ManifestName: report.ManifestFileName,
+		}
+	}
 
 	return reports.NewStandardReport(
 		id,
-		defaultReportSections(),
+		sections,
 		outputStore,
 		outputArchive,
 	), nil
@@ -243,3 +323,66 @@ func defaultReportSections() []report.Section {
 		},
 	}
 }
+
+// pluginSections returns a section for each plugin, so that plugin results are
+// reported separately from the standard checks
+func pluginSections(plugins []*checks.Plugin) []report.Section {
+	sections := []report.Section{}
+	for _, plugin := range plugins {
+		sections = append(sections, report.Section{
+			Title:  plugin.Describe(),
+			Checks: []check.Check{plugin},
+		})
+	}
+
+	return sections
+}
+
+// addDeclarativeChecks adds a check for each definition and container runtime
+// to the section it names, creating the section after the others if needed
+func addDeclarativeChecks(
+	sections []report.Section,
+	definitions []checks.DeclarativeDefinition,
+) ([]report.Section, error) {
+	ids := map[string]bool{}
+	for _, section := range sections {
+		for _, sectionCheck := range section.Checks {
+			ids[sectionCheck.ID()] = true
+		}
+	}
+
+	for _, definition := range definitions {
+		declarativeChecks := []check.Check{
+			&checks.Declarative{
+				Definition: definition,
+				Provider:   &container.DockerProvider{},
+			},
+			&checks.Declarative{
+				Definition: definition,
+				Provider:   &container.PodmanProvider{},
+			},
+		}
+
+		for _, declarativeCheck := range declarativeChecks {
+			if ids[declarativeCheck.ID()] {
+				return nil, fmt.Errorf(
+					"check ID in checks file is already used: %s",
+					definition.ID,
+				)
+			}
+			ids[declarativeCheck.ID()] = true
+		}
+
+		index := slices.IndexFunc(sections, func(section report.Section) bool {
+			return strings.EqualFold(section.Title, definition.Section)
+		})
+		if index == -1 {
+			sections = append(sections, report.Section{Title: definition.Section})
+			index = len(sections) - 1
+		}
+
+		sections[index].Checks = append(sections[index].Checks, declarativeChecks...)
+	}
+
+	return sections, nil
+}
@@ -1,10 +1,16 @@
-package cmd_test
+package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
-	"github.com/cyberark/conjur-inspect/pkg/cmd"
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/checks"
+	"github.com/cyberark/conjur-inspect/pkg/report"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewDefaultReport(t *testing.T) {
@@ -14,9 +20,158 @@ func TestNewDefaultReport(t *testing.T) {
 
 	id := "test-id"
 
-	report, err := cmd.NewDefaultReport(id, ".")
+	report, err := NewDefaultReport(id, ".", DefaultReportOptions{})
 
 	assert.Equal(t, id, report.ID())
 	assert.NotNil(t, report)
 	assert.Nil(t, err)
 }
+
+func TestDefaultRequirementsCheckIDs(t *testing.T) {
+	checkIDs := []string{}
+	for _, section := range defaultReportSections() {
+		for _, sectionCheck := range section.Checks {
+			checkIDs = append(checkIDs, sectionCheck.ID())
+		}
+	}
+
+	// Requirements are looked up by check ID, so each must match a check
+	for checkID := range check.DefaultRequirements() {
+		assert.Contains(t, checkIDs, checkID)
+	}
+}
+
+func TestNewDefaultReportSelection(t *testing.T) {
+	_, err := NewDefaultReport(
+		"test-id",
+		".",
+		DefaultReportOptions{
+			Selection: report.Selection{Only: []string{"missing.*"}},
+		},
+	)
+	assert.EqualError(t, err, "no checks match the '--only' and '--skip' selection")
+
+	_, err = NewDefaultReport(
+		"test-id",
+		".",
+		DefaultReportOptions{
+			Selection: report.Selection{Skip: []string{"disk.["}},
+		},
+	)
+	assert.ErrorContains(t, err, "invalid check selector 'disk.['")
+}
+
+func TestDefaultReportSelectionKeepsContainerAvailability(t *testing.T) {
+	sections := report.Selection{Only: []string{"conjur.*"}}.Filter(
+		defaultReportSections(),
+	)
+
+	// Without the availability check, the container checks would run against
+	// runtimes that aren't installed instead of suppressing their errors
+	checkIDs := []string{}
+	for _, section := range sections {
+		for _, sectionCheck := range section.Checks {
+			checkIDs = append(checkIDs, sectionCheck.ID())
+		}
+	}
+	assert.Contains(t, checkIDs, "container.availability")
+	assert.Contains(t, checkIDs, "conjur.health.docker")
+	assert.NotContains(t, checkIDs, "host.os")
+}
+
+func TestNewDefaultReportPlugins(t *testing.T) {
+	pluginDir := t.TempDir()
+	require.NoError(
+		t,
+		os.WriteFile(filepath.Join(pluginDir, "proxy.sh"), []byte("#!/bin/sh\n"), 0755),
+	)
+
+	// Plugins may be selected like any other check
+	_, err := NewDefaultReport(
+		"test-id",
+		t.TempDir(),
+		DefaultReportOptions{
+			PluginDir: pluginDir,
+			Selection: report.Selection{Only: []string{"plugin.proxy"}},
+		},
+	)
+	assert.NoError(t, err)
+
+	_, err = NewDefaultReport(
+		"test-id",
+		t.TempDir(),
+		DefaultReportOptions{PluginDir: filepath.Join(pluginDir, "missing")},
+	)
+	assert.ErrorContains(t, err, "unable to read plugin directory")
+}
+
+func TestNewDefaultReportChecksFile(t *testing.T) {
+	checksFile := filepath.Join(t.TempDir(), "checks.yml")
+	require.NoError(
+		t,
+		os.WriteFile(
+			checksFile,
+			[]byte("checks:\n  - id: conjur.nginx\n    title: Nginx\n    command: [nginx, -T]\n"),
+			0600,
+		),
+	)
+
+	// Declarative checks may be selected like any other check
+	_, err := NewDefaultReport(
+		"test-id",
+		t.TempDir(),
+		DefaultReportOptions{
+			ChecksFile: checksFile,
+			Selection:  report.Selection{Only: []string{"conjur.nginx"}},
+		},
+	)
+	assert.NoError(t, err)
+
+	_, err = NewDefaultReport(
+		"test-id",
+		t.TempDir(),
+		DefaultReportOptions{ChecksFile: filepath.Join(t.TempDir(), "missing.yml")},
+	)
+	assert.ErrorContains(t, err, "unable to read checks file")
+}
+
+func TestAddDeclarativeChecks(t *testing.T) {
+	definitions := []checks.DeclarativeDefinition{
+		{ID: "conjur.nginx", Section: "conjur", Title: "Nginx"},
+		{ID: "site.proxy", Section: "Custom", Title: "Proxy"},
+	}
+
+	sections, err := addDeclarativeChecks(defaultReportSections(), definitions)
+	require.NoError(t, err)
+
+	// Checks are added to an existing section with the same title, or to a
+	// new section after the standard ones
+	conjurSection := sections[slices.IndexFunc(sections, func(section report.Section) bool {
+		return section.Title == "Conjur"
+	})]
+	assert.Equal(t, "conjur.nginx.docker", conjurSection.Checks[len(conjurSection.Checks)-2].ID())
+	assert.Equal(t, "conjur.nginx.podman", conjurSection.Checks[len(conjurSection.Checks)-1].ID())
+
+	customSection := sections[len(sections)-1]
+	assert.Equal(t, "Custom", customSection.Title)
+	assert.Len(t, customSection.Checks, 2)
+
+	_, err = addDeclarativeChecks(
+		defaultReportSections(),
+		[]checks.DeclarativeDefinition{{ID: "conjur.info", Title: "Info"}},
+	)
+	assert.EqualError(t, err, "check ID in checks file is already used: conjur.info")
+}
+
+func TestDefaultReportCheckIDs(t *testing.T) {
+	// Check IDs must be unique, so that each check can be selected on its own
+	ids := map[string]bool{}
+	for _, section := range defaultReportSections() {
+		for _, sectionCheck := range section.Checks {
+			id := sectionCheck.ID()
+			assert.NotEmpty(t, id)
+			assert.False(t, ids[id], "duplicate check ID: %s", id)
+			ids[id] = true
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+func newDiffCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <old-archive> <new-archive>",
+		Short: "Compare the reports and raw outputs in two raw data archives",
+		Long: "Compare the reports in two raw data archives check by check, " +
+			"showing the results that changed status or value, appeared or " +
+			"disappeared, and the raw output files that were added, removed or " +
+			"changed.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputFormat, err := reportFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat != formatText && outputFormat != formatJSON {
+				return fmt.Errorf("diff doesn't support '--format=%s'", outputFormat)
+			}
+
+			verboseErrors, err := cmd.Flags().GetBool("verbose-errors")
+			if err != nil {
+				return err
+			}
+
+			oldContents, err := readArchive(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read archive: %w", err)
+			}
+
+			newContents, err := readArchive(args[1])
+			if err != nil {
+				return fmt.Errorf("unable to read archive: %w", err)
+			}
+
+			oldResult := oldContents.Result
+			newResult := newContents.Result
+			if !verboseErrors {
+				oldResult = oldResult.Unsuppressed()
+				newResult = newResult.Unsuppressed()
+			}
+
+			diff := report.Diff{
+				Old: report.DiffSource{
+					Name:    filepath.Base(args[0]),
+					Version: oldResult.Version,
+				},
+				New: report.DiffSource{
+					Name:    filepath.Base(args[1]),
+					Version: newResult.Version,
+				},
+				Results: report.CompareResults(&oldResult, &newResult),
+				RawOutputs: report.CompareRawOutputs(
+					oldContents.Files,
+					newContents.Files,
+				),
+			}
+
+			writer := newDiffWriter(cmd.OutOrStdout(), outputFormat == formatJSON)
+			return writer.Write(cmd.OutOrStdout(), &diff)
+		},
+	}
+}
+
+// newDiffWriter returns the diff writer for the requested output format. Text
+// is only colored when written to a terminal.
+func newDiffWriter(out io.Writer, jsonOutput bool) formatting.DiffWriter {
+	switch {
+	case jsonOutput:
+		return &formatting.JSONDiff{}
+	case isTerminal(out):
+		return &formatting.TextDiff{
+			FormatStrategy: &formatting.RichANSIFormatStrategy{},
+		}
+	default:
+		return &formatting.TextDiff{
+			FormatStrategy: &formatting.PlainFormatStrategy{},
+		}
+	}
+}
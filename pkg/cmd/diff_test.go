@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCommand(t *testing.T) {
+	oldArchive, newArchive := writeTestDiffArchives(t)
+
+	stdout, err := executeCommand("diff", oldArchive, newArchive)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "Old: test-report.tar.gz (Version: 1.0.0)")
+	assert.Contains(t, stdout, "New: test-report.tar.gz (Version: 1.1.0)")
+	assert.Contains(
+		t,
+		stdout,
+		"CHANGED - Disk - Read IOPs: PASS (120) -> WARN (40)",
+	)
+	assert.Contains(t, stdout, "REMOVED - Disk - Write IOPs: PASS (100)")
+	assert.Contains(t, stdout, "ADDED - Conjur - Health: PASS (ok)")
+	assert.Contains(t, stdout, "CHANGED - conjur.yml")
+	assert.Contains(t, stdout, "ADDED - postgresql.conf")
+	assert.NotContains(t, stdout, "unchanged.txt")
+}
+
+func TestDiffCommandJSON(t *testing.T) {
+	oldArchive, newArchive := writeTestDiffArchives(t)
+
+	stdout, err := executeCommand("diff", "--json", oldArchive, newArchive)
+	require.NoError(t, err)
+
+	diff := report.Diff{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &diff))
+
+	assert.Equal(t, "1.1.0", diff.New.Version)
+	assert.Len(t, diff.Results, 3)
+	assert.Equal(
+		t,
+		[]report.RawOutputChange{
+			{Change: report.ChangeChanged, Name: "conjur.yml"},
+			{Change: report.ChangeAdded, Name: "postgresql.conf"},
+		},
+		diff.RawOutputs,
+	)
+}
+
+func TestDiffCommandMissingArchive(t *testing.T) {
+	oldArchive, _ := writeTestDiffArchives(t)
+
+	_, err := executeCommand("diff", oldArchive, "missing.tar.gz")
+	assert.ErrorContains(t, err, "unable to read archive")
+}
+
+func TestDiffCommandUnsupportedFormat(t *testing.T) {
+	oldArchive, newArchive := writeTestDiffArchives(t)
+
+	_, err := executeCommand("diff", "--format", "html", oldArchive, newArchive)
+	assert.EqualError(t, err, "diff doesn't support '--format=html'")
+
+	_, err = executeCommand("diff", "--format", "markdown", oldArchive, newArchive)
+	assert.EqualError(t, err, "diff doesn't support '--format=markdown'")
+}
+
+func writeTestDiffArchives(t *testing.T) (string, string) {
+	oldArchive := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.0.0",
+			Sections: []report.ResultSection{
+				{
+					Title: "Disk",
+					Results: []check.Result{
+						{CheckID: "disk.fio.iops", Title: "Read IOPs", Status: check.StatusPass, Value: "120"},
+						{CheckID: "disk.fio.iops", Title: "Write IOPs", Status: check.StatusPass, Value: "100"},
+					},
+				},
+			},
+		},
+		map[string]string{
+			"conjur.yml":    "old",
+			"unchanged.txt": "same",
+		},
+	)
+
+	newArchive := writeTestArchive(
+		t,
+		&report.Result{
+			Version: "1.1.0",
+			Sections: []report.ResultSection{
+				{
+					Title: "Disk",
+					Results: []check.Result{
+						{CheckID: "disk.fio.iops", Title: "Read IOPs", Status: check.StatusWarn, Value: "40"},
+					},
+				},
+				{
+					Title: "Conjur",
+					Results: []check.Result{
+						{CheckID: "conjur.health.docker", Title: "Health", Status: check.StatusPass, Value: "ok"},
+					},
+				},
+			},
+		},
+		map[string]string{
+			"conjur.yml":      "new",
+			"postgresql.conf": "added",
+			"unchanged.txt":   "same",
+		},
+	)
+
+	return oldArchive, newArchive
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// Exit codes used with '--fail-on', based on the worst result status. Exit
+// code 1 is used for any other error.
+const (
+	exitCodeWarn  = 2
+	exitCodeFail  = 3
+	exitCodeError = 4
+)
+
+// failOnStatuses maps the '--fail-on' values to the least severe status that
+// causes a non-zero exit code
+var failOnStatuses = map[string]string{
+	"warn":  check.StatusWarn,
+	"fail":  check.StatusFail,
+	"error": check.StatusError,
+}
+
+// exitStatusError is returned by the root command when the report results
+// should cause a specific exit code, rather than the default for errors.
+type exitStatusError struct {
+	code   int
+	status string
+}
+
+func (err *exitStatusError) Error() string {
+	return fmt.Sprintf("inspection found %s results", err.status)
+}
+
+// statusExitCode returns the exit code for the worst result status
+func statusExitCode(status string) int {
+	switch check.Severity(status) {
+	case check.Severity(check.StatusWarn):
+		return exitCodeWarn
+	case check.Severity(check.StatusFail):
+		return exitCodeFail
+	case check.Severity(check.StatusError):
+		return exitCodeError
+	default:
+		return 0
+	}
+}
+
+// resultSummary returns a one-line summary of the result statuses for the
+// standard error stream, so it isn't mixed with the report on standard out.
+func resultSummary(result *report.Result, exitCode int) string {
+	counts := result.StatusCounts()
+
+	return fmt.Sprintf(
+		"Worst result: %s (%d FAIL, %d WARN, %d ERROR, %d TIMEOUT), exit code %d",
+		result.WorstStatus(),
+		counts[check.StatusFail],
+		counts[check.StatusWarn],
+		counts[check.StatusError],
+		counts[check.StatusTimeout],
+		exitCode,
+	)
+}
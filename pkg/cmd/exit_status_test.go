@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/cyberark/conjur-inspect/pkg/reports"
+	"github.com/cyberark/conjur-inspect/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// statusCheck returns a single result with the given status
+type statusCheck struct {
+	status string
+}
+
+func (sc *statusCheck) Describe() string {
+	return sc.status
+}
+
+func (sc *statusCheck) ID() string {
+	return "test.status"
+}
+
+func (sc *statusCheck) Run(*check.RunContext) []check.Result {
+	return []check.Result{{Title: "Status", Status: sc.status}}
+}
+
+func TestStatusExitCode(t *testing.T) {
+	assert.Equal(t, 0, statusExitCode(check.StatusInfo))
+	assert.Equal(t, 0, statusExitCode(check.StatusPass))
+	assert.Equal(t, exitCodeWarn, statusExitCode(check.StatusWarn))
+	assert.Equal(t, exitCodeFail, statusExitCode(check.StatusFail))
+	assert.Equal(t, exitCodeError, statusExitCode(check.StatusError))
+	assert.Equal(t, exitCodeError, statusExitCode(check.StatusTimeout))
+}
+
+func TestResultSummary(t *testing.T) {
+	result := report.Result{
+		Sections: []report.ResultSection{
+			{
+				Results: []check.Result{
+					{Status: check.StatusWarn},
+					{Status: check.StatusFail},
+					{Status: check.StatusPass},
+					{Status: check.StatusError, Suppressed: true},
+				},
+			},
+		},
+	}
+
+	assert.Equal(
+		t,
+		"Worst result: FAIL (1 FAIL, 1 WARN, 0 ERROR, 0 TIMEOUT), exit code 3",
+		resultSummary(&result, exitCodeFail),
+	)
+}
+
+func TestRootCommandFailOn(t *testing.T) {
+	originalConstructor := defaultReportConstructor
+	defer func() {
+		defaultReportConstructor = originalConstructor
+	}()
+
+	testCases := []struct {
+		status       string
+		failOn       string
+		expectedCode int
+	}{
+		{status: check.StatusWarn, failOn: "warn", expectedCode: exitCodeWarn},
+		{status: check.StatusFail, failOn: "warn", expectedCode: exitCodeFail},
+		{status: check.StatusTimeout, failOn: "fail", expectedCode: exitCodeError},
+		{status: check.StatusWarn, failOn: "fail", expectedCode: 0},
+		{status: check.StatusFail, failOn: "error", expectedCode: 0},
+		{status: check.StatusPass, failOn: "warn", expectedCode: 0},
+	}
+
+	for _, testCase := range testCases {
+		defaultReportConstructor = newStatusTestReport(testCase.status)
+
+		var stdout, stderr bytes.Buffer
+		rootCmd := newRootCommand()
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		rootCmd.SetArgs([]string{"--fail-on", testCase.failOn})
+
+		err := rootCmd.Execute()
+
+		var exitErr *exitStatusError
+		if testCase.expectedCode == 0 {
+			assert.NoError(t, err)
+		} else if assert.True(t, errors.As(err, &exitErr)) {
+			assert.Equal(t, testCase.expectedCode, exitErr.code)
+		}
+
+		// The summary is always written to stderr
+		assert.Contains(t, stderr.String(), "Worst result: "+testCase.status)
+		assert.NotContains(t, stdout.String(), "Worst result")
+	}
+}
+
+func TestRootCommandFailOnInvalid(t *testing.T) {
+	rootCmd := newRootCommand()
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--fail-on", "info"})
+
+	err := rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid value for '--fail-on': info")
+}
+
+func newStatusTestReport(
+	status string,
+) func(string, string, DefaultReportOptions) (report.Report, error) {
+	return func(string, string, DefaultReportOptions) (report.Report, error) {
+		return reports.NewStandardReport(
+			"test",
+			[]report.Section{
+				{
+					Title:  "Test",
+					Checks: []check.Check{&statusCheck{status: status}},
+				},
+			},
+			test.NewOutputStore(),
+			&test.OutputArchive{},
+		), nil
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// fileOutput is a report written to a file with '--output <format>=<path>'
+type fileOutput struct {
+	format string
+	path   string
+}
+
+// archiveCopyNames are the names of the copies of the report saved in the raw
+// data archive, by format. JSON isn't copied, since the complete report is
+// always archived as JSON.
+var archiveCopyNames = map[string]string{
+	formatText:     "conjur-inspect.txt",
+	formatHTML:     "conjur-inspect.html",
+	formatMarkdown: "conjur-inspect.md",
+	formatJUnit:    "conjur-inspect.junit.xml",
+}
+
+// parseFileOutputs parses the values of '--output'
+func parseFileOutputs(values []string) ([]fileOutput, error) {
+	outputs := []fileOutput{}
+
+	for _, value := range values {
+		format, path, found := strings.Cut(value, "=")
+		if !found || path == "" || !slices.Contains(reportFormats, format) {
+			return nil, fmt.Errorf(
+				"invalid value for '--output': %s (must be <format>=<path>, where "+
+					"the format is text, json, html, markdown or junit)",
+				value,
+			)
+		}
+
+		outputs = append(outputs, fileOutput{format: format, path: path})
+	}
+
+	return outputs, nil
+}
+
+// writeFileOutputs writes the report result to each requested file. The
+// status filter only applies to the report written to stdout, so the files
+// include every result.
+func writeFileOutputs(
+	outputs []fileOutput,
+	result *report.Result,
+	options reportWriterOptions,
+) error {
+	for _, output := range outputs {
+		err := writeFileOutput(output, result, options)
+		if err != nil {
+			return fmt.Errorf("unable to write report to %s: %w", output.path, err)
+		}
+	}
+
+	return nil
+}
+
+func writeFileOutput(
+	output fileOutput,
+	result *report.Result,
+	options reportWriterOptions,
+) error {
+	file, err := os.Create(output.path)
+	if err != nil {
+		return err
+	}
+
+	options.format = output.format
+	options.statuses = nil
+	err = newReportWriter(file, options).Write(file, result)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// archiveCopies returns the copies of the report to save in the raw data
+// archive: the HTML report if archiveHTML is set, and each of the file
+// outputs if archiveOutputs is set. Only one copy of each format is saved, and
+// like the file outputs, copies include every result regardless of the status
+// filter.
+func archiveCopies(
+	outputs []fileOutput,
+	archiveHTML bool,
+	archiveOutputs bool,
+	options reportWriterOptions,
+) []report.ArchiveCopy {
+	copies := []report.ArchiveCopy{}
+	copied := map[string]bool{}
+
+	if archiveHTML {
+		copies = append(copies, report.ArchiveCopy{
+			Name:   archiveCopyNames[formatHTML],
+			Writer: &formatting.HTML{ShowRawOutputs: true},
+		})
+		copied[formatHTML] = true
+	}
+
+	if !archiveOutputs {
+		return copies
+	}
+
+	for _, output := range outputs {
+		name, ok := archiveCopyNames[output.format]
+		if !ok || copied[output.format] {
+			continue
+		}
+
+		options.format = output.format
+		options.statuses = nil
+		copies = append(copies, report.ArchiveCopy{
+			Name: name,
+			// Copies are rendered like a file, rather than for a terminal
+			Writer: newReportWriter(io.Discard, options),
+		})
+		copied[output.format] = true
+	}
+
+	return copies
+}
+
+// isArchiveCopy returns whether a file in a raw data archive is a copy of the
+// report, rather than a raw output
+func isArchiveCopy(name string) bool {
+	for _, copyName := range archiveCopyNames {
+		if name == copyName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFileOutputs(t *testing.T) {
+	outputs, err := parseFileOutputs([]string{"json=report.json", "html=out/a=b.html"})
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]fileOutput{
+			{format: formatJSON, path: "report.json"},
+			{format: formatHTML, path: "out/a=b.html"},
+		},
+		outputs,
+	)
+
+	for _, value := range []string{"report.json", "pdf=report.pdf", "json="} {
+		_, err = parseFileOutputs([]string{value})
+		assert.ErrorContains(t, err, "invalid value for '--output': "+value+" ")
+	}
+}
+
+func TestWriteFileOutputs(t *testing.T) {
+	dir := t.TempDir()
+	result := &report.Result{
+		Version: "1.0.0",
+		Sections: []report.ResultSection{
+			{
+				Title:   "Host",
+				Results: []check.Result{{Title: "OS", Status: check.StatusInfo, Value: "linux"}},
+			},
+		},
+	}
+
+	// The status filter only applies to stdout
+	err := writeFileOutputs(
+		[]fileOutput{
+			{format: formatJSON, path: filepath.Join(dir, "report.json")},
+			{format: formatText, path: filepath.Join(dir, "report.txt")},
+		},
+		result,
+		reportWriterOptions{
+			format:   formatHTML,
+			statuses: []string{check.StatusWarn, check.StatusFail},
+		},
+	)
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(filepath.Join(dir, "report.json"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(contents), "{"))
+
+	// Text files are never colored
+	contents, err = os.ReadFile(filepath.Join(dir, "report.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "INFO - OS: linux\n")
+	assert.NotContains(t, string(contents), "\033[")
+
+	err = writeFileOutputs(
+		[]fileOutput{{format: formatJSON, path: filepath.Join(dir, "missing", "a.json")}},
+		result,
+		reportWriterOptions{},
+	)
+	assert.ErrorContains(t, err, "unable to write report to "+filepath.Join(dir, "missing", "a.json"))
+}
+
+func TestArchiveCopies(t *testing.T) {
+	outputs := []fileOutput{
+		{format: formatJSON, path: "report.json"},
+		{format: formatHTML, path: "report.html"},
+		{format: formatMarkdown, path: "report.md"},
+		{format: formatMarkdown, path: "other.md"},
+	}
+
+	assert.Empty(t, archiveCopies(outputs, false, false, reportWriterOptions{}))
+
+	// The HTML report is archived with its raw outputs listed
+	copies := archiveCopies(outputs, true, false, reportWriterOptions{})
+	assert.Equal(
+		t,
+		[]report.ArchiveCopy{
+			{Name: "conjur-inspect.html", Writer: &formatting.HTML{ShowRawOutputs: true}},
+		},
+		copies,
+	)
+
+	// JSON is already archived, and each format is only copied once
+	copies = archiveCopies(outputs, false, true, reportWriterOptions{})
+	require.Len(t, copies, 2)
+	assert.Equal(t, "conjur-inspect.html", copies[0].Name)
+	assert.Equal(t, "conjur-inspect.md", copies[1].Name)
+	assert.IsType(t, &formatting.Markdown{}, copies[1].Writer)
+
+	// Text copies include every result, regardless of the status filter
+	copies = archiveCopies(
+		[]fileOutput{{format: formatText, path: "report.txt"}},
+		false,
+		true,
+		reportWriterOptions{statuses: []string{check.StatusFail}},
+	)
+	require.Len(t, copies, 1)
+	require.IsType(t, &formatting.Text{}, copies[0].Writer)
+	assert.Empty(t, copies[0].Writer.(*formatting.Text).Statuses)
+}
+
+func TestRootCommandFileOutputs(t *testing.T) {
+	defaultReportConstructor = newTestReport
+	outputPath := filepath.Join(t.TempDir(), "report.md")
+
+	var stdout bytes.Buffer
+	rootCmd := newRootCommand()
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--quiet", "--output", "markdown=" + outputPath})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Empty(t, stdout.String())
+
+	contents, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(contents), "# Conjur Enterprise Inspection Report"))
+}
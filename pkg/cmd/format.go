@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+// Report output formats
+const (
+	formatText     = "text"
+	formatJSON     = "json"
+	formatHTML     = "html"
+	formatMarkdown = "markdown"
+	formatJUnit    = "junit"
+)
+
+// reportFormats are the values accepted by '--format'
+var reportFormats = []string{
+	formatText,
+	formatJSON,
+	formatHTML,
+	formatMarkdown,
+	formatJUnit,
+}
+
+// Values accepted by '--junit-warn'
+const (
+	junitWarnSkipped = "skipped"
+	junitWarnFailure = "failure"
+)
+
+// reportFormat returns the output format requested with '--format'. '--json'
+// is an alias for '--format json', kept for existing scripts.
+func reportFormat(cmd *cobra.Command) (string, error) {
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return "", err
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains(reportFormats, format) {
+		return "", fmt.Errorf(
+			"invalid value for '--format': %s (must be text, json, html, markdown or junit)",
+			format,
+		)
+	}
+
+	if jsonOutput {
+		if cmd.Flags().Changed("format") && format != formatJSON {
+			return "", fmt.Errorf("'--json' can't be combined with '--format=%s'", format)
+		}
+
+		return formatJSON, nil
+	}
+
+	return format, nil
+}
+
+// junitWarnAsFailure returns whether WARN results are reported as failures in
+// JUnit XML, rather than as skipped tests
+func junitWarnAsFailure(cmd *cobra.Command) (bool, error) {
+	junitWarn, err := cmd.Flags().GetString("junit-warn")
+	if err != nil {
+		return false, err
+	}
+
+	switch junitWarn {
+	case junitWarnSkipped:
+		return false, nil
+	case junitWarnFailure:
+		return true, nil
+	}
+
+	return false, fmt.Errorf(
+		"invalid value for '--junit-warn': %s (must be skipped or failure)",
+		junitWarn,
+	)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportFormat(t *testing.T) {
+	testCases := []struct {
+		args           []string
+		expectedFormat string
+		expectedError  string
+	}{
+		{args: []string{}, expectedFormat: formatText},
+		{args: []string{"--json"}, expectedFormat: formatJSON},
+		{args: []string{"--format", "json"}, expectedFormat: formatJSON},
+		{args: []string{"--json", "--format", "json"}, expectedFormat: formatJSON},
+		{args: []string{"--format", "html"}, expectedFormat: formatHTML},
+		{args: []string{"--format", "markdown"}, expectedFormat: formatMarkdown},
+		{args: []string{"--format", "junit"}, expectedFormat: formatJUnit},
+		{
+			args:          []string{"--json", "--format", "html"},
+			expectedError: "'--json' can't be combined with '--format=html'",
+		},
+		{
+			args:          []string{"--format", "pdf"},
+			expectedError: "invalid value for '--format': pdf (must be text, json, html, markdown or junit)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		rootCmd := newRootCommand()
+		cmd := &cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }}
+		rootCmd.AddCommand(cmd)
+		rootCmd.SetArgs(append([]string{"test"}, testCase.args...))
+		require.NoError(t, rootCmd.Execute())
+
+		format, err := reportFormat(cmd)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedFormat, format)
+	}
+}
+
+func TestJUnitWarnAsFailure(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		expected      bool
+		expectedError string
+	}{
+		{args: []string{}, expected: false},
+		{args: []string{"--junit-warn", "skipped"}, expected: false},
+		{args: []string{"--junit-warn", "failure"}, expected: true},
+		{
+			args:          []string{"--junit-warn", "error"},
+			expectedError: "invalid value for '--junit-warn': error (must be skipped or failure)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		rootCmd := newRootCommand()
+		cmd := &cobra.Command{Use: "test", RunE: func(*cobra.Command, []string) error { return nil }}
+		rootCmd.AddCommand(cmd)
+		rootCmd.SetArgs(append([]string{"test"}, testCase.args...))
+		require.NoError(t, rootCmd.Execute())
+
+		warnAsFailure, err := junitWarnAsFailure(cmd)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, warnAsFailure)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/signing"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultSigningKeyName is the default file name of a generated signing key
+const defaultSigningKeyName = "conjur-inspect-signing.pem"
+
+func newKeygenCommand() *cobra.Command {
+	var privateKeyFile string
+
+	keygenCmd := &cobra.Command{
+		Use:   "keygen [--output <private-key-file>]",
+		Short: "Generate an ed25519 key pair to sign raw data archives",
+		Long: "Generate an ed25519 key pair to sign raw data archives with " +
+			"'--sign-key' and verify them with 'conjur-inspect verify'. The " +
+			"private key is written to the output file, readable only by its " +
+			"owner, and the public key next to it (e.g. signing.pem and " +
+			"signing.pub.pem).",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			publicKeyFile := publicKeyPath(privateKeyFile)
+
+			publicPEM, privatePEM, err := signing.GenerateKey()
+			if err != nil {
+				return err
+			}
+
+			for _, path := range []string{privateKeyFile, publicKeyFile} {
+				_, err := os.Stat(path)
+				if err == nil {
+					return fmt.Errorf("%s already exists", path)
+				}
+				if !errors.Is(err, os.ErrNotExist) {
+					return err
+				}
+			}
+
+			err = writeNewFile(privateKeyFile, privatePEM, 0600)
+			if err != nil {
+				return err
+			}
+
+			// Don't leave a private key without its public key behind, since
+			// it would stop the next run from writing the key pair
+			err = writeNewFile(publicKeyFile, publicPEM, 0644)
+			if err != nil {
+				_ = os.Remove(privateKeyFile)
+				return err
+			}
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"Private key: %s\nPublic key: %s\n",
+				privateKeyFile,
+				publicKeyFile,
+			)
+			return nil
+		},
+	}
+
+	keygenCmd.Flags().StringVarP(
+		&privateKeyFile,
+		"output",
+		"o",
+		defaultSigningKeyName,
+		"Where to write the private key",
+	)
+
+	return keygenCmd
+}
+
+// publicKeyPath returns where the public key of a generated private key is
+// written, inserting .pub before a .pem extension
+func publicKeyPath(privateKeyFile string) string {
+	if filepath.Ext(privateKeyFile) == ".pem" {
+		return strings.TrimSuffix(privateKeyFile, ".pem") + ".pub.pem"
+	}
+
+	return privateKeyFile + ".pub"
+}
+
+// writeNewFile writes data to a file that mustn't already exist. The file is
+// removed if it can't be written completely.
+func writeNewFile(path string, data []byte, perm os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeygenCommand(t *testing.T) {
+	privateKeyFile := filepath.Join(t.TempDir(), "signing.pem")
+	publicKeyFile := filepath.Join(filepath.Dir(privateKeyFile), "signing.pub.pem")
+
+	stdout, err := executeCommand("keygen", "--output", privateKeyFile)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"Private key: "+privateKeyFile+"\nPublic key: "+publicKeyFile+"\n",
+		stdout,
+	)
+
+	privateKey, err := signing.LoadPrivateKey(privateKeyFile)
+	require.NoError(t, err)
+	publicKey, err := signing.LoadPublicKey(publicKeyFile)
+	require.NoError(t, err)
+	assert.True(t, publicKey.Equal(privateKey.Public()))
+
+	info, err := os.Stat(privateKeyFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// Existing keys aren't overwritten
+	_, err = executeCommand("keygen", "--output", privateKeyFile)
+	assert.ErrorContains(t, err, "already exists")
+}
+
+func TestKeygenCommandPublicKeyWriteFails(t *testing.T) {
+	dir := t.TempDir()
+	privateKeyFile := filepath.Join(dir, "signing.pem")
+
+	// A dangling symlink doesn't exist as far as os.Stat is concerned, but
+	// stops the public key from being created
+	require.NoError(t, os.Symlink(
+		filepath.Join(dir, "missing", "key.pem"),
+		filepath.Join(dir, "signing.pub.pem"),
+	))
+
+	_, err := executeCommand("keygen", "--output", privateKeyFile)
+	require.Error(t, err)
+
+	// The private key isn't left behind without its public key
+	assert.NoFileExists(t, privateKeyFile)
+}
+
+func TestPublicKeyPath(t *testing.T) {
+	assert.Equal(t, "signing.pub.pem", publicKeyPath("signing.pem"))
+	assert.Equal(t, "keys/signing.pub", publicKeyPath("keys/signing"))
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/progress"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// Values accepted by '--progress'
+const (
+	progressBar    = "bar"
+	progressNone   = "none"
+	progressNDJSON = "ndjson"
+)
+
+// stderrFD is the default file descriptor for NDJSON progress events
+const stderrFD = 2
+
+// newRunProgress returns how the progress of the run is reported. NDJSON
+// events are written to stderr, or to another file descriptor opened by the
+// calling process. When they're written to stderr, log messages are written
+// as log events in the same stream, so every line of stderr is an event, until
+// the returned function is called.
+func newRunProgress(
+	mode string,
+	fd int,
+	stderr io.Writer,
+) (report.Progress, func(), error) {
+	if fd != stderrFD && mode != progressNDJSON {
+		return nil, nil, errors.New("'--progress-fd' only applies to '--progress=ndjson'")
+	}
+
+	switch mode {
+	case progressBar:
+		return progress.NewBar(os.Stderr), func() {}, nil
+	case progressNone:
+		return progress.None{}, func() {}, nil
+	case progressNDJSON:
+		if ndjsonOnStderr(mode, fd) {
+			ndjson := progress.NewNDJSON(stderr)
+			log.SetHandler(ndjson.Log)
+
+			return ndjson, func() { log.SetHandler(nil) }, nil
+		}
+
+		file := os.NewFile(uintptr(fd), fmt.Sprintf("fd%d", fd))
+		if file == nil {
+			return nil, nil, fmt.Errorf("invalid value for '--progress-fd': %d", fd)
+		}
+
+		_, err := file.Stat()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid value for '--progress-fd': %w", err)
+		}
+
+		return progress.NewNDJSON(file), func() {}, nil
+	}
+
+	return nil, nil, fmt.Errorf(
+		"invalid value for '--progress': %s (must be bar, none or ndjson)",
+		mode,
+	)
+}
+
+// ndjsonOnStderr returns whether NDJSON progress events are written to stderr,
+// alongside log messages
+func ndjsonOnStderr(mode string, fd int) bool {
+	return mode == progressNDJSON && fd == stderrFD
+}
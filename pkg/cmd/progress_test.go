@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunProgress(t *testing.T) {
+	runProgress, _, err := newRunProgress(progressBar, stderrFD, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.IsType(t, &progress.Bar{}, runProgress)
+
+	runProgress, _, err = newRunProgress(progressNone, stderrFD, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, progress.None{}, runProgress)
+
+	var stderr bytes.Buffer
+	runProgress, restoreLog, err := newRunProgress(progressNDJSON, stderrFD, &stderr)
+	require.NoError(t, err)
+	runProgress.RunStarted(3)
+	assert.Contains(t, stderr.String(), `"event":"run_started"`)
+
+	// Log messages are written as events, so they don't corrupt the stream
+	log.Warn("interrupted")
+	restoreLog()
+	log.Warn("restored")
+	assert.Contains(t, stderr.String(), `"event":"log"`)
+	assert.Contains(t, stderr.String(), `"message":"interrupted"`)
+	assert.NotContains(t, stderr.String(), "restored")
+
+	_, _, err = newRunProgress("spinner", stderrFD, &bytes.Buffer{})
+	assert.EqualError(t, err, "invalid value for '--progress': spinner (must be bar, none or ndjson)")
+
+	_, _, err = newRunProgress(progressBar, 3, &bytes.Buffer{})
+	assert.EqualError(t, err, "'--progress-fd' only applies to '--progress=ndjson'")
+}
+
+func TestNewRunProgressFD(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+	defer writer.Close()
+
+	runProgress, _, err := newRunProgress(progressNDJSON, int(writer.Fd()), &bytes.Buffer{})
+	require.NoError(t, err)
+	runProgress.ArchiveWritten("standby.tar.gz")
+
+	buffer := make([]byte, 1024)
+	n, err := reader.Read(buffer)
+	require.NoError(t, err)
+	assert.Contains(t, string(buffer[:n]), `"path":"standby.tar.gz"`)
+
+	_, _, err = newRunProgress(progressNDJSON, 9999, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid value for '--progress-fd'")
+}
@@ -1,25 +1,64 @@
 package cmd
 
 import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/encryption"
 	"github.com/cyberark/conjur-inspect/pkg/formatting"
 	"github.com/cyberark/conjur-inspect/pkg/log"
 	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/cyberark/conjur-inspect/pkg/signing"
 	"github.com/cyberark/conjur-inspect/pkg/version"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var defaultReportConstructor = NewDefaultReport
 
+// defaultPluginDir is where plugins are discovered if '--plugin-dir' isn't
+// given. Unlike a directory given explicitly, it doesn't need to exist.
+const defaultPluginDir = "/etc/conjur-inspect/plugins"
+
+// defaultMaxOutputSize bounds each raw output, so that, for example, the logs
+// of a busy leader don't fill the disk the archive is written to
+const defaultMaxOutputSize = "256MiB"
+
 func newRootCommand() *cobra.Command {
 	var debug bool
 	var jsonOutput bool
+	var format string
+	var archiveHTML bool
+	var junitWarn string
+	var outputValues []string
+	var quiet bool
+	var archiveOutputs bool
+	var progressMode string
+	var progressFD int
 	var verboseErrors bool
+	var concurrency int
+	var checkTimeout time.Duration
+	var maxOutputSize string
+	var requirementsFile string
+	var onlyChecks []string
+	var skipChecks []string
+	var failOn string
+	var pluginDir string
+	var checksFile string
+	var encryptTo string
+	var signKeyFile string
+	var filter statusFilter
 
 	// Defines the time window this inspection is concerned with. Checks may use
 	// this value to focus or expand their scope to the desired time window.
@@ -32,53 +71,180 @@ func newRootCommand() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "conjur-inspect",
 		Short: "Qualification CLI for common Conjur Enterprise self-hosted issues",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			if debug {
+		PersistentPreRun: func(*cobra.Command, []string) {
+			// With NDJSON progress on stderr, debug mode is enabled once log
+			// messages are written as progress events
+			if debug && !ndjsonOnStderr(progressMode, progressFD) {
 				log.EnableDebugMode()
 			}
-
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
 			// Parse the duration string
 			sinceDuration, err := time.ParseDuration(since)
 			if err != nil {
 				return fmt.Errorf("invalid value for '--since': %w", err)
 			}
 
-			commandReport, err := defaultReportConstructor(reportID, rawDataDir)
+			outputFormat, err := reportFormat(cmd)
+			if err != nil {
+				return err
+			}
+
+			statuses, err := filter.displayStatuses(outputFormat)
+			if err != nil {
+				return err
+			}
+
+			junitWarnAsFailure, err := junitWarnAsFailure(cmd)
+			if err != nil {
+				return err
+			}
+
+			maxOutputBytes, err := humanize.ParseBytes(maxOutputSize)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--max-output-size': %w", err)
+			}
+
+			fileOutputs, err := parseFileOutputs(outputValues)
+			if err != nil {
+				return err
+			}
+
+			runProgress, restoreLog, err := newRunProgress(
+				progressMode,
+				progressFD,
+				cmd.ErrOrStderr(),
+			)
+			if err != nil {
+				return err
+			}
+			defer restoreLog()
+
+			if debug && ndjsonOnStderr(progressMode, progressFD) {
+				log.EnableDebugMode()
+			}
+
+			writerOptions := reportWriterOptions{
+				format:             outputFormat,
+				statuses:           statuses,
+				junitWarnAsFailure: junitWarnAsFailure,
+			}
+
+			failOnStatus, ok := failOnStatuses[failOn]
+			if failOn != "" && !ok {
+				return fmt.Errorf(
+					"invalid value for '--fail-on': %s (must be warn, fail or error)",
+					failOn,
+				)
+			}
+
+			requirements := check.DefaultRequirements()
+			if requirementsFile != "" {
+				requirements, err = check.LoadRequirements(requirementsFile)
+				if err != nil {
+					return fmt.Errorf("invalid value for '--requirements': %w", err)
+				}
+			}
+
+			var encryptionKey crypto.PublicKey
+			if encryptTo != "" {
+				encryptionKey, err = encryption.LoadPublicKey(encryptTo)
+				if err != nil {
+					return fmt.Errorf("invalid value for '--encrypt-to': %w", err)
+				}
+			}
+
+			var signKey ed25519.PrivateKey
+			if signKeyFile != "" {
+				signKey, err = signing.LoadPrivateKey(signKeyFile)
+				if err != nil {
+					return fmt.Errorf("invalid value for '--sign-key': %w", err)
+				}
+			}
+
+			// Skip the default plugin directory if it hasn't been created
+			if !cmd.Flags().Changed("plugin-dir") {
+				_, err := os.Stat(pluginDir)
+				if errors.Is(err, os.ErrNotExist) {
+					pluginDir = ""
+				}
+			}
+
+			commandReport, err := defaultReportConstructor(
+				reportID,
+				rawDataDir,
+				DefaultReportOptions{
+					PluginDir:  pluginDir,
+					ChecksFile: checksFile,
+					Selection:  report.Selection{Only: onlyChecks, Skip: skipChecks},
+					EncryptTo:  encryptionKey,
+					SignKey:    signKey,
+				},
+			)
 			if err != nil {
 				return fmt.Errorf("unable to initialize report: %w", err)
 			}
 
+			// Stop the report on Ctrl-C or SIGTERM, so the running checks are
+			// cleaned up and the results collected so far are still archived
+			ctx, stop := interruptibleContext(cmd.Context())
+			defer stop()
+
 			log.Debug("Running report...")
-			result := commandReport.Run(report.RunConfig{
+			result := commandReport.Run(ctx, report.RunConfig{
 				ContainerID:   containerID,
 				Since:         sinceDuration,
 				VerboseErrors: verboseErrors,
+				Concurrency:   concurrency,
+				CheckTimeout:  checkTimeout,
+				Requirements:  requirements,
+				ArchiveCopies: archiveCopies(
+					fileOutputs,
+					archiveHTML,
+					archiveOutputs,
+					writerOptions,
+				),
+				Progress:      runProgress,
+				MaxOutputSize: int64(maxOutputBytes),
+				Flags:         changedFlags(cmd),
 			})
 
-			// Determine which output format we'll use
-			var writer formatting.Writer
-			switch {
-			case jsonOutput:
-				log.Debug("Using JSON report formatting")
-				writer = &formatting.JSON{}
-			case isTerminal(cmd.OutOrStdout()):
-				log.Debug("Using rich text report formatting")
-				writer = &formatting.Text{
-					FormatStrategy: &formatting.RichANSIFormatStrategy{},
-				}
-			default:
-				log.Debug("Using plain text report formatting")
-				writer = &formatting.Text{
-					FormatStrategy: &formatting.PlainFormatStrategy{},
+			// Write the report result
+			if !quiet {
+				writer := newReportWriter(cmd.OutOrStdout(), writerOptions)
+				err = writer.Write(cmd.OutOrStdout(), &result)
+				if err != nil {
+					return err
 				}
 			}
 
-			// Write the report result
-			err = writer.Write(cmd.OutOrStdout(), &result)
+			err = writeFileOutputs(fileOutputs, &result, writerOptions)
 			if err != nil {
 				return err
 			}
 
+			if result.Interrupted {
+				return errors.New("inspection interrupted before all checks finished")
+			}
+
+			if failOn != "" {
+				worstStatus := result.WorstStatus()
+
+				exitCode := 0
+				if check.Severity(worstStatus) >= check.Severity(failOnStatus) {
+					exitCode = statusExitCode(worstStatus)
+				}
+
+				fmt.Fprintln(cmd.ErrOrStderr(), resultSummary(&result, exitCode))
+
+				if exitCode != 0 {
+					// The summary already explains the exit code
+					cmd.SilenceErrors = true
+					cmd.SilenceUsage = true
+					return &exitStatusError{code: exitCode, status: worstStatus}
+				}
+			}
+
 			log.Debug("Inspection finished!")
 			return nil
 		},
@@ -95,7 +261,7 @@ func newRootCommand() *cobra.Command {
 
 	// Create container ID flag for the conjur-inspect command to specify a
 	// container to inspect.
-	rootCmd.PersistentFlags().StringVarP(
+	rootCmd.Flags().StringVarP(
 		&containerID,
 		"container-id",
 		"", // No shorthand
@@ -105,7 +271,7 @@ func newRootCommand() *cobra.Command {
 
 	// Create since flag for the conjur-inspect command to specify a time window
 	// for the inspection.
-	rootCmd.PersistentFlags().StringVarP(
+	rootCmd.Flags().StringVarP(
 		&since,
 		"since",
 		"",    // No shorthand
@@ -120,10 +286,26 @@ func newRootCommand() *cobra.Command {
 		"json",
 		"j",
 		false,
-		"Output report in JSON",
+		"Output report in JSON (same as '--format json')",
 	)
 
 	rootCmd.PersistentFlags().StringVarP(
+		&format,
+		"format",
+		"",         // No shorthand
+		formatText, // Default is text, colored when written to a terminal
+		"Output format of the report: text, json, html, markdown or junit",
+	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&junitWarn,
+		"junit-warn",
+		"", // No shorthand
+		junitWarnSkipped,
+		"How WARN results are reported in JUnit XML: skipped or failure",
+	)
+
+	rootCmd.Flags().StringVarP(
 		&rawDataDir,
 		"data-output-dir",
 		"",  // No shorthand
@@ -131,7 +313,7 @@ func newRootCommand() *cobra.Command {
 		"Where to save the raw data archive",
 	)
 
-	rootCmd.PersistentFlags().StringVarP(
+	rootCmd.Flags().StringVarP(
 		&reportID,
 		"report-id",
 		"", // No shorthand
@@ -150,7 +332,155 @@ func newRootCommand() *cobra.Command {
 		"Display all errors for unavailable container runtimes",
 	)
 
-	// TODO: Ability to adjust requirement criteria (PASS, WARN, FAIL checks)
+	rootCmd.Flags().IntVarP(
+		&concurrency,
+		"concurrency",
+		"", // No shorthand
+		4,
+		"Maximum number of independent checks to run at the same time",
+	)
+
+	rootCmd.Flags().DurationVarP(
+		&checkTimeout,
+		"check-timeout",
+		"", // No shorthand
+		2*time.Minute,
+		"Maximum time each check may run before it is reported as timed out (0 to disable)",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&requirementsFile,
+		"requirements",
+		"", // No shorthand
+		"", // Default is the built-in requirements
+		"YAML or JSON file with the thresholds for PASS, WARN and FAIL results",
+	)
+
+	rootCmd.Flags().StringSliceVarP(
+		&onlyChecks,
+		"only",
+		"", // No shorthand
+		nil,
+		"Only run the checks matching these check IDs, section titles or globs (e.g. 'conjur.*')",
+	)
+
+	rootCmd.Flags().StringSliceVarP(
+		&skipChecks,
+		"skip",
+		"", // No shorthand
+		nil,
+		"Skip the checks matching these check IDs, section titles or globs (e.g. 'disk.fio.*')",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&failOn,
+		"fail-on",
+		"", // No shorthand
+		"", // Default is to exit 0 regardless of the results
+		"Exit non-zero if any result is at least this severe: warn, fail or error "+
+			"(exit code 2 for WARN, 3 for FAIL and 4 for ERROR or TIMEOUT)",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&pluginDir,
+		"plugin-dir",
+		"", // No shorthand
+		defaultPluginDir,
+		"Directory of executable check plugins to run with the report",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&checksFile,
+		"checks-file",
+		"", // No shorthand
+		"", // Default is no declarative checks
+		"YAML file of additional checks that run commands in the Conjur container",
+	)
+
+	rootCmd.Flags().BoolVarP(
+		&archiveHTML,
+		"archive-html",
+		"", // No shorthand
+		false,
+		"Also save the report as HTML (conjur-inspect.html) in the raw data archive",
+	)
+
+	rootCmd.Flags().StringArrayVarP(
+		&outputValues,
+		"output",
+		"o",
+		nil,
+		"Also write the report to a file, as <format>=<path> (e.g. 'json=report.json'). "+
+			"May be repeated.",
+	)
+
+	rootCmd.Flags().BoolVarP(
+		&quiet,
+		"quiet",
+		"q",
+		false,
+		"Don't write the report to stdout",
+	)
+
+	rootCmd.Flags().BoolVarP(
+		&archiveOutputs,
+		"archive-outputs",
+		"", // No shorthand
+		false,
+		"Also save a copy of each '--output' file, except JSON, in the raw data archive",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&progressMode,
+		"progress",
+		"", // No shorthand
+		progressBar,
+		"How to show the progress of the inspection: bar, none, or ndjson for "+
+			"newline-delimited JSON events",
+	)
+
+	rootCmd.Flags().IntVarP(
+		&progressFD,
+		"progress-fd",
+		"", // No shorthand
+		stderrFD,
+		"File descriptor to write the NDJSON progress events to",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&maxOutputSize,
+		"max-output-size",
+		"", // No shorthand
+		defaultMaxOutputSize,
+		"Maximum size of each raw output saved in the raw data archive (e.g. "+
+			"100MiB). Larger outputs are truncated. 0 saves outputs in full.",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&encryptTo,
+		"encrypt-to",
+		"", // No shorthand
+		"", // Default is to leave the archive unencrypted
+		"PEM encoded RSA, X25519 or NIST curve public key to encrypt the raw "+
+			"data archive to. Open it with 'conjur-inspect decrypt'.",
+	)
+
+	rootCmd.Flags().StringVarP(
+		&signKeyFile,
+		"sign-key",
+		"", // No shorthand
+		"", // Default is to leave the archive unsigned
+		"PEM encoded ed25519 private key to sign the raw data archive with, "+
+			"written to <archive>.sig. Generate one with 'conjur-inspect keygen'.",
+	)
+
+	filter.addFlags(rootCmd)
+
+	rootCmd.AddCommand(newAnalyzeCommand())
+	rootCmd.AddCommand(newDiffCommand())
+	rootCmd.AddCommand(newDecryptCommand())
+	rootCmd.AddCommand(newVerifyCommand())
+	rootCmd.AddCommand(newKeygenCommand())
 
 	return rootCmd
 }
@@ -163,12 +493,119 @@ func Execute(stdout, stderr io.Writer) {
 
 	err := rootCmd.Execute()
 
+	var exitErr *exitStatusError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.code)
+	}
+
 	if err != nil {
 		log.Error("ERROR: %s\n", err)
 		os.Exit(1)
 	}
 }
 
+// interruptibleContext returns a context that is canceled when the process
+// receives SIGINT or SIGTERM. After the first signal, the default behavior is
+// restored, so that a second signal exits immediately.
+func interruptibleContext(
+	parent context.Context,
+) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case received := <-signals:
+			signal.Stop(signals)
+			log.Warn(
+				"Received %s, stopping the inspection and saving partial results. "+
+					"Interrupt again to exit immediately.",
+				received,
+			)
+			cancel()
+		case <-ctx.Done():
+		}
+	}() // async
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// reportWriterOptions configures the writer for a report
+type reportWriterOptions struct {
+	format string
+
+	// showRawOutputs lists the raw output files saved by each section, for
+	// the formats that support it
+	showRawOutputs bool
+
+	// statuses limits the results displayed in the text report
+	statuses []string
+
+	// junitWarnAsFailure reports WARN results as failures in JUnit XML
+	junitWarnAsFailure bool
+}
+
+// newReportWriter returns the writer for the requested output format. Text is
+// only colored when written to a terminal, and only displays the results with
+// the given statuses, if any.
+func newReportWriter(
+	out io.Writer,
+	options reportWriterOptions,
+) formatting.Writer {
+	switch {
+	case options.format == formatJSON:
+		log.Debug("Using JSON report formatting")
+		return &formatting.JSON{}
+	case options.format == formatHTML:
+		log.Debug("Using HTML report formatting")
+		return &formatting.HTML{ShowRawOutputs: options.showRawOutputs}
+	case options.format == formatMarkdown:
+		log.Debug("Using Markdown report formatting")
+		return &formatting.Markdown{ShowRawOutputs: options.showRawOutputs}
+	case options.format == formatJUnit:
+		log.Debug("Using JUnit XML report formatting")
+		return &formatting.JUnit{WarnAsFailure: options.junitWarnAsFailure}
+	case isTerminal(out):
+		log.Debug("Using rich text report formatting")
+		return &formatting.Text{
+			FormatStrategy: &formatting.RichANSIFormatStrategy{},
+			ShowRawOutputs: options.showRawOutputs,
+			Statuses:       options.statuses,
+		}
+	default:
+		log.Debug("Using plain text report formatting")
+		return &formatting.Text{
+			FormatStrategy: &formatting.PlainFormatStrategy{},
+			ShowRawOutputs: options.showRawOutputs,
+			Statuses:       options.statuses,
+		}
+	}
+}
+
+// changedFlags returns the flags set on the command line, as they would be
+// written to set them, to record how the report was run
+func changedFlags(cmd *cobra.Command) []string {
+	flags := []string{}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		values := []string{flag.Value.String()}
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			values = sliceValue.GetSlice()
+		}
+
+		for _, value := range values {
+			flags = append(flags, fmt.Sprintf("--%s=%s", flag.Name, value))
+		}
+	})
+
+	return flags
+}
+
 func isTerminal(writer io.Writer) bool {
 	// Test if the writer is for a file. If not, we know it isn't a terminal
 	file, ok := writer.(*os.File)
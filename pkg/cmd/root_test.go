@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/report"
 	"github.com/cyberark/conjur-inspect/pkg/reports"
@@ -20,6 +23,28 @@ func TestNewRootCommand(t *testing.T) {
 	assert.NotNil(t, rootCmd)
 }
 
+func TestInterruptibleContext(t *testing.T) {
+	ctx, stop := interruptibleContext(context.Background())
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	assert.NoError(t, err)
+	assert.NoError(t, process.Signal(syscall.SIGTERM))
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context was not canceled by SIGTERM")
+	}
+}
+
+func TestInterruptibleContextStop(t *testing.T) {
+	ctx, stop := interruptibleContext(context.Background())
+	stop()
+
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
 // The only scenario we can't adequately test is when this is actually
 // a terminal
 func TestIsTerminal(t *testing.T) {
@@ -34,6 +59,38 @@ func TestIsTerminal(t *testing.T) {
 	assert.False(t, isTerminal(&buffer))
 }
 
+func TestChangedFlags(t *testing.T) {
+	rootCmd := newRootCommand()
+	err := rootCmd.ParseFlags([]string{
+		"--container-id", "conjur",
+		"-o", "html=report.html",
+		"-o", "junit=report.xml",
+	})
+	assert.NoError(t, err)
+
+	// Only the flags that were set are recorded, with each value of a
+	// repeated flag listed separately
+	assert.Equal(
+		t,
+		[]string{
+			"--container-id=conjur",
+			"--output=html=report.html",
+			"--output=junit=report.xml",
+		},
+		changedFlags(rootCmd),
+	)
+}
+
+func TestRootCommandMaxOutputSizeInvalid(t *testing.T) {
+	rootCmd := newRootCommand()
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--max-output-size", "lots"})
+
+	err := rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid value for '--max-output-size'")
+}
+
 func TestExecute(t *testing.T) {
 	// Redirect stdout to a buffer so we can capture the output
 	var stdout bytes.Buffer
@@ -78,7 +135,7 @@ func TestExecuteWithDebugOutput(t *testing.T) {
 	assert.NotEmpty(t, stdout.String())
 }
 
-func newTestReport(string, string) (report.Report, error) {
+func newTestReport(string, string, DefaultReportOptions) (report.Report, error) {
 	outputStore := test.NewOutputStore()
 	outputArchive := &test.OutputArchive{}
 	report := reports.NewStandardReport(
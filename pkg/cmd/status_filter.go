@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/spf13/cobra"
+)
+
+// filterableStatuses are the statuses that may be given to '--status'
+var filterableStatuses = []string{
+	check.StatusInfo,
+	check.StatusPass,
+	check.StatusWarn,
+	check.StatusFail,
+	check.StatusError,
+	check.StatusTimeout,
+}
+
+// problemStatuses are the statuses shown with '--only-problems'
+var problemStatuses = []string{
+	check.StatusWarn,
+	check.StatusFail,
+	check.StatusError,
+	check.StatusTimeout,
+}
+
+// statusFilter holds the flags that filter the results displayed in the text
+// report by status
+type statusFilter struct {
+	statuses     []string
+	onlyProblems bool
+}
+
+// addFlags adds the status filter flags to the command
+func (filter *statusFilter) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(
+		&filter.statuses,
+		"status",
+		"", // No shorthand
+		nil,
+		"Only display results with these statuses in the text report "+
+			"(e.g. 'warn,fail,error')",
+	)
+
+	cmd.Flags().BoolVarP(
+		&filter.onlyProblems,
+		"only-problems",
+		"", // No shorthand
+		false,
+		"Only display WARN, FAIL, ERROR and TIMEOUT results in the text report",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("status", "only-problems")
+}
+
+// displayStatuses returns the statuses to display, or nil to display every result.
+// The filter only applies to the text report, so it is an error to use it
+// with another output format.
+func (filter *statusFilter) displayStatuses(format string) ([]string, error) {
+	if len(filter.statuses) == 0 && !filter.onlyProblems {
+		return nil, nil
+	}
+
+	if format != formatText {
+		return nil, errors.New(
+			"'--status' and '--only-problems' only apply to the text report",
+		)
+	}
+
+	if filter.onlyProblems {
+		return problemStatuses, nil
+	}
+
+	statuses := []string{}
+	for _, status := range filter.statuses {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if !slices.Contains(filterableStatuses, status) {
+			return nil, fmt.Errorf(
+				"invalid value for '--status': %s (must be info, pass, warn, fail, "+
+					"error or timeout)",
+				strings.ToLower(status),
+			)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
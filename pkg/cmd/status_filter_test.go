@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusFilterDisplayStatuses(t *testing.T) {
+	statuses, err := (&statusFilter{}).displayStatuses(formatText)
+	assert.NoError(t, err)
+	assert.Nil(t, statuses)
+
+	statuses, err = (&statusFilter{statuses: []string{"warn", " FAIL"}}).displayStatuses(formatText)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{check.StatusWarn, check.StatusFail}, statuses)
+
+	statuses, err = (&statusFilter{onlyProblems: true}).displayStatuses(formatText)
+	assert.NoError(t, err)
+	assert.Equal(t, problemStatuses, statuses)
+
+	_, err = (&statusFilter{statuses: []string{"bad"}}).displayStatuses(formatText)
+	assert.EqualError(
+		t,
+		err,
+		"invalid value for '--status': bad (must be info, pass, warn, fail, error or timeout)",
+	)
+
+	// The filter only applies to text
+	_, err = (&statusFilter{onlyProblems: true}).displayStatuses(formatJSON)
+	assert.EqualError(t, err, "'--status' and '--only-problems' only apply to the text report")
+}
+
+func TestStatusFilterFlagsExclusive(t *testing.T) {
+	_, err := executeCommand("analyze", "--status", "warn", "--only-problems", "archive.tar.gz")
+	assert.ErrorContains(t, err, "none of the others can be")
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/output"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/cyberark/conjur-inspect/pkg/signing"
+
+	"github.com/spf13/cobra"
+)
+
+func newVerifyCommand() *cobra.Command {
+	var publicKeyFile string
+	var signaturePath string
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify --pubkey <public-key-file> <archive>",
+		Short: "Verify the signature of a raw data archive written with '--sign-key'",
+		Long: "Verify that a raw data archive hasn't been altered since it was " +
+			"signed, using the public key matching the '--sign-key' it was " +
+			"signed with. The signature is read from <archive>.sig, unless " +
+			"'--signature' is given.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archivePath := args[0]
+
+			publicKey, err := signing.LoadPublicKey(publicKeyFile)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--pubkey': %w", err)
+			}
+
+			if signaturePath == "" {
+				signaturePath = archivePath + signing.Extension
+			}
+
+			signature, err := os.ReadFile(signaturePath)
+			if err != nil {
+				return fmt.Errorf("unable to read signature: %w", err)
+			}
+
+			statement, err := signing.Verify(signature, publicKey)
+			if err != nil {
+				return fmt.Errorf("unable to verify %s: %w", archivePath, err)
+			}
+
+			err = verifyArchive(archivePath, statement)
+			if err != nil {
+				return fmt.Errorf("unable to verify %s: %w", archivePath, err)
+			}
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"Verified %s: signed by %s at %s\n",
+				archivePath,
+				statement.KeyID,
+				statement.SignedAt.Format(time.RFC3339),
+			)
+			return nil
+		},
+	}
+
+	verifyCmd.Flags().StringVarP(
+		&publicKeyFile,
+		"pubkey",
+		"",
+		"",
+		"PEM encoded ed25519 public key matching the key the archive was signed with",
+	)
+	_ = verifyCmd.MarkFlagRequired("pubkey")
+
+	verifyCmd.Flags().StringVarP(
+		&signaturePath,
+		"signature",
+		"", // No shorthand
+		"", // Default is the archive path with a .sig extension
+		"Where to read the signature from",
+	)
+
+	return verifyCmd
+}
+
+// verifyArchive checks that the archive matches the signed statement. The
+// manifest is only checked in unencrypted archives, since it can't be read
+// from encrypted ones without the private key, but the archive digest covers
+// it either way.
+func verifyArchive(archivePath string, statement *signing.Statement) error {
+	archiveDigest, err := signing.FileDigest(archivePath)
+	if err != nil {
+		return err
+	}
+	if archiveDigest != statement.ArchiveSHA256 {
+		return fmt.Errorf(
+			"the archive has been modified since it was signed (%s was signed)",
+			statement.Archive,
+		)
+	}
+
+	encrypted, err := isEncryptedArchive(archivePath)
+	if err != nil || encrypted {
+		return err
+	}
+
+	manifestDigest := ""
+	err = output.WalkTarGzipArchive(
+		archivePath,
+		func(name string, reader io.Reader) error {
+			if name != report.ManifestFileName {
+				return nil
+			}
+
+			var err error
+			manifestDigest, err = signing.Digest(reader)
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if manifestDigest != statement.ManifestSHA256 {
+		return fmt.Errorf(
+			"the archive's %s doesn't match the signed manifest",
+			report.ManifestFileName,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/cyberark/conjur-inspect/pkg/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCommand(t *testing.T) {
+	publicKeyFile, privateKeyFile := writeTestKeys(t, "ed25519")
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{Version: "1.0.0"},
+		map[string]string{report.ManifestFileName: "{}"},
+	)
+	signTestArchive(t, archivePath, privateKeyFile, "{}")
+
+	stdout, err := executeCommand("verify", "--pubkey", publicKeyFile, archivePath)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Verified "+archivePath+": signed by SHA256:")
+
+	// The signature may be kept somewhere else
+	signaturePath := filepath.Join(t.TempDir(), "archive.sig")
+	require.NoError(t, os.Rename(archivePath+".sig", signaturePath))
+
+	_, err = executeCommand(
+		"verify",
+		"--pubkey", publicKeyFile,
+		"--signature", signaturePath,
+		archivePath,
+	)
+	require.NoError(t, err)
+}
+
+func TestVerifyCommandModified(t *testing.T) {
+	publicKeyFile, privateKeyFile := writeTestKeys(t, "ed25519")
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{Version: "1.0.0"},
+		map[string]string{report.ManifestFileName: "{}"},
+	)
+	signTestArchive(t, archivePath, privateKeyFile, "{}")
+
+	archive, err := os.OpenFile(archivePath, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = archive.Write([]byte{0})
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+
+	_, err = executeCommand("verify", "--pubkey", publicKeyFile, archivePath)
+	assert.ErrorContains(t, err, "has been modified since it was signed")
+}
+
+func TestVerifyCommandManifestMismatch(t *testing.T) {
+	publicKeyFile, privateKeyFile := writeTestKeys(t, "ed25519")
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{Version: "1.0.0"},
+		map[string]string{report.ManifestFileName: "{}"},
+	)
+	signTestArchive(t, archivePath, privateKeyFile, `{"files":[]}`)
+
+	_, err := executeCommand("verify", "--pubkey", publicKeyFile, archivePath)
+	assert.ErrorContains(t, err, "doesn't match the signed manifest")
+}
+
+func TestVerifyCommandWrongKey(t *testing.T) {
+	_, privateKeyFile := writeTestKeys(t, "ed25519")
+	otherPublicKeyFile, _ := writeTestKeys(t, "ed25519")
+	archivePath := writeTestArchive(
+		t,
+		&report.Result{Version: "1.0.0"},
+		map[string]string{report.ManifestFileName: "{}"},
+	)
+	signTestArchive(t, archivePath, privateKeyFile, "{}")
+
+	_, err := executeCommand("verify", "--pubkey", otherPublicKeyFile, archivePath)
+	assert.ErrorIs(t, err, signing.ErrInvalidSignature)
+}
+
+func TestVerifyCommandMissingSignature(t *testing.T) {
+	publicKeyFile, _ := writeTestKeys(t, "ed25519")
+	archivePath := writeTestArchive(t, &report.Result{Version: "1.0.0"}, nil)
+
+	_, err := executeCommand("verify", "--pubkey", publicKeyFile, archivePath)
+	assert.ErrorContains(t, err, "unable to read signature")
+}
+
+func TestRootCommandSignKeyInvalid(t *testing.T) {
+	rootCmd := newRootCommand()
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{
+		"--sign-key", filepath.Join(t.TempDir(), "missing.pem"),
+	})
+
+	err := rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid value for '--sign-key'")
+}
+
+// signTestArchive writes a signature for the archive, vouching for the given
+// manifest contents
+func signTestArchive(
+	t *testing.T,
+	archivePath string,
+	privateKeyFile string,
+	manifest string,
+) {
+	privateKey, err := signing.LoadPrivateKey(privateKeyFile)
+	require.NoError(t, err)
+
+	archiveDigest, err := signing.FileDigest(archivePath)
+	require.NoError(t, err)
+	manifestDigest, err := signing.Digest(strings.NewReader(manifest))
+	require.NoError(t, err)
+
+	signature, err := signing.Sign(
+		signing.Statement{
+			Archive:        filepath.Base(archivePath),
+			ArchiveSHA256:  archiveDigest,
+			ManifestSHA256: manifestDigest,
+		},
+		privateKey,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(archivePath+".sig", signature, 0644))
+}
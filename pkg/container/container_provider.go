@@ -3,6 +3,7 @@
 package container
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -13,18 +14,26 @@ import (
 // engine (e.g. Docker, Podman)
 type ContainerProvider interface {
 	Name() string
-	Info() (ContainerProviderInfo, error)
+	Info(ctx context.Context) (ContainerProviderInfo, error)
 	Container(containerID string) Container
-	NetworkInspect() (io.Reader, error)
+	NetworkInspect(ctx context.Context) (io.Reader, error)
 }
 
 // Container is an interface for a container instance
 type Container interface {
 	ID() string
-	Inspect() (io.Reader, error)
-	Exec(command ...string) (stdout, stderr io.Reader, err error)
-	ExecAsUser(user string, command ...string) (stdout, stderr io.Reader, err error)
-	Logs(since time.Duration) (io.Reader, error)
+	Inspect(ctx context.Context) (io.Reader, error)
+	Exec(ctx context.Context, command ...string) (stdout, stderr io.Reader, err error)
+	ExecAsUser(ctx context.Context, user string, command ...string) (stdout, stderr io.Reader, err error)
+	Logs(ctx context.Context, since time.Duration) (io.Reader, error)
+
+	// ExecTo runs a command like Exec, writing its standard output to the
+	// given writer as it is produced rather than collecting it in memory
+	ExecTo(ctx context.Context, stdout io.Writer, command ...string) (stderr io.Reader, err error)
+
+	// LogsTo writes the container logs to the given writer as they are read,
+	// rather than collecting them in memory
+	LogsTo(ctx context.Context, writer io.Writer, since time.Duration) error
 }
 
 // ContainerProviderInfo is an interface for the results of
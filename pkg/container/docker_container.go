@@ -3,6 +3,7 @@
 package container
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -14,6 +15,8 @@ import (
 // Function variable for dependency injection
 var dockerFunc = docker
 var dockerCombinedOutputFunc = dockerCombinedOutput
+var dockerToFunc = dockerTo
+var dockerCombinedOutputToFunc = dockerCombinedOutputTo
 
 // DockerContainer is a concrete implementation of the Container interface
 type DockerContainer struct {
@@ -26,8 +29,9 @@ func (dc *DockerContainer) ID() string {
 }
 
 // Inspect returns the JSON output of the `docker inspect` command
-func (dc *DockerContainer) Inspect() (io.Reader, error) {
+func (dc *DockerContainer) Inspect(ctx context.Context) (io.Reader, error) {
 	stdout, stderr, err := dockerFunc(
+		ctx,
 		"inspect",
 		"--format",
 		"json",
@@ -49,35 +53,79 @@ func (dc *DockerContainer) Inspect() (io.Reader, error) {
 
 // Exec runs a command inside the container
 func (dc *DockerContainer) Exec(
+	ctx context.Context,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
 	args := append([]string{"exec", dc.ContainerID}, command...)
-	return dockerFunc(args...)
+	return dockerFunc(ctx, args...)
 }
 
 // ExecAsUser runs a command inside the container as a specific user
 func (dc *DockerContainer) ExecAsUser(
+	ctx context.Context,
 	user string,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
 	args := append([]string{"exec", "--user", user, dc.ContainerID}, command...)
-	return dockerFunc(args...)
+	return dockerFunc(ctx, args...)
 }
 
 // Logs returns the logs of the container
-func (dc *DockerContainer) Logs(since time.Duration) (io.Reader, error) {
+func (dc *DockerContainer) Logs(
+	ctx context.Context,
+	since time.Duration,
+) (io.Reader, error) {
+	args := []string{"logs", fmt.Sprintf("--since=%s", since), dc.ContainerID}
+	return dockerCombinedOutputFunc(ctx, args...)
+}
+
+// ExecTo runs a command inside the container, writing its standard output to
+// the given writer
+func (dc *DockerContainer) ExecTo(
+	ctx context.Context,
+	stdout io.Writer,
+	command ...string,
+) (stderr io.Reader, err error) {
+	args := append([]string{"exec", dc.ContainerID}, command...)
+	return dockerToFunc(ctx, stdout, args...)
+}
+
+// LogsTo writes the logs of the container to the given writer
+func (dc *DockerContainer) LogsTo(
+	ctx context.Context,
+	writer io.Writer,
+	since time.Duration,
+) error {
 	args := []string{"logs", fmt.Sprintf("--since=%s", since), dc.ContainerID}
-	return dockerCombinedOutputFunc(args...)
+	return dockerCombinedOutputToFunc(ctx, writer, args...)
 }
 
 func docker(
+	ctx context.Context,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
-	return shell.NewCommandWrapper("docker", command...).Run()
+	return shell.NewCommandWrapper("docker", command...).Run(ctx)
 }
 
 func dockerCombinedOutput(
+	ctx context.Context,
 	command ...string,
 ) (io.Reader, error) {
-	return shell.NewCommandWrapper("docker", command...).RunCombinedOutput()
+	return shell.NewCommandWrapper("docker", command...).RunCombinedOutput(ctx)
+}
+
+func dockerTo(
+	ctx context.Context,
+	stdout io.Writer,
+	command ...string,
+) (stderr io.Reader, err error) {
+	return shell.NewCommandWrapper("docker", command...).RunTo(ctx, stdout)
+}
+
+func dockerCombinedOutputTo(
+	ctx context.Context,
+	writer io.Writer,
+	command ...string,
+) error {
+	return shell.NewCommandWrapper("docker", command...).RunCombinedOutputTo(ctx, writer)
 }
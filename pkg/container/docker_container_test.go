@@ -3,6 +3,7 @@
 package container
 
 import (
+	"context"
 	"errors"
 	"io"
 	"strings"
@@ -17,7 +18,7 @@ func TestDockerContainerInspect(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := dockerFunc
-	dockerFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	dockerFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		stdout = strings.NewReader(rawOutput)
 		return stdout, stderr, err
 	}
@@ -29,7 +30,7 @@ func TestDockerContainerInspect(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	inspectResult, err := dockerContainer.Inspect()
+	inspectResult, err := dockerContainer.Inspect(context.Background())
 	assert.NoError(t, err)
 
 	inspectBytes, err := io.ReadAll(inspectResult)
@@ -42,7 +43,7 @@ func TestDockerContainerInspectError(t *testing.T) {
 	testError := errors.New("fake error")
 	// Mock dependencies
 	oldFunc := dockerFunc
-	dockerFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	dockerFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		err = testError
 		return stdout, stderr, err
 	}
@@ -54,7 +55,7 @@ func TestDockerContainerInspectError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	inspectResult, err := dockerContainer.Inspect()
+	inspectResult, err := dockerContainer.Inspect(context.Background())
 	assert.Error(t, testError, err)
 	assert.Nil(t, inspectResult)
 }
@@ -65,7 +66,7 @@ func TestDockerContainerExec(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := dockerFunc
-	dockerFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	dockerFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		stdout = strings.NewReader(standardOut)
 		stderr = strings.NewReader(standardErr)
 		return stdout, stderr, err
@@ -78,7 +79,7 @@ func TestDockerContainerExec(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	execStdout, execStderr, err := dockerContainer.Exec("test")
+	execStdout, execStderr, err := dockerContainer.Exec(context.Background(), "test")
 	assert.NoError(t, err)
 
 	stdoutBytes, err := io.ReadAll(execStdout)
@@ -95,7 +96,7 @@ func TestDockerContainerExecError(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := dockerFunc
-	dockerFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	dockerFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		err = testError
 		return stdout, stderr, err
 	}
@@ -107,7 +108,7 @@ func TestDockerContainerExecError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	stdout, stderr, err := dockerContainer.Exec("test")
+	stdout, stderr, err := dockerContainer.Exec(context.Background(), "test")
 	assert.Error(t, testError, err)
 	assert.Nil(t, stdout)
 	assert.Nil(t, stderr)
@@ -118,7 +119,7 @@ func TestDockerContainerLogs(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := dockerCombinedOutputFunc
-	dockerCombinedOutputFunc = func(...string) (output io.Reader, err error) {
+	dockerCombinedOutputFunc = func(context.Context, ...string) (output io.Reader, err error) {
 		output = strings.NewReader(logOut)
 		return output, err
 	}
@@ -130,7 +131,7 @@ func TestDockerContainerLogs(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	output, err := dockerContainer.Logs(time.Duration(0))
+	output, err := dockerContainer.Logs(context.Background(), time.Duration(0))
 	assert.NoError(t, err)
 
 	outputBytes, err := io.ReadAll(output)
@@ -143,7 +144,7 @@ func TestDockerContainerLogsError(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := dockerCombinedOutputFunc
-	dockerCombinedOutputFunc = func(...string) (output io.Reader, err error) {
+	dockerCombinedOutputFunc = func(context.Context, ...string) (output io.Reader, err error) {
 		err = testError
 		return output, err
 	}
@@ -155,7 +156,7 @@ func TestDockerContainerLogsError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	output, err := dockerContainer.Logs(time.Duration(0))
+	output, err := dockerContainer.Logs(context.Background(), time.Duration(0))
 	assert.Error(t, testError, err)
 	assert.Nil(t, output)
 }
@@ -166,7 +167,7 @@ func TestDockerContainerExecAsUser(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := dockerFunc
-	dockerFunc = func(args ...string) (stdout, stderr io.Reader, err error) {
+	dockerFunc = func(_ context.Context, args ...string) (stdout, stderr io.Reader, err error) {
 		capturedArgs = args
 		stdout = strings.NewReader(standardOut)
 		stderr = strings.NewReader(standardErr)
@@ -180,7 +181,7 @@ func TestDockerContainerExecAsUser(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	execStdout, execStderr, err := dockerContainer.ExecAsUser("conjur", "psql", "-c", "SELECT 1")
+	execStdout, execStderr, err := dockerContainer.ExecAsUser(context.Background(), "conjur", "psql", "-c", "SELECT 1")
 	assert.NoError(t, err)
 
 	stdoutBytes, err := io.ReadAll(execStdout)
@@ -200,7 +201,7 @@ func TestDockerContainerExecAsUserError(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := dockerFunc
-	dockerFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	dockerFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		err = testError
 		return stdout, stderr, err
 	}
@@ -212,8 +213,63 @@ func TestDockerContainerExecAsUserError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	stdout, stderr, err := dockerContainer.ExecAsUser("conjur", "psql", "-c", "SELECT 1")
+	stdout, stderr, err := dockerContainer.ExecAsUser(context.Background(), "conjur", "psql", "-c", "SELECT 1")
 	assert.Error(t, testError, err)
 	assert.Nil(t, stdout)
 	assert.Nil(t, stderr)
 }
+
+func TestDockerContainerExecTo(t *testing.T) {
+	capturedArgs := []string{}
+
+	// Mock dependencies
+	oldFunc := dockerToFunc
+	dockerToFunc = func(_ context.Context, stdout io.Writer, args ...string) (io.Reader, error) {
+		capturedArgs = args
+		_, err := io.WriteString(stdout, "test standard output")
+		return strings.NewReader("test standard error"), err
+	}
+	defer func() {
+		dockerToFunc = oldFunc
+	}()
+
+	dockerContainer := &DockerContainer{
+		ContainerID: "test-container",
+	}
+
+	var stdout strings.Builder
+	execStderr, err := dockerContainer.ExecTo(context.Background(), &stdout, "cat", "/etc/hosts")
+	assert.NoError(t, err)
+	assert.Equal(t, "test standard output", stdout.String())
+
+	stderrBytes, err := io.ReadAll(execStderr)
+	assert.NoError(t, err)
+	assert.Equal(t, "test standard error", string(stderrBytes))
+
+	assert.Equal(t, []string{"exec", "test-container", "cat", "/etc/hosts"}, capturedArgs)
+}
+
+func TestDockerContainerLogsTo(t *testing.T) {
+	capturedArgs := []string{}
+
+	// Mock dependencies
+	oldFunc := dockerCombinedOutputToFunc
+	dockerCombinedOutputToFunc = func(_ context.Context, writer io.Writer, args ...string) error {
+		capturedArgs = args
+		_, err := io.WriteString(writer, "test logs")
+		return err
+	}
+	defer func() {
+		dockerCombinedOutputToFunc = oldFunc
+	}()
+
+	dockerContainer := &DockerContainer{
+		ContainerID: "test-container",
+	}
+
+	var logs strings.Builder
+	err := dockerContainer.LogsTo(context.Background(), &logs, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "test logs", logs.String())
+	assert.Equal(t, []string{"logs", "--since=1h0m0s", "test-container"}, capturedArgs)
+}
@@ -3,6 +3,7 @@
 package container
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -26,8 +27,10 @@ func (*DockerProvider) Name() string {
 }
 
 // Info returns the Docker runtime info
-func (*DockerProvider) Info() (ContainerProviderInfo, error) {
-	stdout, stderr, err := executeDockerInfoFunc()
+func (*DockerProvider) Info(
+	ctx context.Context,
+) (ContainerProviderInfo, error) {
+	stdout, stderr, err := executeDockerInfoFunc(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to inspect Docker runtime: %w (%s)",
@@ -71,28 +74,28 @@ func (*DockerProvider) Container(containerID string) Container {
 }
 
 // NetworkInspect returns the JSON output of all Docker networks
-func (*DockerProvider) NetworkInspect() (io.Reader, error) {
-	return executeDockerNetworkInspectFunc()
+func (*DockerProvider) NetworkInspect(ctx context.Context) (io.Reader, error) {
+	return executeDockerNetworkInspectFunc(ctx)
 }
 
-func executeDockerInfo() (stdout, stderr io.Reader, err error) {
+func executeDockerInfo(ctx context.Context) (stdout, stderr io.Reader, err error) {
 	return shell.NewCommandWrapper(
 		"docker",
 		"--debug",
 		"info",
 		"--format",
 		"{{json .}}",
-	).Run()
+	).Run(ctx)
 }
 
-func executeDockerNetworkInspect() (io.Reader, error) {
+func executeDockerNetworkInspect(ctx context.Context) (io.Reader, error) {
 	// First, get the list of network IDs
 	stdout, stderr, err := shell.NewCommandWrapper(
 		"docker",
 		"network",
 		"ls",
 		"-q",
-	).Run()
+	).Run(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -119,7 +122,7 @@ func executeDockerNetworkInspect() (io.Reader, error) {
 	ids := strings.Fields(networkIDs)
 	args := append([]string{"network", "inspect"}, ids...)
 
-	stdout, stderr, err = shell.NewCommandWrapper("docker", args...).Run()
+	stdout, stderr, err = shell.NewCommandWrapper("docker", args...).Run(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to inspect Docker networks: %w (%s)",
@@ -4,6 +4,7 @@ package container
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"strings"
@@ -20,7 +21,7 @@ func TestDockerProviderInfo(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := executeDockerInfoFunc
-	executeDockerInfoFunc = func() (stdout, stderr io.Reader, err error) {
+	executeDockerInfoFunc = func(context.Context) (stdout, stderr io.Reader, err error) {
 		stdout = bytes.NewReader(rawOutput)
 		return stdout, stderr, err
 	}
@@ -30,7 +31,7 @@ func TestDockerProviderInfo(t *testing.T) {
 
 	// Get the info
 	docker := &DockerProvider{}
-	dockerInfo, err := docker.Info()
+	dockerInfo, err := docker.Info(context.Background())
 
 	assert.NoError(t, err)
 
@@ -62,7 +63,7 @@ func TestDockerProviderInfo(t *testing.T) {
 func TestDockerProviderInfoParseError(t *testing.T) {
 	// Mock dependencies
 	oldFunc := executeDockerInfoFunc
-	executeDockerInfoFunc = func() (stdout, stderr io.Reader, err error) {
+	executeDockerInfoFunc = func(context.Context) (stdout, stderr io.Reader, err error) {
 		stdout = strings.NewReader("invalid json")
 		return stdout, stderr, err
 	}
@@ -72,7 +73,7 @@ func TestDockerProviderInfoParseError(t *testing.T) {
 
 	// Get the info
 	docker := &DockerProvider{}
-	dockerInfo, err := docker.Info()
+	dockerInfo, err := docker.Info(context.Background())
 
 	assert.Nil(t, dockerInfo)
 	assert.ErrorContains(t, err, "failed to parse Docker info output: ")
@@ -81,7 +82,7 @@ func TestDockerProviderInfoParseError(t *testing.T) {
 func TestDockerProviderInfoFailure(t *testing.T) {
 	// Mock dependencies
 	oldFunc := executeDockerInfoFunc
-	executeDockerInfoFunc = func() (stdout, stderr io.Reader, err error) {
+	executeDockerInfoFunc = func(context.Context) (stdout, stderr io.Reader, err error) {
 		err = errors.New("fake error")
 		return stdout, stderr, err
 	}
@@ -91,7 +92,7 @@ func TestDockerProviderInfoFailure(t *testing.T) {
 
 	// Get the info
 	docker := &DockerProvider{}
-	dockerInfo, err := docker.Info()
+	dockerInfo, err := docker.Info(context.Background())
 
 	assert.Nil(t, dockerInfo)
 
@@ -101,7 +102,7 @@ func TestDockerProviderInfoFailure(t *testing.T) {
 func TestDockerProviderInfoServerError(t *testing.T) {
 	// Mock dependencies
 	oldFunc := executeDockerInfoFunc
-	executeDockerInfoFunc = func() (stdout, stderr io.Reader, err error) {
+	executeDockerInfoFunc = func(context.Context) (stdout, stderr io.Reader, err error) {
 		stdout = strings.NewReader(`{"ServerErrors": ["Test error"]}`)
 		return stdout, stderr, err
 	}
@@ -111,7 +112,7 @@ func TestDockerProviderInfoServerError(t *testing.T) {
 
 	// Get the info
 	docker := &DockerProvider{}
-	dockerInfo, err := docker.Info()
+	dockerInfo, err := docker.Info(context.Background())
 
 	assert.Nil(t, dockerInfo)
 
@@ -134,7 +135,7 @@ func TestDockerProviderNetworkInspect(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := executeDockerNetworkInspectFunc
-	executeDockerNetworkInspectFunc = func() (io.Reader, error) {
+	executeDockerNetworkInspectFunc = func(context.Context) (io.Reader, error) {
 		return strings.NewReader(rawOutput), nil
 	}
 	defer func() {
@@ -142,7 +143,7 @@ func TestDockerProviderNetworkInspect(t *testing.T) {
 	}()
 
 	docker := &DockerProvider{}
-	result, err := docker.NetworkInspect()
+	result, err := docker.NetworkInspect(context.Background())
 
 	assert.NoError(t, err)
 
@@ -156,7 +157,7 @@ func TestDockerProviderNetworkInspectEmpty(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := executeDockerNetworkInspectFunc
-	executeDockerNetworkInspectFunc = func() (io.Reader, error) {
+	executeDockerNetworkInspectFunc = func(context.Context) (io.Reader, error) {
 		return strings.NewReader(rawOutput), nil
 	}
 	defer func() {
@@ -164,7 +165,7 @@ func TestDockerProviderNetworkInspectEmpty(t *testing.T) {
 	}()
 
 	docker := &DockerProvider{}
-	result, err := docker.NetworkInspect()
+	result, err := docker.NetworkInspect(context.Background())
 
 	assert.NoError(t, err)
 
@@ -176,7 +177,7 @@ func TestDockerProviderNetworkInspectEmpty(t *testing.T) {
 func TestDockerProviderNetworkInspectError(t *testing.T) {
 	// Mock dependencies
 	oldFunc := executeDockerNetworkInspectFunc
-	executeDockerNetworkInspectFunc = func() (io.Reader, error) {
+	executeDockerNetworkInspectFunc = func(context.Context) (io.Reader, error) {
 		return nil, errors.New("network inspect failed")
 	}
 	defer func() {
@@ -184,7 +185,7 @@ func TestDockerProviderNetworkInspectError(t *testing.T) {
 	}()
 
 	docker := &DockerProvider{}
-	result, err := docker.NetworkInspect()
+	result, err := docker.NetworkInspect(context.Background())
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
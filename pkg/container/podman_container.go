@@ -3,6 +3,7 @@
 package container
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -14,6 +15,8 @@ import (
 // Function variable for dependency injection
 var podmanFunc = podman
 var podmanCombinedOutputFunc = podmanCombinedOutput
+var podmanToFunc = podmanTo
+var podmanCombinedOutputToFunc = podmanCombinedOutputTo
 
 // PodmanContainer is a concrete implementation of the Container interface
 // for Podman
@@ -27,8 +30,9 @@ func (pc *PodmanContainer) ID() string {
 }
 
 // Inspect returns the JSON output of the `podman inspect` command
-func (pc *PodmanContainer) Inspect() (io.Reader, error) {
+func (pc *PodmanContainer) Inspect(ctx context.Context) (io.Reader, error) {
 	stdout, stderr, err := podmanFunc(
+		ctx,
 		"container",
 		"inspect",
 		"--format",
@@ -51,35 +55,79 @@ func (pc *PodmanContainer) Inspect() (io.Reader, error) {
 
 // Exec runs a command inside the container
 func (pc *PodmanContainer) Exec(
+	ctx context.Context,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
 	args := append([]string{"exec", pc.ContainerID}, command...)
-	return podmanFunc(args...)
+	return podmanFunc(ctx, args...)
 }
 
 // ExecAsUser runs a command inside the container as a specific user
 func (pc *PodmanContainer) ExecAsUser(
+	ctx context.Context,
 	user string,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
 	args := append([]string{"exec", "--user", user, pc.ContainerID}, command...)
-	return podmanFunc(args...)
+	return podmanFunc(ctx, args...)
 }
 
 // Logs returns the logs of the container
-func (pc *PodmanContainer) Logs(since time.Duration) (io.Reader, error) {
+func (pc *PodmanContainer) Logs(
+	ctx context.Context,
+	since time.Duration,
+) (io.Reader, error) {
+	args := []string{"logs", fmt.Sprintf("--since=%s", since), pc.ContainerID}
+	return podmanCombinedOutputFunc(ctx, args...)
+}
+
+// ExecTo runs a command inside the container, writing its standard output to
+// the given writer
+func (pc *PodmanContainer) ExecTo(
+	ctx context.Context,
+	stdout io.Writer,
+	command ...string,
+) (stderr io.Reader, err error) {
+	args := append([]string{"exec", pc.ContainerID}, command...)
+	return podmanToFunc(ctx, stdout, args...)
+}
+
+// LogsTo writes the logs of the container to the given writer
+func (pc *PodmanContainer) LogsTo(
+	ctx context.Context,
+	writer io.Writer,
+	since time.Duration,
+) error {
 	args := []string{"logs", fmt.Sprintf("--since=%s", since), pc.ContainerID}
-	return podmanCombinedOutputFunc(args...)
+	return podmanCombinedOutputToFunc(ctx, writer, args...)
 }
 
 func podman(
+	ctx context.Context,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
-	return shell.NewCommandWrapper("podman", command...).Run()
+	return shell.NewCommandWrapper("podman", command...).Run(ctx)
 }
 
 func podmanCombinedOutput(
+	ctx context.Context,
 	command ...string,
 ) (io.Reader, error) {
-	return shell.NewCommandWrapper("podman", command...).RunCombinedOutput()
+	return shell.NewCommandWrapper("podman", command...).RunCombinedOutput(ctx)
+}
+
+func podmanTo(
+	ctx context.Context,
+	stdout io.Writer,
+	command ...string,
+) (stderr io.Reader, err error) {
+	return shell.NewCommandWrapper("podman", command...).RunTo(ctx, stdout)
+}
+
+func podmanCombinedOutputTo(
+	ctx context.Context,
+	writer io.Writer,
+	command ...string,
+) error {
+	return shell.NewCommandWrapper("podman", command...).RunCombinedOutputTo(ctx, writer)
 }
@@ -3,6 +3,7 @@
 package container
 
 import (
+	"context"
 	"errors"
 	"io"
 	"strings"
@@ -17,7 +18,7 @@ func TestPodmanContainerInspect(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := podmanFunc
-	podmanFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	podmanFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		stdout = rawOutput
 		return stdout, stderr, err
 	}
@@ -29,7 +30,7 @@ func TestPodmanContainerInspect(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	inspectResult, err := podmanContainer.Inspect()
+	inspectResult, err := podmanContainer.Inspect(context.Background())
 	assert.NoError(t, err)
 
 	assert.Equal(t, rawOutput, inspectResult)
@@ -39,7 +40,7 @@ func TestPodmanContainerInspectError(t *testing.T) {
 	testError := errors.New("fake error")
 	// Mock dependencies
 	oldFunc := podmanFunc
-	podmanFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	podmanFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		err = testError
 		return stdout, stderr, err
 	}
@@ -51,7 +52,7 @@ func TestPodmanContainerInspectError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	inspectResult, err := podmanContainer.Inspect()
+	inspectResult, err := podmanContainer.Inspect(context.Background())
 	assert.Error(t, testError, err)
 	assert.Nil(t, inspectResult)
 }
@@ -62,7 +63,7 @@ func TestPodmanContainerExec(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := podmanFunc
-	podmanFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	podmanFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		stdout = standardOut
 		stderr = standardErr
 		return stdout, stderr, err
@@ -75,7 +76,7 @@ func TestPodmanContainerExec(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	execStdout, execStderr, err := podmanContainer.Exec("test")
+	execStdout, execStderr, err := podmanContainer.Exec(context.Background(), "test")
 	assert.NoError(t, err)
 	assert.Equal(t, standardOut, execStdout)
 	assert.Equal(t, standardErr, execStderr)
@@ -86,7 +87,7 @@ func TestPodmanContainerExecError(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := podmanFunc
-	podmanFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	podmanFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		err = testError
 		return stdout, stderr, err
 	}
@@ -98,7 +99,7 @@ func TestPodmanContainerExecError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	stdout, stderr, err := podmanContainer.Exec("test")
+	stdout, stderr, err := podmanContainer.Exec(context.Background(), "test")
 	assert.Error(t, testError, err)
 	assert.Nil(t, stdout)
 	assert.Nil(t, stderr)
@@ -109,7 +110,7 @@ func TestPodmanContainerLogs(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := podmanCombinedOutputFunc
-	podmanCombinedOutputFunc = func(...string) (output io.Reader, err error) {
+	podmanCombinedOutputFunc = func(context.Context, ...string) (output io.Reader, err error) {
 		output = strings.NewReader(logOut)
 		return output, err
 	}
@@ -121,7 +122,7 @@ func TestPodmanContainerLogs(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	output, err := podmanContainer.Logs(time.Duration(0))
+	output, err := podmanContainer.Logs(context.Background(), time.Duration(0))
 	assert.NoError(t, err)
 
 	outputBytes, err := io.ReadAll(output)
@@ -134,7 +135,7 @@ func TestPodmanContainerLogsError(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := podmanCombinedOutputFunc
-	podmanCombinedOutputFunc = func(...string) (output io.Reader, err error) {
+	podmanCombinedOutputFunc = func(context.Context, ...string) (output io.Reader, err error) {
 		err = testError
 		return output, err
 	}
@@ -146,7 +147,7 @@ func TestPodmanContainerLogsError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	output, err := podmanContainer.Logs(time.Duration(0))
+	output, err := podmanContainer.Logs(context.Background(), time.Duration(0))
 	assert.Error(t, testError, err)
 	assert.Nil(t, output)
 }
@@ -157,7 +158,7 @@ func TestPodmanContainerExecAsUser(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := podmanFunc
-	podmanFunc = func(args ...string) (stdout, stderr io.Reader, err error) {
+	podmanFunc = func(_ context.Context, args ...string) (stdout, stderr io.Reader, err error) {
 		capturedArgs = args
 		stdout = standardOut
 		stderr = standardErr
@@ -171,7 +172,7 @@ func TestPodmanContainerExecAsUser(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	execStdout, execStderr, err := podmanContainer.ExecAsUser("conjur", "psql", "-c", "SELECT 1")
+	execStdout, execStderr, err := podmanContainer.ExecAsUser(context.Background(), "conjur", "psql", "-c", "SELECT 1")
 	assert.NoError(t, err)
 	assert.Equal(t, standardOut, execStdout)
 	assert.Equal(t, standardErr, execStderr)
@@ -185,7 +186,7 @@ func TestPodmanContainerExecAsUserError(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := podmanFunc
-	podmanFunc = func(...string) (stdout, stderr io.Reader, err error) {
+	podmanFunc = func(context.Context, ...string) (stdout, stderr io.Reader, err error) {
 		err = testError
 		return stdout, stderr, err
 	}
@@ -197,8 +198,63 @@ func TestPodmanContainerExecAsUserError(t *testing.T) {
 		ContainerID: "test-container",
 	}
 
-	stdout, stderr, err := podmanContainer.ExecAsUser("conjur", "psql", "-c", "SELECT 1")
+	stdout, stderr, err := podmanContainer.ExecAsUser(context.Background(), "conjur", "psql", "-c", "SELECT 1")
 	assert.Error(t, testError, err)
 	assert.Nil(t, stdout)
 	assert.Nil(t, stderr)
 }
+
+func TestPodmanContainerExecTo(t *testing.T) {
+	capturedArgs := []string{}
+
+	// Mock dependencies
+	oldFunc := podmanToFunc
+	podmanToFunc = func(_ context.Context, stdout io.Writer, args ...string) (io.Reader, error) {
+		capturedArgs = args
+		_, err := io.WriteString(stdout, "test standard output")
+		return strings.NewReader("test standard error"), err
+	}
+	defer func() {
+		podmanToFunc = oldFunc
+	}()
+
+	podmanContainer := &PodmanContainer{
+		ContainerID: "test-container",
+	}
+
+	var stdout strings.Builder
+	execStderr, err := podmanContainer.ExecTo(context.Background(), &stdout, "cat", "/etc/hosts")
+	assert.NoError(t, err)
+	assert.Equal(t, "test standard output", stdout.String())
+
+	stderrBytes, err := io.ReadAll(execStderr)
+	assert.NoError(t, err)
+	assert.Equal(t, "test standard error", string(stderrBytes))
+
+	assert.Equal(t, []string{"exec", "test-container", "cat", "/etc/hosts"}, capturedArgs)
+}
+
+func TestPodmanContainerLogsTo(t *testing.T) {
+	capturedArgs := []string{}
+
+	// Mock dependencies
+	oldFunc := podmanCombinedOutputToFunc
+	podmanCombinedOutputToFunc = func(_ context.Context, writer io.Writer, args ...string) error {
+		capturedArgs = args
+		_, err := io.WriteString(writer, "test logs")
+		return err
+	}
+	defer func() {
+		podmanCombinedOutputToFunc = oldFunc
+	}()
+
+	podmanContainer := &PodmanContainer{
+		ContainerID: "test-container",
+	}
+
+	var logs strings.Builder
+	err := podmanContainer.LogsTo(context.Background(), &logs, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "test logs", logs.String())
+	assert.Equal(t, []string{"logs", "--since=1h0m0s", "test-container"}, capturedArgs)
+}
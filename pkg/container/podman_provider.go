@@ -3,6 +3,7 @@
 package container
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,8 +26,10 @@ func (*PodmanProvider) Name() string {
 }
 
 // Info returns the Podman runtime info
-func (*PodmanProvider) Info() (ContainerProviderInfo, error) {
-	stdout, stderr, err := executePodmanInfoFunc()
+func (*PodmanProvider) Info(
+	ctx context.Context,
+) (ContainerProviderInfo, error) {
+	stdout, stderr, err := executePodmanInfoFunc(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to inspect Podman runtime: %w (%s)",
@@ -62,28 +65,28 @@ func (*PodmanProvider) Container(containerID string) Container {
 }
 
 // NetworkInspect returns the JSON output of all Podman networks
-func (*PodmanProvider) NetworkInspect() (io.Reader, error) {
-	return executePodmanNetworkInspectFunc()
+func (*PodmanProvider) NetworkInspect(ctx context.Context) (io.Reader, error) {
+	return executePodmanNetworkInspectFunc(ctx)
 }
 
-func executePodmanInfo() (stdout, stderr io.Reader, err error) {
+func executePodmanInfo(ctx context.Context) (stdout, stderr io.Reader, err error) {
 	return shell.NewCommandWrapper(
 		"podman",
 		"info",
 		"--debug",
 		"--format",
 		"{{json .}}",
-	).Run()
+	).Run(ctx)
 }
 
-func executePodmanNetworkInspect() (io.Reader, error) {
+func executePodmanNetworkInspect(ctx context.Context) (io.Reader, error) {
 	// First, get the list of network IDs
 	stdout, stderr, err := shell.NewCommandWrapper(
 		"podman",
 		"network",
 		"ls",
 		"-q",
-	).Run()
+	).Run(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -110,7 +113,7 @@ func executePodmanNetworkInspect() (io.Reader, error) {
 	ids := strings.Fields(networkIDs)
 	args := append([]string{"network", "inspect"}, ids...)
 
-	stdout, stderr, err = shell.NewCommandWrapper("podman", args...).Run()
+	stdout, stderr, err = shell.NewCommandWrapper("podman", args...).Run(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to inspect Podman networks: %w (%s)",
@@ -4,6 +4,7 @@ package container
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"strings"
@@ -20,7 +21,7 @@ func TestPodmanProviderInfo(t *testing.T) {
 
 	// Mock executePodmanInfoFunc to return expected output
 	originalFunc := executePodmanInfoFunc
-	executePodmanInfoFunc = func() (stdout, stderr io.Reader, err error) {
+	executePodmanInfoFunc = func(context.Context) (stdout, stderr io.Reader, err error) {
 		stdout = bytes.NewReader(rawOutput)
 		return stdout, stderr, err
 	}
@@ -30,7 +31,7 @@ func TestPodmanProviderInfo(t *testing.T) {
 
 	// Get the info
 	podman := &PodmanProvider{}
-	podmanInfo, err := podman.Info()
+	podmanInfo, err := podman.Info(context.Background())
 
 	assert.NoError(t, err)
 
@@ -73,7 +74,7 @@ func TestPodmanProviderInfo(t *testing.T) {
 func TestPodmanProviderInfoParseError(t *testing.T) {
 	// Mock dependencies
 	oldFunc := executePodmanInfoFunc
-	executePodmanInfoFunc = func() (stdout, stderr io.Reader, err error) {
+	executePodmanInfoFunc = func(context.Context) (stdout, stderr io.Reader, err error) {
 		stdout = strings.NewReader(`invalid json`)
 		return stdout, stderr, err
 	}
@@ -83,7 +84,7 @@ func TestPodmanProviderInfoParseError(t *testing.T) {
 
 	// Get the info
 	podman := &PodmanProvider{}
-	podmanInfo, err := podman.Info()
+	podmanInfo, err := podman.Info(context.Background())
 
 	assert.Nil(t, podmanInfo)
 	assert.ErrorContains(t, err, "failed to parse Podman info output: ")
@@ -92,7 +93,7 @@ func TestPodmanProviderInfoParseError(t *testing.T) {
 func TestPodmanProviderInfoError(t *testing.T) {
 	// Mock executePodmanInfoFunc to return an error
 	originalFunc := executePodmanInfoFunc
-	executePodmanInfoFunc = func() (stdout, stderr io.Reader, err error) {
+	executePodmanInfoFunc = func(context.Context) (stdout, stderr io.Reader, err error) {
 		return stdout, stderr, errors.New("fake error")
 	}
 	defer func() {
@@ -101,7 +102,7 @@ func TestPodmanProviderInfoError(t *testing.T) {
 
 	// Get the info
 	podman := &PodmanProvider{}
-	podmanInfo, err := podman.Info()
+	podmanInfo, err := podman.Info(context.Background())
 
 	assert.Error(t, err)
 	assert.Nil(t, podmanInfo)
@@ -123,7 +124,7 @@ func TestPodmanProviderNetworkInspect(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := executePodmanNetworkInspectFunc
-	executePodmanNetworkInspectFunc = func() (io.Reader, error) {
+	executePodmanNetworkInspectFunc = func(context.Context) (io.Reader, error) {
 		return strings.NewReader(rawOutput), nil
 	}
 	defer func() {
@@ -131,7 +132,7 @@ func TestPodmanProviderNetworkInspect(t *testing.T) {
 	}()
 
 	podman := &PodmanProvider{}
-	result, err := podman.NetworkInspect()
+	result, err := podman.NetworkInspect(context.Background())
 
 	assert.NoError(t, err)
 
@@ -145,7 +146,7 @@ func TestPodmanProviderNetworkInspectEmpty(t *testing.T) {
 
 	// Mock dependencies
 	oldFunc := executePodmanNetworkInspectFunc
-	executePodmanNetworkInspectFunc = func() (io.Reader, error) {
+	executePodmanNetworkInspectFunc = func(context.Context) (io.Reader, error) {
 		return strings.NewReader(rawOutput), nil
 	}
 	defer func() {
@@ -153,7 +154,7 @@ func TestPodmanProviderNetworkInspectEmpty(t *testing.T) {
 	}()
 
 	podman := &PodmanProvider{}
-	result, err := podman.NetworkInspect()
+	result, err := podman.NetworkInspect(context.Background())
 
 	assert.NoError(t, err)
 
@@ -165,7 +166,7 @@ func TestPodmanProviderNetworkInspectEmpty(t *testing.T) {
 func TestPodmanProviderNetworkInspectError(t *testing.T) {
 	// Mock dependencies
 	oldFunc := executePodmanNetworkInspectFunc
-	executePodmanNetworkInspectFunc = func() (io.Reader, error) {
+	executePodmanNetworkInspectFunc = func(context.Context) (io.Reader, error) {
 		return nil, errors.New("network inspect failed")
 	}
 	defer func() {
@@ -173,7 +174,7 @@ func TestPodmanProviderNetworkInspectError(t *testing.T) {
 	}()
 
 	podman := &PodmanProvider{}
-	result, err := podman.NetworkInspect()
+	result, err := podman.NetworkInspect(context.Background())
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -0,0 +1,387 @@
+// Package encryption encrypts raw data archives to a recipient's public key,
+// so that only the holder of the matching private key can open them.
+//
+// Each archive is encrypted with a random AES-256-GCM key. For RSA recipients
+// the key is encrypted with RSA-OAEP; for X25519 and NIST curve recipients it
+// is derived with ECDH from an ephemeral key, using HKDF-SHA256. The archive
+// is encrypted in chunks, so archives of any size can be encrypted and
+// decrypted as a stream. Each chunk is authenticated along with the header,
+// its position, and whether it is the last chunk, so chunks can't be modified,
+// reordered or removed without decryption failing.
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// magic identifies an encrypted archive and its format version
+const magic = "conjur-inspect encrypted archive v1\n"
+
+// chunkSize is the size of each encrypted chunk of the archive, before its
+// authentication tag is added
+const chunkSize = 64 * 1024
+
+// maxHeaderSize bounds the header read before it is authenticated
+const maxHeaderSize = 64 * 1024
+
+// keyInfo binds the derived and encrypted keys to this format
+const keyInfo = "conjur-inspect archive key"
+
+const (
+	algorithmRSA  = "RSA-OAEP-SHA256"
+	algorithmECDH = "ECDH-HKDF-SHA256"
+)
+
+// ErrWrongKey is returned when an archive was encrypted to a different key
+// than the one given to decrypt it
+var ErrWrongKey = errors.New("archive was encrypted to a different key")
+
+// ErrCorrupt is returned when an archive fails authentication, because it is
+// incomplete or has been modified
+var ErrCorrupt = errors.New("archive is incomplete or has been modified")
+
+// header describes how to recover the archive key
+type header struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+
+	// EncryptedKey is the RSA encrypted archive key
+	EncryptedKey []byte `json:"encrypted_key,omitempty"`
+
+	// EphemeralKey is the public key the archive key was derived from with
+	// ECDH
+	EphemeralKey []byte `json:"ephemeral_key,omitempty"`
+}
+
+// Encrypt reads the plaintext from reader and writes it to writer, encrypted
+// to the given public key
+func Encrypt(
+	writer io.Writer,
+	reader io.Reader,
+	publicKey crypto.PublicKey,
+) error {
+	keyID, err := KeyID(publicKey)
+	if err != nil {
+		return err
+	}
+
+	archiveHeader := header{KeyID: keyID}
+	var archiveKey []byte
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		archiveHeader.Algorithm = algorithmRSA
+
+		archiveKey = make([]byte, 32)
+		_, err = rand.Read(archiveKey)
+		if err != nil {
+			return err
+		}
+
+		archiveHeader.EncryptedKey, err = rsa.EncryptOAEP(
+			sha256.New(),
+			rand.Reader,
+			key,
+			archiveKey,
+			[]byte(keyInfo),
+		)
+		if err != nil {
+			return err
+		}
+	case *ecdh.PublicKey:
+		archiveHeader.Algorithm = algorithmECDH
+
+		ephemeralKey, err := key.Curve().GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		archiveHeader.EphemeralKey = ephemeralKey.PublicKey().Bytes()
+
+		sharedSecret, err := ephemeralKey.ECDH(key)
+		if err != nil {
+			return err
+		}
+
+		archiveKey, err = deriveKey(sharedSecret, ephemeralKey.PublicKey(), key)
+		if err != nil {
+			return err
+		}
+	default:
+		return unsupportedKeyError(publicKey)
+	}
+
+	prefix, err := headerPrefix(archiveHeader)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(prefix)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(archiveKey)
+	if err != nil {
+		return err
+	}
+
+	bufferedReader := bufio.NewReaderSize(reader, chunkSize)
+	plaintext := make([]byte, chunkSize)
+
+	for counter := uint64(0); ; counter++ {
+		count, err := io.ReadFull(bufferedReader, plaintext)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		final := count < chunkSize || isEOF(bufferedReader)
+
+		ciphertext := aead.Seal(nil, chunkNonce(counter, final), plaintext[:count], prefix)
+		_, err = writer.Write(ciphertext)
+		if err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// Decrypt reads an encrypted archive from reader and writes the plaintext to
+// writer, using the given private key. Plaintext is written as each chunk is
+// authenticated, so if decryption fails, what was written must be discarded.
+func Decrypt(
+	writer io.Writer,
+	reader io.Reader,
+	privateKey crypto.PrivateKey,
+) error {
+	bufferedReader := bufio.NewReaderSize(reader, chunkSize)
+
+	archiveHeader, prefix, err := readHeader(bufferedReader)
+	if err != nil {
+		return err
+	}
+
+	archiveKey, err := recoverKey(archiveHeader, privateKey)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(archiveKey)
+	if err != nil {
+		return err
+	}
+
+	ciphertext := make([]byte, chunkSize+aead.Overhead())
+
+	for counter := uint64(0); ; counter++ {
+		count, err := io.ReadFull(bufferedReader, ciphertext)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		// Encryption always writes a final chunk, so running out of chunks
+		// before it means the archive was cut short
+		if count < aead.Overhead() {
+			return ErrCorrupt
+		}
+
+		final := count < len(ciphertext) || isEOF(bufferedReader)
+
+		plaintext, err := aead.Open(
+			nil,
+			chunkNonce(counter, final),
+			ciphertext[:count],
+			prefix,
+		)
+		if err != nil {
+			return ErrCorrupt
+		}
+
+		_, err = writer.Write(plaintext)
+		if err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// IsEncrypted returns whether the reader starts like an encrypted archive
+func IsEncrypted(reader io.Reader) bool {
+	start := make([]byte, len(magic))
+	_, err := io.ReadFull(reader, start)
+
+	return err == nil && string(start) == magic
+}
+
+// headerPrefix returns everything written before the encrypted chunks: the
+// magic string, the header length and the header. It is authenticated with
+// each chunk.
+func headerPrefix(archiveHeader header) ([]byte, error) {
+	headerJSON, err := json.Marshal(archiveHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix bytes.Buffer
+	prefix.WriteString(magic)
+	_ = binary.Write(&prefix, binary.BigEndian, uint32(len(headerJSON)))
+	prefix.Write(headerJSON)
+
+	return prefix.Bytes(), nil
+}
+
+func readHeader(reader io.Reader) (header, []byte, error) {
+	var archiveHeader header
+
+	if !IsEncrypted(reader) {
+		return archiveHeader, nil, errors.New("not an encrypted conjur-inspect archive")
+	}
+
+	var headerLength uint32
+	err := binary.Read(reader, binary.BigEndian, &headerLength)
+	if err != nil {
+		return archiveHeader, nil, ErrCorrupt
+	}
+	if headerLength > maxHeaderSize {
+		return archiveHeader, nil, ErrCorrupt
+	}
+
+	headerJSON := make([]byte, headerLength)
+	_, err = io.ReadFull(reader, headerJSON)
+	if err != nil {
+		return archiveHeader, nil, ErrCorrupt
+	}
+
+	err = json.Unmarshal(headerJSON, &archiveHeader)
+	if err != nil {
+		return archiveHeader, nil, ErrCorrupt
+	}
+
+	var prefix bytes.Buffer
+	prefix.WriteString(magic)
+	_ = binary.Write(&prefix, binary.BigEndian, headerLength)
+	prefix.Write(headerJSON)
+
+	return archiveHeader, prefix.Bytes(), nil
+}
+
+// recoverKey recovers the archive key from the header with the private key
+func recoverKey(
+	archiveHeader header,
+	privateKey crypto.PrivateKey,
+) ([]byte, error) {
+	var publicKey crypto.PublicKey
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		publicKey = &key.PublicKey
+	case *ecdh.PrivateKey:
+		publicKey = key.PublicKey()
+	default:
+		return nil, unsupportedKeyError(privateKey)
+	}
+
+	keyID, err := KeyID(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if keyID != archiveHeader.KeyID {
+		return nil, fmt.Errorf("%w (%s)", ErrWrongKey, archiveHeader.KeyID)
+	}
+
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if archiveHeader.Algorithm != algorithmRSA {
+			return nil, ErrCorrupt
+		}
+
+		archiveKey, err := rsa.DecryptOAEP(
+			sha256.New(),
+			nil,
+			key,
+			archiveHeader.EncryptedKey,
+			[]byte(keyInfo),
+		)
+		if err != nil {
+			return nil, ErrCorrupt
+		}
+		return archiveKey, nil
+	case *ecdh.PrivateKey:
+		if archiveHeader.Algorithm != algorithmECDH {
+			return nil, ErrCorrupt
+		}
+
+		ephemeralKey, err := key.Curve().NewPublicKey(archiveHeader.EphemeralKey)
+		if err != nil {
+			return nil, ErrCorrupt
+		}
+
+		sharedSecret, err := key.ECDH(ephemeralKey)
+		if err != nil {
+			return nil, ErrCorrupt
+		}
+
+		return deriveKey(sharedSecret, ephemeralKey, key.PublicKey())
+	}
+
+	return nil, unsupportedKeyError(privateKey)
+}
+
+// deriveKey derives the archive key from the ECDH shared secret. The
+// ephemeral and recipient public keys are used as the salt, so the key is
+// bound to both.
+func deriveKey(
+	sharedSecret []byte,
+	ephemeralKey *ecdh.PublicKey,
+	recipientKey *ecdh.PublicKey,
+) ([]byte, error) {
+	salt := append(ephemeralKey.Bytes(), recipientKey.Bytes()...)
+
+	return hkdf.Key(sha256.New, sharedSecret, salt, keyInfo, 32)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of a chunk: its position in the archive, and
+// whether it is the last chunk. Each archive has its own key, so nonces are
+// never reused with the same key.
+func chunkNonce(counter uint64, final bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[3:11], counter)
+	if final {
+		nonce[11] = 1
+	}
+
+	return nonce
+}
+
+// isEOF returns whether there is nothing left to read
+func isEOF(reader *bufio.Reader) bool {
+	_, err := reader.Peek(1)
+	return errors.Is(err, io.EOF)
+}
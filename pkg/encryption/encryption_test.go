@@ -0,0 +1,120 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	x25519Key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	p256Key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys := map[string]struct {
+		publicKey  crypto.PublicKey
+		privateKey crypto.PrivateKey
+	}{
+		"RSA":    {&rsaKey.PublicKey, rsaKey},
+		"X25519": {x25519Key.PublicKey(), x25519Key},
+		"P-256":  {p256Key.PublicKey(), p256Key},
+	}
+
+	plaintexts := map[string][]byte{
+		"empty":           {},
+		"short":           []byte("conjur-inspect"),
+		"exact chunks":    bytes.Repeat([]byte("a"), 2*chunkSize),
+		"partial chunk":   bytes.Repeat([]byte("b"), 2*chunkSize+7),
+		"one byte over":   bytes.Repeat([]byte("c"), chunkSize+1),
+		"one byte under":  bytes.Repeat([]byte("d"), chunkSize-1),
+		"exactly a chunk": bytes.Repeat([]byte("e"), chunkSize),
+	}
+
+	for keyName, key := range keys {
+		for plaintextName, plaintext := range plaintexts {
+			t.Run(keyName+" "+plaintextName, func(t *testing.T) {
+				var encrypted bytes.Buffer
+				err := Encrypt(&encrypted, bytes.NewReader(plaintext), key.publicKey)
+				require.NoError(t, err)
+
+				assert.True(t, IsEncrypted(bytes.NewReader(encrypted.Bytes())))
+
+				var decrypted bytes.Buffer
+				err = Decrypt(&decrypted, &encrypted, key.privateKey)
+				require.NoError(t, err)
+				assert.True(t, bytes.Equal(plaintext, decrypted.Bytes()))
+			})
+		}
+	}
+}
+
+func TestDecryptWrongKey(t *testing.T) {
+	recipientKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	var encrypted bytes.Buffer
+	err = Encrypt(&encrypted, bytes.NewReader([]byte("secret")), recipientKey.PublicKey())
+	require.NoError(t, err)
+
+	err = Decrypt(&bytes.Buffer{}, &encrypted, otherKey)
+	assert.ErrorIs(t, err, ErrWrongKey)
+}
+
+func TestDecryptModified(t *testing.T) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	plaintext := bytes.Repeat([]byte("a"), 2*chunkSize+7)
+
+	var encrypted bytes.Buffer
+	err = Encrypt(&encrypted, bytes.NewReader(plaintext), key.PublicKey())
+	require.NoError(t, err)
+
+	encryptedBytes := encrypted.Bytes()
+	chunkLength := chunkSize + 16
+	lastChunk := len(encryptedBytes) - (7 + 16)
+
+	modifications := map[string][]byte{
+		// A byte in the middle of the first chunk is flipped
+		"flipped": func() []byte {
+			modified := bytes.Clone(encryptedBytes)
+			modified[lastChunk-chunkLength-100] ^= 1
+			return modified
+		}(),
+		// The last chunk is removed, so the archive ends on a full chunk
+		"truncated at a chunk": encryptedBytes[:lastChunk],
+		// The archive ends part way through a chunk
+		"truncated in a chunk": encryptedBytes[:len(encryptedBytes)-3],
+		// Data is added after the final chunk
+		"extended": append(bytes.Clone(encryptedBytes), 0),
+	}
+
+	for name, modified := range modifications {
+		t.Run(name, func(t *testing.T) {
+			err := Decrypt(&bytes.Buffer{}, bytes.NewReader(modified), key)
+			assert.ErrorIs(t, err, ErrCorrupt)
+		})
+	}
+}
+
+func TestDecryptNotEncrypted(t *testing.T) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	err = Decrypt(&bytes.Buffer{}, bytes.NewReader([]byte("plain tar.gz")), key)
+	assert.EqualError(t, err, "not an encrypted conjur-inspect archive")
+}
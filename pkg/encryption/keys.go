@@ -0,0 +1,147 @@
+package encryption
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// minRSABits is the smallest RSA key archives may be encrypted to
+const minRSABits = 2048
+
+// LoadPublicKey reads a PEM encoded RSA, X25519 or NIST curve public key
+// from the given file
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePublicKey(pemBytes)
+}
+
+// ParsePublicKey parses a PEM encoded RSA, X25519 or NIST curve public key.
+// The key is returned as an *rsa.PublicKey or an *ecdh.PublicKey.
+func ParsePublicKey(pemBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+
+	var publicKey any
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizePublicKey(publicKey)
+}
+
+// LoadPrivateKey reads a PEM encoded RSA, X25519 or NIST curve private key
+// from the given file
+func LoadPrivateKey(path string) (crypto.PrivateKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePrivateKey(pemBytes)
+}
+
+// ParsePrivateKey parses a PEM encoded RSA, X25519 or NIST curve private
+// key. The key is returned as an *rsa.PrivateKey or an *ecdh.PrivateKey.
+func ParsePrivateKey(pemBytes []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+
+	var privateKey any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdh.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key.ECDH()
+	default:
+		return nil, unsupportedKeyError(privateKey)
+	}
+}
+
+// normalizePublicKey returns the key in the form used for encryption. EC keys
+// are used for key agreement, whether they were generated for ECDH or ECDSA.
+func normalizePublicKey(publicKey any) (crypto.PublicKey, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf(
+				"RSA key is too small: %d bits (must be at least %d)",
+				key.N.BitLen(),
+				minRSABits,
+			)
+		}
+		return key, nil
+	case *ecdh.PublicKey:
+		return key, nil
+	case *ecdsa.PublicKey:
+		return key.ECDH()
+	default:
+		return nil, unsupportedKeyError(publicKey)
+	}
+}
+
+// KeyID returns a fingerprint of a public key, recorded in encrypted
+// archives so it's clear which key can open them
+func KeyID(publicKey crypto.PublicKey) (string, error) {
+	var keyBytes []byte
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		keyBytes = x509.MarshalPKCS1PublicKey(key)
+	case *ecdh.PublicKey:
+		keyBytes = key.Bytes()
+	default:
+		return "", unsupportedKeyError(publicKey)
+	}
+
+	sum := sha256.Sum256(keyBytes)
+	return "SHA256:" + hex.EncodeToString(sum[:]), nil
+}
+
+func unsupportedKeyError(key any) error {
+	return fmt.Errorf(
+		"unsupported key type %T (must be RSA, X25519 or a NIST curve)",
+		key,
+	)
+}
@@ -0,0 +1,69 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeys(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&ecdsaKey.PublicKey)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+
+	// EC keys are used for ECDH, whether they were generated for it or not
+	publicKey, err := ParsePublicKey(
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}),
+	)
+	require.NoError(t, err)
+	assert.IsType(t, &ecdh.PublicKey{}, publicKey)
+
+	privateKey, err := ParsePrivateKey(
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateDER}),
+	)
+	require.NoError(t, err)
+	assert.IsType(t, &ecdh.PrivateKey{}, privateKey)
+
+	var encrypted bytes.Buffer
+	err = Encrypt(&encrypted, bytes.NewReader([]byte("secret")), publicKey)
+	require.NoError(t, err)
+
+	var decrypted bytes.Buffer
+	err = Decrypt(&decrypted, &encrypted, privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted.String())
+}
+
+func TestParsePublicKeyErrors(t *testing.T) {
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	_, err = ParsePublicKey(
+		pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PUBLIC KEY",
+			Bytes: x509.MarshalPKCS1PublicKey(&smallRSAKey.PublicKey),
+		}),
+	)
+	assert.EqualError(t, err, "RSA key is too small: 1024 bits (must be at least 2048)")
+
+	_, err = ParsePublicKey([]byte("not a key"))
+	assert.EqualError(t, err, "no PEM encoded key found")
+
+	_, err = ParsePublicKey(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{}}),
+	)
+	assert.EqualError(t, err, "unsupported PEM block type: CERTIFICATE")
+}
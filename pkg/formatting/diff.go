@@ -0,0 +1,117 @@
+package formatting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/maybe"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// DiffWriter represents an object that can render the differences between
+// two reports to an io.Writer
+type DiffWriter interface {
+	Write(io.Writer, *report.Diff) error
+}
+
+// TextDiff renders a report diff as text, using a given format strategy
+type TextDiff struct {
+	FormatStrategy TextFormatStrategy
+}
+
+func (text *TextDiff) Write(writer io.Writer, diff *report.Diff) error {
+	maybeWriter := maybe.NewWriter(writer)
+
+	formattedHeader := text.FormatStrategy.Bold(
+		strings.Join(
+			[]string{
+				"========================================",
+				"Conjur Enterprise Inspection Report Diff",
+				fmt.Sprintf("Old: %s (Version: %s)", diff.Old.Name, diff.Old.Version),
+				fmt.Sprintf("New: %s (Version: %s)", diff.New.Name, diff.New.Version),
+				"========================================",
+			},
+			"\n",
+		),
+	)
+	maybeWriter.WriteString(formattedHeader)
+	maybeWriter.WriteString("\n\n")
+
+	maybeWriter.WriteString(text.FormatStrategy.Bold(titleHeader("Results")))
+	maybeWriter.WriteString("\n")
+	if len(diff.Results) == 0 {
+		maybeWriter.WriteString("No changes\n")
+	}
+	for _, change := range diff.Results {
+		lineColor := ""
+		if change.New != nil {
+			lineColor = statusColor(change.New.Status)
+		}
+
+		maybeWriter.WriteString(
+			text.FormatStrategy.Color(resultChangeLine(change), lineColor),
+		)
+		maybeWriter.WriteString("\n")
+	}
+
+	maybeWriter.WriteString("\n")
+	maybeWriter.WriteString(text.FormatStrategy.Bold(titleHeader("Raw Outputs")))
+	maybeWriter.WriteString("\n")
+	if len(diff.RawOutputs) == 0 {
+		maybeWriter.WriteString("No changes\n")
+	}
+	for _, change := range diff.RawOutputs {
+		maybeWriter.WriteString(
+			fmt.Sprintf("%s - %s\n", strings.ToUpper(change.Change), change.Name),
+		)
+	}
+
+	return maybeWriter.Error()
+}
+
+func resultChangeLine(change report.ResultChange) string {
+	title := change.Old
+	if title == nil {
+		title = change.New
+	}
+
+	var detail string
+	switch change.Change {
+	case report.ChangeAdded:
+		detail = statusValue(change.New)
+	case report.ChangeRemoved:
+		detail = statusValue(change.Old)
+	default:
+		detail = fmt.Sprintf(
+			"%s -> %s",
+			statusValue(change.Old),
+			statusValue(change.New),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s - %s - %s: %s",
+		strings.ToUpper(change.Change),
+		change.Section,
+		title.Title,
+		detail,
+	)
+}
+
+func statusValue(result *check.Result) string {
+	return fmt.Sprintf("%s (%s)", result.Status, result.Value)
+}
+
+// JSONDiff renders a report diff as JSON
+type JSONDiff struct{}
+
+func (*JSONDiff) Write(writer io.Writer, diff *report.Diff) error {
+	encoder := json.NewEncoder(writer)
+
+	encoder.SetIndent("", " ")
+
+	return encoder.Encode(diff)
+}
@@ -0,0 +1,212 @@
+package formatting
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+
+	"github.com/TwiN/go-color"
+)
+
+// HTML renders a report result as a single, self-contained HTML page, so it
+// can be attached to a ticket or email and viewed in any browser
+type HTML struct {
+	// ShowRawOutputs lists the raw output files saved by each section after
+	// the section's results
+	ShowRawOutputs bool
+}
+
+// htmlColors are the CSS colors of the ANSI colors used in the text report,
+// so that statuses look the same in both
+var htmlColors = map[string]string{
+	color.Red:    "#c62828",
+	color.Yellow: "#b26a00",
+	color.Green:  "#2e7d32",
+}
+
+// htmlDefaultColor is used for statuses that aren't colored in the text report
+const htmlDefaultColor = "#546e7a"
+
+// htmlReport is the data rendered by the HTML template
+type htmlReport struct {
+	*report.Result
+
+	ShowRawOutputs     bool
+	Summary            report.Summary
+	StatusCounts       []htmlStatusCount
+	RecommendedActions []report.RecommendedAction
+}
+
+type htmlStatusCount struct {
+	Status string
+	Count  int
+}
+
+var htmlTemplate = template.Must(
+	template.New("report").Funcs(template.FuncMap{
+		"statusColor": func(status string) string {
+			return htmlColor(statusColor(status))
+		},
+		"verdictColor": func(verdict string) string {
+			return htmlColor(verdictColor(verdict))
+		},
+		"sectionStatuses": sectionStatuses,
+		"sectionOpen":     sectionOpen,
+		"upper":           strings.ToUpper,
+		"join":            strings.Join,
+	}).Parse(htmlReportTemplate),
+)
+
+func (html *HTML) Write(
+	writer io.Writer,
+	result *report.Result,
+) error {
+	summary := result.Summarize()
+
+	statusCounts := []htmlStatusCount{}
+	for _, status := range countedStatuses(summary.StatusCounts) {
+		statusCounts = append(
+			statusCounts,
+			htmlStatusCount{Status: status, Count: summary.StatusCounts[status]},
+		)
+	}
+
+	return htmlTemplate.Execute(writer, htmlReport{
+		Result:             result,
+		ShowRawOutputs:     html.ShowRawOutputs,
+		Summary:            summary,
+		StatusCounts:       statusCounts,
+		RecommendedActions: result.RecommendedActions(),
+	})
+}
+
+func htmlColor(ansiColor string) string {
+	if htmlColor, ok := htmlColors[ansiColor]; ok {
+		return htmlColor
+	}
+
+	return htmlDefaultColor
+}
+
+// sectionStatuses returns the distinct statuses of the section's results, in
+// summary order, to show on the collapsed section
+func sectionStatuses(section report.ResultSection) []htmlStatusCount {
+	counts := map[string]int{}
+	for _, result := range section.Results {
+		counts[result.Status]++
+	}
+
+	statusCounts := []htmlStatusCount{}
+	for _, status := range countedStatuses(counts) {
+		statusCounts = append(
+			statusCounts,
+			htmlStatusCount{Status: status, Count: counts[status]},
+		)
+	}
+
+	return statusCounts
+}
+
+// sectionOpen is whether a section is expanded when the page is opened.
+// Sections with problems are expanded, so they stand out.
+func sectionOpen(section report.ResultSection) bool {
+	for _, result := range section.Results {
+		if check.Severity(result.Status) >= check.Severity(check.StatusWarn) {
+			return true
+		}
+	}
+
+	return false
+}
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Conjur Enterprise Inspection Report{{if .ID}} - {{.ID}}{{end}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 64em; padding: 0 1em; color: #212121; }
+h1 { margin-bottom: 0.2em; }
+.meta { color: #616161; margin-top: 0; }
+.notice { border-left: 4px solid #b26a00; padding: 0.5em 1em; background: #fff8e1; }
+.badge { display: inline-block; border-radius: 0.8em; padding: 0.1em 0.6em; color: #fff; font-size: 0.8em; font-weight: bold; white-space: nowrap; }
+.verdict { font-size: 1.3em; font-weight: bold; }
+details { border: 1px solid #e0e0e0; border-radius: 4px; margin: 0.6em 0; }
+summary { cursor: pointer; padding: 0.6em 1em; font-weight: bold; background: #f5f5f5; }
+summary .badge { margin-left: 0.4em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; vertical-align: top; padding: 0.4em 1em; border-top: 1px solid #e0e0e0; }
+.remediation { color: #424242; font-size: 0.9em; }
+.raw-outputs { padding: 0.4em 1em; color: #616161; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Conjur Enterprise Inspection Report</h1>
+<p class="meta">Version: {{.Version}}{{if .ID}} &middot; Report ID: {{.ID}}{{end}}</p>
+{{- if .Interrupted}}
+<p class="notice">Inspection interrupted: only the checks that ran are included</p>
+{{- end}}
+
+<h2>Summary</h2>
+<p class="verdict" style="color: {{verdictColor .Summary.Verdict}}">Verdict: {{upper .Summary.Verdict}}</p>
+<p>Results:
+{{- range .StatusCounts}}
+<span class="badge" style="background: {{statusColor .Status}}">{{.Count}} {{.Status}}</span>
+{{- else}} none{{end}}
+</p>
+{{- if .Summary.Problems}}
+<ul>
+{{- range .Summary.Problems}}
+<li><span class="badge" style="background: {{statusColor .Status}}">{{.Status}}</span> {{.Section}} - {{.Title}}: {{.Value}}</li>
+{{- end}}
+</ul>
+{{- end}}
+
+<h2>Results</h2>
+{{- range .Sections}}
+{{- if or .Results (and $.ShowRawOutputs .RawOutputs)}}
+<details{{if sectionOpen .}} open{{end}}>
+<summary>{{.Title}}
+{{- range sectionStatuses .}} <span class="badge" style="background: {{statusColor .Status}}">{{.Count}} {{.Status}}</span>{{end}}</summary>
+{{- if .Results}}
+<table>
+<tr><th>Status</th><th>Title</th><th>Value</th><th>Message</th></tr>
+{{- range .Results}}
+<tr>
+<td><span class="badge" style="background: {{statusColor .Status}}">{{.Status}}</span></td>
+<td>{{.Title}}</td>
+<td>{{.Value}}</td>
+<td>{{.Message}}
+{{- if .Remediation}}<div class="remediation">Remediation: {{.Remediation}}</div>{{end}}
+{{- if .DocURL}}<div class="remediation">See: <a href="{{.DocURL}}">{{.DocURL}}</a></div>{{end -}}
+</td>
+</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if and $.ShowRawOutputs .RawOutputs}}
+<div class="raw-outputs">Raw outputs: {{join .RawOutputs ", "}}</div>
+{{- end}}
+</details>
+{{- end}}
+{{- end}}
+{{- if .RecommendedActions}}
+
+<h2>Recommended Actions</h2>
+<ul>
+{{- range .RecommendedActions}}
+<li><span class="badge" style="background: {{statusColor .Status}}">{{.Status}}</span> {{.Remediation}}
+<div class="remediation">Affects: {{join .Results ", "}}</div>
+{{- if .DocURL}}
+<div class="remediation">See: <a href="{{.DocURL}}">{{.DocURL}}</a></div>
+{{- end}}
+</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`
@@ -0,0 +1,71 @@
+package formatting_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+func TestHTML(t *testing.T) {
+	result := report.Result{
+		Version:     "1.0.0",
+		ID:          "standby",
+		Interrupted: true,
+		Sections: []report.ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{
+						Title:       "Read IOPs",
+						Value:       "40",
+						Status:      check.StatusWarn,
+						Remediation: "Use faster storage",
+						DocURL:      "https://example.com/storage",
+					},
+				},
+				RawOutputs: []string{"fio.json"},
+			},
+			{
+				Title: "Host",
+				Results: []check.Result{
+					{Title: "Hostname", Value: "<script>", Status: check.StatusInfo},
+				},
+			},
+			{Title: "Empty"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, (&formatting.HTML{}).Write(&buffer, &result))
+	html := buffer.String()
+
+	assert.Contains(t, html, "<title>Conjur Enterprise Inspection Report - standby</title>")
+	assert.Contains(t, html, "Version: 1.0.0 &middot; Report ID: standby")
+	assert.Contains(t, html, "Inspection interrupted")
+
+	// The summary and status badges use the same colors as the text report
+	assert.Contains(t, html, `<p class="verdict" style="color: #b26a00">Verdict: AT RISK</p>`)
+	assert.Contains(t, html, `<span class="badge" style="background: #b26a00">1 WARN</span>`)
+
+	// Sections with problems are expanded, and empty sections are hidden
+	assert.Contains(t, html, "<details open>\n<summary>Disk")
+	assert.Contains(t, html, "<details>\n<summary>Host")
+	assert.NotContains(t, html, "Empty")
+
+	// Values are escaped
+	assert.Contains(t, html, "<td>&lt;script&gt;</td>")
+
+	assert.Contains(t, html, "Remediation: Use faster storage")
+	assert.Contains(t, html, "<h2>Recommended Actions</h2>")
+	assert.NotContains(t, html, "Raw outputs")
+
+	buffer.Reset()
+	require.NoError(t, (&formatting.HTML{ShowRawOutputs: true}).Write(&buffer, &result))
+	assert.Contains(t, buffer.String(), "Raw outputs: fio.json")
+}
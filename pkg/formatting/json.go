@@ -19,5 +19,10 @@ func (*JSON) Write(
 
 	encoder.SetIndent("", " ")
 
-	return encoder.Encode(result)
+	// Include the summary without changing the given result
+	summary := result.Summarize()
+	summarized := *result
+	summarized.Summary = &summary
+
+	return encoder.Encode(&summarized)
 }
@@ -0,0 +1,182 @@
+package formatting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// JUnit renders a report result as JUnit XML, so CI systems can show the
+// results like test results. Each section is a test suite and each result is a
+// test case. FAIL results are failures, ERROR and TIMEOUT results are errors,
+// and WARN results are skipped unless WarnAsFailure is set.
+type JUnit struct {
+	// WarnAsFailure reports WARN results as failures instead of skipped tests
+	WarnAsFailure bool
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitProblem `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+func (junit *JUnit) Write(
+	writer io.Writer,
+	result *report.Result,
+) error {
+	suites := junitTestSuites{Name: "Conjur Enterprise Inspection Report"}
+
+	for _, section := range result.Sections {
+		if len(section.Results) == 0 {
+			continue
+		}
+
+		suite := junitTestSuite{
+			Name:       section.Title,
+			Properties: junitProperties(result),
+		}
+
+		for _, sectionResult := range section.Results {
+			testCase := junit.testCase(section.Title, sectionResult)
+
+			suite.Tests++
+			switch {
+			case testCase.Failure != nil:
+				suite.Failures++
+			case testCase.Error != nil:
+				suite.Errors++
+			case testCase.Skipped != nil:
+				suite.Skipped++
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+		}
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+
+	err = encoder.Encode(&suites)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
+
+// testCase returns the test case for a result. Results are grouped by their
+// check ID, or by section for reports that predate check IDs.
+func (junit *JUnit) testCase(sectionTitle string, result check.Result) junitTestCase {
+	className := result.CheckID
+	if className == "" {
+		className = sectionTitle
+	}
+
+	testCase := junitTestCase{
+		Name:      result.Title,
+		ClassName: className,
+		SystemOut: junitSystemOut(result),
+	}
+
+	problem := &junitProblem{
+		Message: strings.TrimSpace(fmt.Sprintf("%s: %s", result.Status, result.Value)),
+		Type:    result.Status,
+	}
+
+	switch result.Status {
+	case check.StatusFail:
+		testCase.Failure = problem
+	case check.StatusError, check.StatusTimeout:
+		testCase.Error = problem
+	case check.StatusWarn:
+		if junit.WarnAsFailure {
+			testCase.Failure = problem
+		} else {
+			testCase.Skipped = problem
+		}
+	}
+
+	return testCase
+}
+
+// junitProperties identifies the report that each test suite belongs to
+func junitProperties(result *report.Result) []junitProperty {
+	properties := []junitProperty{{Name: "version", Value: result.Version}}
+
+	if result.ID != "" {
+		properties = append(properties, junitProperty{Name: "report_id", Value: result.ID})
+	}
+
+	if result.Interrupted {
+		properties = append(properties, junitProperty{Name: "interrupted", Value: "true"})
+	}
+
+	return properties
+}
+
+// junitSystemOut returns the details of a result to show with its test case
+func junitSystemOut(result check.Result) string {
+	lines := []string{fmt.Sprintf("Value: %s", result.Value)}
+
+	if result.Message != "" {
+		lines = append(lines, fmt.Sprintf("Message: %s", result.Message))
+	}
+	if result.Remediation != "" {
+		lines = append(lines, fmt.Sprintf("Remediation: %s", result.Remediation))
+	}
+	if result.DocURL != "" {
+		lines = append(lines, fmt.Sprintf("See: %s", result.DocURL))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,112 @@
+package formatting_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+func junitTestResult() *report.Result {
+	return &report.Result{
+		Version: "1.0.0",
+		ID:      "standby",
+		Sections: []report.ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{
+						CheckID:     "disk.fio.iops",
+						Title:       "Read IOPs",
+						Value:       "40",
+						Status:      check.StatusWarn,
+						Remediation: "Use faster storage",
+					},
+					{
+						CheckID: "disk.fio.iops",
+						Title:   "Write IOPs",
+						Value:   "120",
+						Status:  check.StatusPass,
+					},
+				},
+			},
+			{
+				Title: "Host",
+				Results: []check.Result{
+					{Title: "CPU Cores", Value: "1", Status: check.StatusFail},
+					{
+						Title:   "Hostname",
+						Value:   "N/A",
+						Status:  check.StatusError,
+						Message: "command not found",
+					},
+				},
+			},
+			{Title: "Empty"},
+		},
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, (&formatting.JUnit{}).Write(&buffer, junitTestResult()))
+
+	assert.Equal(
+		t,
+		`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="Conjur Enterprise Inspection Report" tests="4" failures="1" errors="1" skipped="1">
+  <testsuite name="Disk" tests="2" failures="0" errors="0" skipped="1">
+    <properties>
+      <property name="version" value="1.0.0"></property>
+      <property name="report_id" value="standby"></property>
+    </properties>
+    <testcase name="Read IOPs" classname="disk.fio.iops">
+      <skipped message="WARN: 40" type="WARN"></skipped>
+      <system-out>Value: 40&#xA;Remediation: Use faster storage</system-out>
+    </testcase>
+    <testcase name="Write IOPs" classname="disk.fio.iops">
+      <system-out>Value: 120</system-out>
+    </testcase>
+  </testsuite>
+  <testsuite name="Host" tests="2" failures="1" errors="1" skipped="0">
+    <properties>
+      <property name="version" value="1.0.0"></property>
+      <property name="report_id" value="standby"></property>
+    </properties>
+    <testcase name="CPU Cores" classname="Host">
+      <failure message="FAIL: 1" type="FAIL"></failure>
+      <system-out>Value: 1</system-out>
+    </testcase>
+    <testcase name="Hostname" classname="Host">
+      <error message="ERROR: N/A" type="ERROR"></error>
+      <system-out>Value: N/A&#xA;Message: command not found</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		buffer.String(),
+	)
+}
+
+func TestJUnitWarnAsFailure(t *testing.T) {
+	var buffer bytes.Buffer
+	junit := &formatting.JUnit{WarnAsFailure: true}
+	require.NoError(t, junit.Write(&buffer, junitTestResult()))
+
+	// The output is well-formed XML
+	var parsed struct {
+		Failures int `xml:"failures,attr"`
+		Skipped  int `xml:"skipped,attr"`
+	}
+	require.NoError(t, xml.Unmarshal(buffer.Bytes(), &parsed))
+
+	assert.Equal(t, 2, parsed.Failures)
+	assert.Equal(t, 0, parsed.Skipped)
+	assert.Contains(t, buffer.String(), `<failure message="WARN: 40" type="WARN"></failure>`)
+}
@@ -0,0 +1,176 @@
+package formatting
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/maybe"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// Markdown renders a report result as Markdown, with a heading and a table of
+// results for each section, so it can be pasted into tickets and wikis
+type Markdown struct {
+	// ShowRawOutputs lists the raw output files saved by each section after
+	// the section's results
+	ShowRawOutputs bool
+}
+
+// markdownCellReplacer escapes the characters that would otherwise end a
+// table cell or row
+var markdownCellReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+// markdownLineReplacer keeps text on a single line, so it stays in the list
+// item or paragraph it is written to
+var markdownLineReplacer = strings.NewReplacer(
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+func (markdown *Markdown) Write(
+	writer io.Writer,
+	result *report.Result,
+) error {
+	maybeWriter := maybe.NewWriter(writer)
+
+	maybeWriter.WriteString("# Conjur Enterprise Inspection Report\n\n")
+	maybeWriter.WriteString(fmt.Sprintf("Version: %s", markdownLine(result.Version)))
+	if result.ID != "" {
+		// Two trailing spaces break the line without starting a new paragraph
+		maybeWriter.WriteString(fmt.Sprintf("  \nReport ID: %s", markdownLine(result.ID)))
+	}
+	maybeWriter.WriteString("\n\n")
+
+	if result.Interrupted {
+		maybeWriter.WriteString(
+			"> **Inspection interrupted:** only the checks that ran are included\n\n",
+		)
+	}
+
+	markdown.writeSummary(maybeWriter, result.Summarize())
+
+	for _, section := range result.Sections {
+		showRawOutputs := markdown.ShowRawOutputs && len(section.RawOutputs) > 0
+		if len(section.Results) == 0 && !showRawOutputs {
+			continue
+		}
+
+		maybeWriter.WriteString(fmt.Sprintf("\n## %s\n", markdownLine(section.Title)))
+
+		if len(section.Results) > 0 {
+			maybeWriter.WriteString("\n| Status | Title | Value | Message |\n")
+			maybeWriter.WriteString("| --- | --- | --- | --- |\n")
+		}
+		for _, result := range section.Results {
+			maybeWriter.WriteString(
+				fmt.Sprintf(
+					"| %s | %s | %s | %s |\n",
+					markdownCell(result.Status),
+					markdownCell(result.Title),
+					markdownCell(result.Value),
+					markdownCell(result.Message),
+				),
+			)
+		}
+
+		if showRawOutputs {
+			maybeWriter.WriteString(
+				fmt.Sprintf(
+					"\nRaw outputs: %s\n",
+					markdownLine(strings.Join(section.RawOutputs, ", ")),
+				),
+			)
+		}
+	}
+
+	markdown.writeRecommendedActions(maybeWriter, result)
+
+	return maybeWriter.Error()
+}
+
+func (*Markdown) writeSummary(maybeWriter *maybe.Writer, summary report.Summary) {
+	maybeWriter.WriteString("## Summary\n\n")
+	maybeWriter.WriteString(
+		fmt.Sprintf("**Verdict: %s**\n\n", strings.ToUpper(summary.Verdict)),
+	)
+
+	counts := []string{}
+	for _, status := range countedStatuses(summary.StatusCounts) {
+		counts = append(
+			counts,
+			fmt.Sprintf("%d %s", summary.StatusCounts[status], status),
+		)
+	}
+	if len(counts) == 0 {
+		counts = append(counts, "none")
+	}
+	maybeWriter.WriteString(
+		fmt.Sprintf("Results: %s\n", markdownLine(strings.Join(counts, ", "))),
+	)
+
+	if len(summary.Problems) > 0 {
+		maybeWriter.WriteString("\n")
+	}
+	for _, problem := range summary.Problems {
+		maybeWriter.WriteString(
+			fmt.Sprintf(
+				"- **%s** - %s - %s: %s\n",
+				markdownLine(problem.Status),
+				markdownLine(problem.Section),
+				markdownLine(problem.Title),
+				markdownLine(problem.Value),
+			),
+		)
+	}
+}
+
+func (*Markdown) writeRecommendedActions(
+	maybeWriter *maybe.Writer,
+	result *report.Result,
+) {
+	actions := result.RecommendedActions()
+	if len(actions) == 0 {
+		return
+	}
+
+	maybeWriter.WriteString("\n## Recommended Actions\n\n")
+
+	for _, action := range actions {
+		maybeWriter.WriteString(
+			fmt.Sprintf(
+				"- **%s** - %s\n",
+				markdownLine(action.Status),
+				markdownLine(action.Remediation),
+			),
+		)
+		maybeWriter.WriteString(
+			fmt.Sprintf(
+				"  - Affects: %s\n",
+				markdownLine(strings.Join(action.Results, ", ")),
+			),
+		)
+		if action.DocURL != "" {
+			maybeWriter.WriteString(
+				fmt.Sprintf("  - See: <%s>\n", markdownLine(action.DocURL)),
+			)
+		}
+	}
+}
+
+// markdownCell escapes a value for a table cell
+func markdownCell(value string) string {
+	return markdownCellReplacer.Replace(value)
+}
+
+// markdownLine escapes a value for a single line of text
+func markdownLine(value string) string {
+	return markdownLineReplacer.Replace(value)
+}
@@ -0,0 +1,107 @@
+package formatting_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+func TestMarkdown(t *testing.T) {
+	result := report.Result{
+		Version: "1.0.0",
+		ID:      "standby",
+		Sections: []report.ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{
+						Title:       "Read IOPs",
+						Value:       "40",
+						Status:      check.StatusWarn,
+						Remediation: "Use faster storage",
+						DocURL:      "https://example.com/storage",
+					},
+					{Title: "Write IOPs", Value: "120", Status: check.StatusPass},
+				},
+				RawOutputs: []string{"fio.json"},
+			},
+			{
+				Title: "Host",
+				Results: []check.Result{
+					{
+						Title:   "Mounts",
+						Value:   "/ | ext4\n/opt | xfs",
+						Status:  check.StatusInfo,
+						Message: `C:\data`,
+					},
+				},
+			},
+			{Title: "Empty"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	markdown := &formatting.Markdown{ShowRawOutputs: true}
+	require.NoError(t, markdown.Write(&buffer, &result))
+
+	assert.Equal(
+		t,
+		`# Conjur Enterprise Inspection Report
+
+Version: 1.0.0  
+Report ID: standby
+
+## Summary
+
+**Verdict: AT RISK**
+
+Results: 1 WARN, 1 PASS, 1 INFO
+
+- **WARN** - Disk - Read IOPs: 40
+
+## Disk
+
+| Status | Title | Value | Message |
+| --- | --- | --- | --- |
+| WARN | Read IOPs | 40 |  |
+| PASS | Write IOPs | 120 |  |
+
+Raw outputs: fio.json
+
+## Host
+
+| Status | Title | Value | Message |
+| --- | --- | --- | --- |
+| INFO | Mounts | / \| ext4<br>/opt \| xfs | C:\\data |
+
+## Recommended Actions
+
+- **WARN** - Use faster storage
+  - Affects: Disk - Read IOPs
+  - See: <https://example.com/storage>
+`,
+		buffer.String(),
+	)
+}
+
+func TestMarkdownInterrupted(t *testing.T) {
+	var buffer bytes.Buffer
+	markdown := &formatting.Markdown{}
+	require.NoError(
+		t,
+		markdown.Write(&buffer, &report.Result{Version: "1.0.0", Interrupted: true}),
+	)
+
+	assert.Contains(
+		t,
+		buffer.String(),
+		"> **Inspection interrupted:** only the checks that ran are included",
+	)
+	assert.Contains(t, buffer.String(), "Results: none\n")
+}
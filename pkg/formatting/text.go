@@ -3,6 +3,8 @@ package formatting
 import (
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
@@ -15,6 +17,15 @@ import (
 // Text renders a report result as text, using a given format strategy
 type Text struct {
 	FormatStrategy TextFormatStrategy
+
+	// ShowRawOutputs lists the raw output files saved by each section after
+	// the section's results
+	ShowRawOutputs bool
+
+	// Statuses limits the displayed results to those with one of these
+	// statuses. Sections without any displayed results are hidden. If empty,
+	// every result is displayed.
+	Statuses []string
 }
 
 func (text *Text) Write(
@@ -30,10 +41,26 @@ func (text *Text) Write(
 	maybeWriter.WriteString(formattedHeader)
 	maybeWriter.WriteString("\n\n")
 
-	// Filter out sections with no results
+	if result.Interrupted {
+		maybeWriter.WriteString(
+			text.FormatStrategy.Color(
+				"Inspection interrupted: only the checks that ran are included",
+				color.Yellow,
+			),
+		)
+		maybeWriter.WriteString("\n\n")
+	}
+
+	// The summary covers the whole report, even if some results are hidden
+	text.writeSummary(maybeWriter, result.Summarize())
+
+	displayed, hiddenCount := text.filterStatuses(result)
+
+	// Filter out sections with nothing to show
 	nonEmptySections := []report.ResultSection{}
-	for _, section := range result.Sections {
-		if len(section.Results) > 0 {
+	for _, section := range displayed.Sections {
+		if len(section.Results) > 0 ||
+			(text.ShowRawOutputs && len(text.Statuses) == 0 && len(section.RawOutputs) > 0) {
 			nonEmptySections = append(nonEmptySections, section)
 		}
 	}
@@ -51,6 +78,16 @@ func (text *Text) Write(
 			)
 			maybeWriter.WriteString(formattedResultLine)
 			maybeWriter.WriteString("\n")
+			maybeWriter.WriteString(remediationLines(result.Remediation, result.DocURL))
+		}
+
+		if text.ShowRawOutputs && len(section.RawOutputs) > 0 {
+			maybeWriter.WriteString(
+				fmt.Sprintf(
+					"Raw outputs: %s\n",
+					strings.Join(section.RawOutputs, ", "),
+				),
+			)
 		}
 
 		// Extra space between sections (but not extra space at the end)
@@ -59,9 +96,167 @@ func (text *Text) Write(
 		}
 	}
 
+	text.writeRecommendedActions(maybeWriter, &displayed, len(nonEmptySections) > 0)
+
+	if hiddenCount > 0 {
+		maybeWriter.WriteString(
+			fmt.Sprintf(
+				"\n%d results hidden by the status filter (showing %s)\n",
+				hiddenCount,
+				strings.Join(text.Statuses, ", "),
+			),
+		)
+	}
+
 	return maybeWriter.Error()
 }
 
+// filterStatuses returns a copy of the result with only the results matching
+// the status filter, and the number of results that were removed
+func (text *Text) filterStatuses(result *report.Result) (report.Result, int) {
+	if len(text.Statuses) == 0 {
+		return *result, 0
+	}
+
+	filtered := *result
+	filtered.Sections = make([]report.ResultSection, len(result.Sections))
+	hiddenCount := 0
+
+	for i, section := range result.Sections {
+		filtered.Sections[i] = report.ResultSection{
+			Title:      section.Title,
+			Results:    []check.Result{},
+			RawOutputs: section.RawOutputs,
+		}
+
+		for _, sectionResult := range section.Results {
+			if !slices.Contains(text.Statuses, sectionResult.Status) {
+				hiddenCount++
+				continue
+			}
+
+			filtered.Sections[i].Results = append(
+				filtered.Sections[i].Results,
+				sectionResult,
+			)
+		}
+	}
+
+	return filtered, hiddenCount
+}
+
+// summaryStatuses is the order in which status counts are summarized, from
+// the most to least important to act on
+var summaryStatuses = []string{
+	check.StatusFail,
+	check.StatusWarn,
+	check.StatusError,
+	check.StatusTimeout,
+	check.StatusPass,
+	check.StatusInfo,
+}
+
+// writeSummary writes an overview of the results before the report sections,
+// so that problems stand out in long reports
+func (text *Text) writeSummary(maybeWriter *maybe.Writer, summary report.Summary) {
+	maybeWriter.WriteString(text.FormatStrategy.Bold(titleHeader("Summary")))
+	maybeWriter.WriteString("\n")
+
+	maybeWriter.WriteString(
+		text.FormatStrategy.Bold(
+			text.FormatStrategy.Color(
+				fmt.Sprintf("Verdict: %s", strings.ToUpper(summary.Verdict)),
+				verdictColor(summary.Verdict),
+			),
+		),
+	)
+	maybeWriter.WriteString("\n")
+
+	counts := []string{}
+	for _, status := range countedStatuses(summary.StatusCounts) {
+		counts = append(
+			counts,
+			fmt.Sprintf("%d %s", summary.StatusCounts[status], status),
+		)
+	}
+	if len(counts) == 0 {
+		counts = append(counts, "none")
+	}
+	maybeWriter.WriteString(fmt.Sprintf("Results: %s\n", strings.Join(counts, ", ")))
+
+	for _, problem := range summary.Problems {
+		maybeWriter.WriteString(
+			text.FormatStrategy.Color(
+				fmt.Sprintf(
+					"%s - %s - %s: %s",
+					problem.Status,
+					problem.Section,
+					problem.Title,
+					problem.Value,
+				),
+				statusColor(problem.Status),
+			),
+		)
+		maybeWriter.WriteString("\n")
+	}
+
+	maybeWriter.WriteString("\n")
+}
+
+// countedStatuses returns the statuses with a non-zero count, in summary
+// order. Statuses from plugins may not be one of the standard statuses, so
+// they are listed last.
+func countedStatuses(statusCounts map[string]int) []string {
+	statuses := slices.Clone(summaryStatuses)
+	for _, status := range slices.Sorted(maps.Keys(statusCounts)) {
+		if !slices.Contains(statuses, status) {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return slices.DeleteFunc(statuses, func(status string) bool {
+		return statusCounts[status] == 0
+	})
+}
+
+// writeRecommendedActions writes the remediations for the report's results
+// together at the end of the report, so they can be followed as a checklist
+func (text *Text) writeRecommendedActions(
+	maybeWriter *maybe.Writer,
+	result *report.Result,
+	afterSections bool,
+) {
+	actions := result.RecommendedActions()
+	if len(actions) == 0 {
+		return
+	}
+
+	if afterSections {
+		maybeWriter.WriteString("\n")
+	}
+
+	maybeWriter.WriteString(
+		text.FormatStrategy.Bold(titleHeader("Recommended Actions")),
+	)
+	maybeWriter.WriteString("\n")
+
+	for _, action := range actions {
+		maybeWriter.WriteString(
+			text.FormatStrategy.Color(
+				fmt.Sprintf("%s - %s", action.Status, action.Remediation),
+				statusColor(action.Status),
+			),
+		)
+		maybeWriter.WriteString("\n")
+		maybeWriter.WriteString(
+			fmt.Sprintf("  Affects: %s\n", strings.Join(action.Results, ", ")),
+		)
+		if action.DocURL != "" {
+			maybeWriter.WriteString(fmt.Sprintf("  See: %s\n", action.DocURL))
+		}
+	}
+}
+
 func reportHeader(version string) string {
 	return strings.Join(
 		[]string{
@@ -104,12 +299,41 @@ func resultLine(result check.Result) string {
 	}
 }
 
+// remediationLines returns the remediation and documentation link to show
+// under a result, or an empty string if there are none
+func remediationLines(remediation, docURL string) string {
+	lines := ""
+	if remediation != "" {
+		lines += fmt.Sprintf("  Remediation: %s\n", remediation)
+	}
+	if docURL != "" {
+		lines += fmt.Sprintf("  See: %s\n", docURL)
+	}
+
+	return lines
+}
+
+func verdictColor(verdict string) string {
+	switch verdict {
+	case report.VerdictQualified:
+		return color.Green
+	case report.VerdictAtRisk:
+		return color.Yellow
+	case report.VerdictNotQualified:
+		return color.Red
+	}
+
+	return ""
+}
+
 func statusColor(status string) string {
 	switch status {
 	case check.StatusError:
 		return color.Red
 	case check.StatusFail:
 		return color.Red
+	case check.StatusTimeout:
+		return color.Red
 	case check.StatusWarn:
 		return color.Yellow
 	case check.StatusPass:
@@ -0,0 +1,154 @@
+package formatting_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+func TestTextRemediation(t *testing.T) {
+	result := report.Result{
+		Version: "1.0.0",
+		Sections: []report.ResultSection{
+			{
+				Title: "CPU",
+				Results: []check.Result{
+					{
+						Title:       "CPU Cores",
+						Value:       "1",
+						Status:      check.StatusFail,
+						Remediation: "Allocate more CPU cores",
+					},
+				},
+			},
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{
+						Title:       "Read IOPs",
+						Value:       "40",
+						Status:      check.StatusWarn,
+						Remediation: "Use faster storage",
+						DocURL:      "https://example.com/storage",
+					},
+					{Title: "Write IOPs", Value: "120", Status: check.StatusPass},
+				},
+			},
+		},
+	}
+
+	var buffer bytes.Buffer
+	text := &formatting.Text{FormatStrategy: &formatting.PlainFormatStrategy{}}
+	require.NoError(t, text.Write(&buffer, &result))
+
+	assert.Equal(
+		t,
+		`========================================
+Conjur Enterprise Inspection Report
+Version: 1.0.0
+========================================
+
+Summary
+-------
+Verdict: NOT QUALIFIED
+Results: 1 FAIL, 1 WARN, 1 PASS
+FAIL - CPU - CPU Cores: 1
+WARN - Disk - Read IOPs: 40
+
+CPU
+---
+FAIL - CPU Cores: 1
+  Remediation: Allocate more CPU cores
+
+Disk
+----
+WARN - Read IOPs: 40
+  Remediation: Use faster storage
+  See: https://example.com/storage
+PASS - Write IOPs: 120
+
+Recommended Actions
+-------------------
+FAIL - Allocate more CPU cores
+  Affects: CPU - CPU Cores
+WARN - Use faster storage
+  Affects: Disk - Read IOPs
+  See: https://example.com/storage
+`,
+		buffer.String(),
+	)
+}
+
+func TestTextWithoutRemediation(t *testing.T) {
+	result := report.Result{
+		Version: "1.0.0",
+		Sections: []report.ResultSection{
+			{
+				Title:   "CPU",
+				Results: []check.Result{{Title: "CPU Cores", Value: "4", Status: check.StatusPass}},
+			},
+		},
+	}
+
+	var buffer bytes.Buffer
+	text := &formatting.Text{FormatStrategy: &formatting.PlainFormatStrategy{}}
+	require.NoError(t, text.Write(&buffer, &result))
+
+	assert.NotContains(t, buffer.String(), "Recommended Actions")
+}
+
+func TestTextStatusFilter(t *testing.T) {
+	result := report.Result{
+		Version: "1.0.0",
+		Sections: []report.ResultSection{
+			{
+				Title: "CPU",
+				Results: []check.Result{
+					{Title: "CPU Cores", Value: "8", Status: check.StatusPass},
+				},
+			},
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{Title: "Read IOPs", Value: "40", Status: check.StatusWarn},
+					{Title: "Write IOPs", Value: "120", Status: check.StatusPass},
+				},
+			},
+		},
+	}
+
+	var buffer bytes.Buffer
+	text := &formatting.Text{
+		FormatStrategy: &formatting.PlainFormatStrategy{},
+		Statuses:       []string{check.StatusWarn, check.StatusFail},
+	}
+	require.NoError(t, text.Write(&buffer, &result))
+
+	assert.Equal(
+		t,
+		`========================================
+Conjur Enterprise Inspection Report
+Version: 1.0.0
+========================================
+
+Summary
+-------
+Verdict: AT RISK
+Results: 1 WARN, 2 PASS
+WARN - Disk - Read IOPs: 40
+
+Disk
+----
+WARN - Read IOPs: 40
+
+2 results hidden by the status filter (showing WARN, FAIL)
+`,
+		buffer.String(),
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 )
 
 // We want all logging to go to the standard error stream, since we output data
@@ -13,6 +14,10 @@ var infoLogger = log.New(os.Stderr, "INFO:  ", log.LUTC|log.Ldate|log.Ltime|log.
 var errorLogger = log.New(os.Stderr, "ERROR: ", log.LUTC|log.Ldate|log.Ltime|log.Lmicroseconds|log.Lshortfile)
 var isDebug = false
 
+// handler receives the log messages instead of the loggers, when set
+var handler func(level string, message string)
+var handlerMutex sync.RWMutex
+
 /*
 RecordedError prints an error message to the error log and returns a new error with the given message.
 This method can receive also more arguments (e.g an external error) and they will be appended to the given error message.
@@ -67,7 +72,30 @@ func EnableDebugMode() {
 	Debug("Debug mode is enabled")
 }
 
+// SetHandler sends the log messages to the given function instead of the
+// standard error stream, for example when stderr carries machine-readable
+// output that plain log lines would corrupt. A nil handler restores logging to
+// stderr. The handler may be called concurrently.
+func SetHandler(newHandler func(level string, message string)) {
+	handlerMutex.Lock()
+	defer handlerMutex.Unlock()
+
+	handler = newHandler
+}
+
 func writeLog(logger *log.Logger, logLevel string, message string, args ...interface{}) {
+	handlerMutex.RLock()
+	currentHandler := handler
+	handlerMutex.RUnlock()
+
+	if currentHandler != nil {
+		if len(args) > 0 {
+			message = fmt.Sprintf(message, args...)
+		}
+		currentHandler(logLevel, message)
+		return
+	}
+
 	// -7 format ensures logs alignment, by padding spaces to log level to ensure 7 characters length.
 	// 5 for longest log level, 1 for ':', and a space separator.
 	logger.SetPrefix(fmt.Sprintf("%-7s", logLevel+":"))
@@ -46,6 +46,26 @@ func TestAuthenticator(t *testing.T) {
 	})
 }
 
+func TestSetHandler(t *testing.T) {
+	var logBuffer bytes.Buffer
+	infoLogger = log.New(&logBuffer, "", 0)
+
+	messages := []string{}
+	SetHandler(func(level string, message string) {
+		messages = append(messages, level+" "+message)
+	})
+
+	Warn("message with param: <%s>", "param value")
+	assert.Equal(t, []string{"WARN message with param: <param value>"}, messages)
+	assert.Equal(t, 0, logBuffer.Len())
+
+	// Removing the handler logs to the logger again
+	SetHandler(nil)
+	Warn("message")
+	assert.Contains(t, logBuffer.String(), "message")
+	assert.Len(t, messages, 1)
+}
+
 func validateLog(t *testing.T, logFunc func(string, ...interface{}), logLevel, messageFormat, param string) {
 	// Replace logger with buffer to test its value
 	var logBuffer bytes.Buffer
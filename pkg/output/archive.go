@@ -4,4 +4,7 @@ package output
 // such as a gzipped tar file.
 type Archive interface {
 	Archive(name string, store Store) error
+
+	// Path returns where the archive with the given name is written
+	Path(name string) string
 }
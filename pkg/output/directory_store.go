@@ -1,15 +1,29 @@
 package output
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
-	"path"
+	"path/filepath"
+	"sync"
 )
 
+// ErrStoreCleanedUp is returned when saving to a store that has been cleaned
+// up, for example by a check that was abandoned after it timed out
+var ErrStoreCleanedUp = errors.New("output store has been cleaned up")
+
 // DirectoryStore is an output store implementation that stores outputs as files
-// in a given directory.
+// in a given directory. Outputs with nested names are stored in
+// subdirectories.
 type DirectoryStore struct {
 	directory string
+
+	// Guards cleanedUp, so that a file can't be created after the directory
+	// is removed
+	mutex     sync.Mutex
+	cleanedUp bool
 }
 
 // NewDirectoryStore instantiates a new DirectoryStore struct
@@ -21,9 +35,14 @@ func NewDirectoryStore(directory string) *DirectoryStore {
 
 // Save stores a given output to the directory as a file
 func (dirStore *DirectoryStore) Save(name string, reader io.Reader) (StoreItem, error) {
-	path := path.Join(dirStore.directory, name)
+	// Names must stay inside the store's directory
+	if !fs.ValidPath(name) || name == "." {
+		return nil, fmt.Errorf("invalid output name: %s", name)
+	}
 
-	file, err := os.Create(path)
+	path := filepath.Join(dirStore.directory, filepath.FromSlash(name))
+
+	file, err := dirStore.createFile(path)
 	if err != nil {
 		return nil, err
 	}
@@ -34,35 +53,71 @@ func (dirStore *DirectoryStore) Save(name string, reader io.Reader) (StoreItem,
 		return nil, err
 	}
 
-	return &DirectoryStoreItem{path: path}, nil
+	return &DirectoryStoreItem{path: path, name: name}, nil
 }
 
-// Items returns the collection of outputs store in this directory
-func (dirStore *DirectoryStore) Items() ([]StoreItem, error) {
-	files, err := os.ReadDir(dirStore.directory)
+// createFile creates the file for an output, and its directories, unless the
+// store has been cleaned up
+func (dirStore *DirectoryStore) createFile(path string) (*os.File, error) {
+	dirStore.mutex.Lock()
+	defer dirStore.mutex.Unlock()
+
+	if dirStore.cleanedUp {
+		return nil, ErrStoreCleanedUp
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
 	if err != nil {
 		return nil, err
 	}
 
+	return os.Create(path)
+}
+
+// Items returns the collection of outputs store in this directory, including
+// those in subdirectories
+func (dirStore *DirectoryStore) Items() ([]StoreItem, error) {
 	var items []StoreItem
-	for _, file := range files {
-		// Don't include directories in the list, it should only be a collection
-		// of flat files.
-		if file.IsDir() {
-			continue
-		}
-
-		// Resolve the absolute path
-		filePath := path.Join(dirStore.directory, file.Name())
-
-		// Add the FileStoreItem to the array
-		items = append(items, &DirectoryStoreItem{path: filePath})
+
+	err := filepath.WalkDir(
+		dirStore.directory,
+		func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			// Directories only organize the outputs, they aren't outputs
+			// themselves
+			if entry.IsDir() {
+				return nil
+			}
+
+			name, err := filepath.Rel(dirStore.directory, filePath)
+			if err != nil {
+				return err
+			}
+
+			items = append(
+				items,
+				&DirectoryStoreItem{path: filePath, name: filepath.ToSlash(name)},
+			)
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	return items, nil
 }
 
-// Cleanup removes the directory and files used for this output store
+// Cleanup removes the directory and files used for this output store. Outputs
+// can't be saved to the store afterwards.
 func (dirStore *DirectoryStore) Cleanup() error {
+	dirStore.mutex.Lock()
+	defer dirStore.mutex.Unlock()
+
+	dirStore.cleanedUp = true
+
 	return os.RemoveAll(dirStore.directory)
 }
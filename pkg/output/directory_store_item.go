@@ -8,6 +8,12 @@ import (
 // DirectoryStoreItem is a reference to a file stored in a DirectoryStore
 type DirectoryStoreItem struct {
 	path string
+	name string
+}
+
+// Name returns the path of the item relative to the store's directory
+func (item *DirectoryStoreItem) Name() string {
+	return item.name
 }
 
 // Info returns the stat results for a given item (file) in a DirectoryStore
@@ -0,0 +1,79 @@
+package output
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryStore_SaveNested(t *testing.T) {
+	dir := t.TempDir()
+	store := NewDirectoryStore(dir)
+
+	item, err := store.Save("container/container.logs/docker/container.log", strings.NewReader("log"))
+	require.NoError(t, err)
+	assert.Equal(t, "container/container.logs/docker/container.log", item.Name())
+
+	data, err := os.ReadFile(filepath.Join(dir, "container", "container.logs", "docker", "container.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "log", string(data))
+}
+
+func TestDirectoryStore_SaveInvalidName(t *testing.T) {
+	store := NewDirectoryStore(t.TempDir())
+
+	for _, name := range []string{"", ".", "../escape.txt", "/absolute.txt", "a//b.txt"} {
+		_, err := store.Save(name, strings.NewReader("data"))
+		assert.ErrorContains(t, err, "invalid output name", name)
+	}
+}
+
+func TestDirectoryStore_SaveAfterCleanup(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outputs")
+	store := NewDirectoryStore(dir)
+
+	_, err := store.Save("before.txt", strings.NewReader("before"))
+	require.NoError(t, err)
+	require.NoError(t, store.Cleanup())
+
+	// A late save doesn't recreate the removed directory
+	_, err = store.Save("late/after.txt", strings.NewReader("after"))
+	assert.ErrorIs(t, err, ErrStoreCleanedUp)
+	assert.NoDirExists(t, dir)
+}
+
+func TestDirectoryStore_Items(t *testing.T) {
+	store := NewDirectoryStore(t.TempDir())
+
+	_, err := store.Save("top.txt", strings.NewReader("top"))
+	require.NoError(t, err)
+	_, err = store.Save("host/host.os/nested.txt", strings.NewReader("nested"))
+	require.NoError(t, err)
+
+	items, err := store.Items()
+	require.NoError(t, err)
+
+	// Items in subdirectories are included, named by their path in the store
+	contents := map[string]string{}
+	for _, item := range items {
+		reader, cleanup, err := item.Open()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, cleanup())
+
+		contents[item.Name()] = string(data)
+	}
+
+	assert.Equal(
+		t,
+		map[string]string{"top.txt": "top", "host/host.os/nested.txt": "nested"},
+		contents,
+	)
+}
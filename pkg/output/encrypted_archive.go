@@ -0,0 +1,60 @@
+package output
+
+import (
+	"crypto"
+	"os"
+
+	"github.com/cyberark/conjur-inspect/pkg/encryption"
+)
+
+// EncryptedArchive wraps another archive to encrypt it to a recipient's
+// public key. The unencrypted archive is removed once it has been encrypted,
+// so only the encrypted archive is left in the output directory.
+type EncryptedArchive struct {
+	Unencrypted Archive
+	PublicKey   crypto.PublicKey
+}
+
+// Archive writes the wrapped archive and encrypts it
+func (archive *EncryptedArchive) Archive(
+	name string,
+	store Store,
+) (err error) {
+	plaintextPath := archive.Unencrypted.Path(name)
+	defer os.Remove(plaintextPath)
+
+	err = archive.Unencrypted.Archive(name, store)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := os.Open(plaintextPath)
+	if err != nil {
+		return err
+	}
+	defer plaintext.Close()
+
+	encryptedPath := archive.Path(name)
+	out, err := os.Create(encryptedPath)
+	if err != nil {
+		return err
+	}
+
+	// Don't leave a partial archive behind that can't be decrypted
+	defer func() {
+		closeErr := out.Close()
+		if err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(encryptedPath)
+		}
+	}()
+
+	return encryption.Encrypt(out, plaintext, archive.PublicKey)
+}
+
+// Path returns the path of the wrapped archive, with a .enc extension
+func (archive *EncryptedArchive) Path(name string) string {
+	return archive.Unencrypted.Path(name) + ".enc"
+}
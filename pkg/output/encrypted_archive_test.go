@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedArchive(t *testing.T) {
+	outputDir := t.TempDir()
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	store := NewDirectoryStore(t.TempDir())
+	_, err = store.Save("test.txt", strings.NewReader("test"))
+	require.NoError(t, err)
+
+	archive := &EncryptedArchive{
+		Unencrypted: &TarGzipArchive{OutputDir: outputDir},
+		PublicKey:   key.PublicKey(),
+	}
+
+	err = archive.Archive("test-archive", store)
+	require.NoError(t, err)
+
+	assert.Equal(t, outputDir+"/test-archive.tar.gz.enc", archive.Path("test-archive"))
+
+	// Only the encrypted archive is left
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "test-archive.tar.gz.enc", entries[0].Name())
+
+	encrypted, err := os.Open(archive.Path("test-archive"))
+	require.NoError(t, err)
+	defer encrypted.Close()
+
+	decryptedPath := outputDir + "/test-archive.tar.gz"
+	decrypted, err := os.Create(decryptedPath)
+	require.NoError(t, err)
+	defer decrypted.Close()
+
+	err = encryption.Decrypt(decrypted, encrypted, key)
+	require.NoError(t, err)
+
+	contents := map[string]string{}
+	err = WalkTarGzipArchive(decryptedPath, func(name string, reader io.Reader) error {
+		var buffer bytes.Buffer
+		_, err := buffer.ReadFrom(reader)
+		contents[name] = buffer.String()
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"test.txt": "test"}, contents)
+}
+
+func TestEncryptedArchiveUnsupportedKey(t *testing.T) {
+	outputDir := t.TempDir()
+
+	archive := &EncryptedArchive{
+		Unencrypted: &TarGzipArchive{OutputDir: outputDir},
+		PublicKey:   "not a key",
+	}
+
+	err := archive.Archive("test-archive", NewDirectoryStore(t.TempDir()))
+	assert.Error(t, err)
+
+	// Neither the unencrypted archive nor a partial encrypted one is left
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
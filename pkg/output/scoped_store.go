@@ -0,0 +1,73 @@
+package output
+
+import (
+	"io"
+	"path"
+	"strings"
+)
+
+// ScopedStore saves outputs to a namespace (directory) of another store, so
+// outputs from different sources can use the same names without colliding.
+type ScopedStore struct {
+	store     Store
+	namespace string
+}
+
+// NewScopedStore returns a store that saves outputs under the given
+// slash-separated namespace of the given store
+func NewScopedStore(store Store, namespace string) *ScopedStore {
+	return &ScopedStore{
+		store:     store,
+		namespace: path.Clean(namespace),
+	}
+}
+
+// Save stores a given output under the store's namespace. The returned item is
+// named relative to the namespace.
+func (scopedStore *ScopedStore) Save(name string, reader io.Reader) (StoreItem, error) {
+	item, err := scopedStore.store.Save(path.Join(scopedStore.namespace, name), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scopedStoreItem{StoreItem: item, name: name}, nil
+}
+
+// Items returns the outputs in the store's namespace, named relative to it
+func (scopedStore *ScopedStore) Items() ([]StoreItem, error) {
+	items, err := scopedStore.store.Items()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := scopedStore.namespace + "/"
+
+	var scopedItems []StoreItem
+	for _, item := range items {
+		name, found := strings.CutPrefix(item.Name(), prefix)
+		if !found {
+			continue
+		}
+
+		scopedItems = append(scopedItems, &scopedStoreItem{StoreItem: item, name: name})
+	}
+
+	return scopedItems, nil
+}
+
+// Cleanup does nothing, because the outputs belong to the underlying store,
+// which is cleaned up by its owner
+func (scopedStore *ScopedStore) Cleanup() error {
+	return nil
+}
+
+// scopedStoreItem is an item of the underlying store, named relative to the
+// namespace
+type scopedStoreItem struct {
+	StoreItem
+	name string
+}
+
+func (item *scopedStoreItem) Name() string {
+	return item.name
+}
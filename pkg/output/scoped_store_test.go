@@ -0,0 +1,60 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopedStore(t *testing.T) {
+	store := NewDirectoryStore(t.TempDir())
+	dockerStore := NewScopedStore(store, "conjur/conjur.config/docker")
+	podmanStore := NewScopedStore(store, "conjur/conjur.config/podman")
+
+	// Both scopes can use the same name
+	item, err := dockerStore.Save("conjur.yml", strings.NewReader("docker"))
+	require.NoError(t, err)
+	assert.Equal(t, "conjur.yml", item.Name())
+
+	_, err = podmanStore.Save("conjur.yml", strings.NewReader("podman"))
+	require.NoError(t, err)
+
+	storeItems, err := store.Items()
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, storeItem := range storeItems {
+		names = append(names, storeItem.Name())
+	}
+	assert.ElementsMatch(
+		t,
+		[]string{
+			"conjur/conjur.config/docker/conjur.yml",
+			"conjur/conjur.config/podman/conjur.yml",
+		},
+		names,
+	)
+
+	// A scoped store only lists its own items, named relative to its scope
+	scopedItems, err := dockerStore.Items()
+	require.NoError(t, err)
+	require.Len(t, scopedItems, 1)
+	assert.Equal(t, "conjur.yml", scopedItems[0].Name())
+}
+
+func TestScopedStore_Cleanup(t *testing.T) {
+	store := NewDirectoryStore(t.TempDir())
+	scopedStore := NewScopedStore(store, "host")
+
+	_, err := scopedStore.Save("test.txt", strings.NewReader("test"))
+	require.NoError(t, err)
+
+	// The outputs belong to the underlying store
+	require.NoError(t, scopedStore.Cleanup())
+
+	items, err := store.Items()
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/signing"
+)
+
+// SignedArchive wraps another archive to write a detached ed25519 signature
+// next to it, covering the archive's digest and the digest of its manifest
+type SignedArchive struct {
+	Unsigned   Archive
+	PrivateKey ed25519.PrivateKey
+
+	// ManifestName is the name of the manifest in the store
+	ManifestName string
+}
+
+// Archive writes the wrapped archive and its signature
+func (archive *SignedArchive) Archive(name string, store Store) error {
+	err := archive.Unsigned.Archive(name, store)
+	if err != nil {
+		return err
+	}
+
+	archivePath := archive.Path(name)
+
+	archiveDigest, err := signing.FileDigest(archivePath)
+	if err != nil {
+		return err
+	}
+
+	manifestDigest, err := archive.manifestDigest(store)
+	if err != nil {
+		return err
+	}
+
+	signature, err := signing.Sign(
+		signing.Statement{
+			Archive:        filepath.Base(archivePath),
+			ArchiveSHA256:  archiveDigest,
+			ManifestSHA256: manifestDigest,
+			SignedAt:       time.Now().UTC(),
+		},
+		archive.PrivateKey,
+	)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(archivePath+signing.Extension, signature, 0644)
+}
+
+// Path returns the path of the wrapped archive. Its signature is written to
+// the same path with a .sig extension.
+func (archive *SignedArchive) Path(name string) string {
+	return archive.Unsigned.Path(name)
+}
+
+func (archive *SignedArchive) manifestDigest(store Store) (string, error) {
+	items, err := store.Items()
+	if err != nil {
+		return "", err
+	}
+
+	for _, item := range items {
+		if item.Name() != archive.ManifestName {
+			continue
+		}
+
+		reader, cleanup, err := item.Open()
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+
+		return signing.Digest(reader)
+	}
+
+	return "", fmt.Errorf("%s not found in the output store", archive.ManifestName)
+}
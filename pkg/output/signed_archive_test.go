@@ -0,0 +1,68 @@
+package output
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedArchive(t *testing.T) {
+	outputDir := t.TempDir()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	store := NewDirectoryStore(t.TempDir())
+	_, err = store.Save("test.txt", strings.NewReader("test"))
+	require.NoError(t, err)
+	_, err = store.Save("manifest.json", strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	archive := &SignedArchive{
+		Unsigned:     &TarGzipArchive{OutputDir: outputDir},
+		PrivateKey:   privateKey,
+		ManifestName: "manifest.json",
+	}
+
+	err = archive.Archive("test-archive", store)
+	require.NoError(t, err)
+
+	archivePath := archive.Path("test-archive")
+	assert.Equal(t, outputDir+"/test-archive.tar.gz", archivePath)
+
+	signature, err := os.ReadFile(archivePath + ".sig")
+	require.NoError(t, err)
+
+	statement, err := signing.Verify(signature, publicKey)
+	require.NoError(t, err)
+
+	archiveDigest, err := signing.FileDigest(archivePath)
+	require.NoError(t, err)
+	manifestDigest, err := signing.Digest(strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-archive.tar.gz", statement.Archive)
+	assert.Equal(t, archiveDigest, statement.ArchiveSHA256)
+	assert.Equal(t, manifestDigest, statement.ManifestSHA256)
+}
+
+func TestSignedArchiveMissingManifest(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	archive := &SignedArchive{
+		Unsigned:     &TarGzipArchive{OutputDir: t.TempDir()},
+		PrivateKey:   privateKey,
+		ManifestName: "manifest.json",
+	}
+
+	err = archive.Archive("test-archive", NewDirectoryStore(t.TempDir()))
+	assert.EqualError(t, err, "manifest.json not found in the output store")
+	assert.NoFileExists(t, archive.Path("test-archive")+".sig")
+}
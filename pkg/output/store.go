@@ -6,6 +6,8 @@ import (
 )
 
 // Store represent an object that can save raw outputs, referencing them by name.
+// Names are slash-separated paths, so outputs may be organized into
+// directories, such as "container/container.logs/docker/container.log".
 type Store interface {
 	Save(name string, reader io.Reader) (StoreItem, error)
 	Items() ([]StoreItem, error)
@@ -14,6 +16,8 @@ type Store interface {
 
 // StoreItem represents a particular raw output that has been saved to a Store.
 type StoreItem interface {
+	// Name returns the path the item was saved with, relative to the store
+	Name() string
 	Info() (fs.FileInfo, error)
 	Open() (reader io.Reader, cleanup func() error, err error)
 }
@@ -0,0 +1,73 @@
+package output
+
+import "io"
+
+// SaveStream saves what produce writes to the store under the given name, as
+// it is written, so large outputs such as container logs are never held in
+// memory. The output is only saved if produce writes something, so a command
+// that fails without output doesn't leave an empty file behind. Errors from
+// producing the output are left to produce to report; the returned error is
+// from saving it.
+func SaveStream(
+	store Store,
+	name string,
+	produce func(writer io.Writer),
+) (StoreItem, error) {
+	writer := &streamWriter{store: store, name: name}
+
+	produce(writer)
+
+	return writer.close()
+}
+
+// streamWriter starts saving the output to the store on the first write, and
+// passes each write through a pipe to the store as it reads
+type streamWriter struct {
+	store Store
+	name  string
+
+	pipeWriter *io.PipeWriter
+	saved      chan saveResult
+}
+
+type saveResult struct {
+	item StoreItem
+	err  error
+}
+
+func (writer *streamWriter) Write(data []byte) (int, error) {
+	if writer.pipeWriter == nil {
+		pipeReader, pipeWriter := io.Pipe()
+
+		writer.pipeWriter = pipeWriter
+		writer.saved = make(chan saveResult, 1)
+
+		go func() {
+			item, err := writer.store.Save(writer.name, pipeReader)
+
+			// If the store stops reading early, fail any further writes rather
+			// than blocking them
+			if err != nil {
+				pipeReader.CloseWithError(err)
+			} else {
+				pipeReader.Close()
+			}
+
+			writer.saved <- saveResult{item: item, err: err}
+		}()
+	}
+
+	return writer.pipeWriter.Write(data)
+}
+
+// close ends the output and waits for the store to finish saving it
+func (writer *streamWriter) close() (StoreItem, error) {
+	if writer.pipeWriter == nil {
+		return nil, nil
+	}
+
+	writer.pipeWriter.Close()
+	result := <-writer.saved
+
+	return result.item, result.err
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveStream(t *testing.T) {
+	store := NewDirectoryStore(t.TempDir())
+
+	item, err := SaveStream(store, "logs/container.log", func(writer io.Writer) {
+		for i := 0; i < 3; i++ {
+			_, err := io.WriteString(writer, "line\n")
+			require.NoError(t, err)
+		}
+	})
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.Equal(t, "logs/container.log", item.Name())
+
+	reader, cleanup, err := item.Open()
+	require.NoError(t, err)
+	defer cleanup()
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "line\nline\nline\n", string(data))
+}
+
+func TestSaveStream_NoOutput(t *testing.T) {
+	store := NewDirectoryStore(t.TempDir())
+
+	item, err := SaveStream(store, "empty.txt", func(io.Writer) {})
+	assert.NoError(t, err)
+	assert.Nil(t, item)
+
+	// Nothing is saved for a producer that doesn't write anything
+	items, err := store.Items()
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+// failingStore fails to save any output without reading it
+type failingStore struct {
+	Store
+}
+
+func (failingStore) Save(string, io.Reader) (StoreItem, error) {
+	return nil, errors.New("disk full")
+}
+
+func TestSaveStream_SaveError(t *testing.T) {
+	var writeErr error
+
+	_, err := SaveStream(failingStore{}, "test.txt", func(writer io.Writer) {
+		_, writeErr = io.WriteString(writer, "test")
+	})
+
+	// The producer isn't blocked by a store that stopped reading
+	assert.EqualError(t, err, "disk full")
+	assert.EqualError(t, writeErr, "disk full")
+}
@@ -3,10 +3,12 @@ package output
 import (
 	"archive/tar"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"strings"
 )
 
 // TarGzipArchive archives an output store as a Gzipped Tar archive.
@@ -21,11 +23,8 @@ func (archive *TarGzipArchive) Archive(
 	store Store,
 ) error {
 
-	archiveName := fmt.Sprintf("%s.tar.gz", name)
-	archivePath := path.Join(archive.OutputDir, archiveName)
-
 	// Create output file
-	out, err := os.Create(archivePath)
+	out, err := os.Create(archive.Path(name))
 	if err != nil {
 		return err
 	}
@@ -55,6 +54,12 @@ func (archive *TarGzipArchive) Archive(
 	return nil
 }
 
+// Path returns the path of the archive with the given name in the output
+// directory
+func (archive *TarGzipArchive) Path(name string) string {
+	return path.Join(archive.OutputDir, fmt.Sprintf("%s.tar.gz", name))
+}
+
 func archiveItem(tarWriter *tar.Writer, prefix string, item StoreItem) error {
 	fileInfo, err := item.Info()
 	if err != nil {
@@ -67,8 +72,9 @@ func archiveItem(tarWriter *tar.Writer, prefix string, item StoreItem) error {
 		return err
 	}
 
-	// Add the archive prefix to the item name
-	header.Name = path.Join(prefix, fileInfo.Name())
+	// Add the archive prefix to the item name, keeping the directories of
+	// nested outputs
+	header.Name = path.Join(prefix, item.Name())
 
 	// Write the header for this tar entry
 	err = tarWriter.WriteHeader(header)
@@ -89,3 +95,51 @@ func archiveItem(tarWriter *tar.Writer, prefix string, item StoreItem) error {
 
 	return nil
 }
+
+// WalkTarGzipArchive reads a gzipped tar archive written by TarGzipArchive and
+// calls walkFn with the name and contents of each file in it. Names are
+// relative to the archive's top-level directory, so they match the names the
+// outputs were saved with.
+func WalkTarGzipArchive(
+	archivePath string,
+	walkFn func(name string, reader io.Reader) error,
+) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("unable to read %s as a gzipped archive: %w", archivePath, err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read %s as a tar archive: %w", archivePath, err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Remove the archive prefix added when the archive was written
+		name := header.Name
+		_, unprefixed, found := strings.Cut(name, "/")
+		if found {
+			name = unprefixed
+		}
+
+		err = walkFn(name, tarReader)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"strings"
 	"testing"
 
@@ -64,3 +65,55 @@ func TestTarGzipArchive_Archive(t *testing.T) {
 	err = os.Remove(testName + ".tar.gz")
 	assert.Nil(t, err)
 }
+
+func TestTarGzipArchive_Path(t *testing.T) {
+	archive := &TarGzipArchive{OutputDir: "/tmp/reports"}
+	assert.Equal(t, "/tmp/reports/standby.tar.gz", archive.Path("standby"))
+}
+
+func TestWalkTarGzipArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	store := NewDirectoryStore(dir)
+	store.Save("test1.txt", strings.NewReader("test 1"))
+	store.Save("test2.json", strings.NewReader("test 2"))
+	store.Save("host/host.os/test3.txt", strings.NewReader("test 3"))
+
+	archive := &TarGzipArchive{OutputDir: t.TempDir()}
+	err := archive.Archive("test-archive", store)
+	assert.Nil(t, err)
+
+	contents := map[string]string{}
+	err = WalkTarGzipArchive(
+		path.Join(archive.OutputDir, "test-archive.tar.gz"),
+		func(name string, reader io.Reader) error {
+			data, err := io.ReadAll(reader)
+			contents[name] = string(data)
+			return err
+		},
+	)
+	assert.Nil(t, err)
+
+	// Nested outputs keep their directories
+	assert.Equal(
+		t,
+		map[string]string{
+			"test1.txt":              "test 1",
+			"test2.json":             "test 2",
+			"host/host.os/test3.txt": "test 3",
+		},
+		contents,
+	)
+}
+
+func TestWalkTarGzipArchive_NotGzip(t *testing.T) {
+	archivePath := path.Join(t.TempDir(), "test.tar.gz")
+	err := os.WriteFile(archivePath, []byte("not an archive"), 0600)
+	assert.Nil(t, err)
+
+	err = WalkTarGzipArchive(
+		archivePath,
+		func(string, io.Reader) error { return nil },
+	)
+	assert.ErrorContains(t, err, "unable to read "+archivePath+" as a gzipped archive")
+}
@@ -0,0 +1,77 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// TruncatingReader reads up to a limit from another reader. The rest of the
+// reader is read and discarded, so a command writing to it isn't blocked, and
+// replaced with a marker that says how much was omitted.
+type TruncatingReader struct {
+	reader    io.Reader
+	limit     int64
+	remaining int64
+
+	omitted int64
+	marker  io.Reader
+}
+
+// NewTruncatingReader returns a reader that reads the first limit bytes of
+// the given reader. If limit is zero or less, the reader is read in full.
+func NewTruncatingReader(reader io.Reader, limit int64) *TruncatingReader {
+	return &TruncatingReader{
+		reader:    reader,
+		limit:     limit,
+		remaining: limit,
+	}
+}
+
+func (truncating *TruncatingReader) Read(data []byte) (int, error) {
+	if truncating.limit <= 0 {
+		return truncating.reader.Read(data)
+	}
+
+	if truncating.marker != nil {
+		return truncating.marker.Read(data)
+	}
+
+	if truncating.remaining > 0 {
+		if int64(len(data)) > truncating.remaining {
+			data = data[:truncating.remaining]
+		}
+
+		count, err := truncating.reader.Read(data)
+		truncating.remaining -= int64(count)
+		return count, err
+	}
+
+	// The limit is reached, so only the size of the rest is kept
+	omitted, err := io.Copy(io.Discard, truncating.reader)
+	if err != nil {
+		return 0, err
+	}
+	if omitted == 0 {
+		return 0, io.EOF
+	}
+
+	truncating.omitted = omitted
+	truncating.marker = strings.NewReader(
+		fmt.Sprintf(
+			"\n[TRUNCATED] conjur-inspect saved the first %s of this output and omitted the remaining %s\n",
+			humanize.IBytes(uint64(truncating.limit)),
+			humanize.IBytes(uint64(omitted)),
+		),
+	)
+
+	return truncating.marker.Read(data)
+}
+
+// Truncated returns whether any of the reader was omitted. It is only known
+// once the reader has been read to the end.
+func (truncating *TruncatingReader) Truncated() bool {
+	return truncating.omitted > 0
+}
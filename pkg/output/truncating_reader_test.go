@@ -0,0 +1,48 @@
+package output
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncatingReader(t *testing.T) {
+	reader := NewTruncatingReader(strings.NewReader(strings.Repeat("a", 2048)), 1024)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	assert.True(t, reader.Truncated())
+	assert.Equal(
+		t,
+		strings.Repeat("a", 1024)+
+			"\n[TRUNCATED] conjur-inspect saved the first 1.0 KiB of this output and omitted the remaining 1.0 KiB\n",
+		string(data),
+	)
+}
+
+func TestTruncatingReader_UnderLimit(t *testing.T) {
+	for _, input := range []string{"", "short", strings.Repeat("a", 1024)} {
+		reader := NewTruncatingReader(strings.NewReader(input), 1024)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+
+		assert.False(t, reader.Truncated())
+		assert.Equal(t, input, string(data))
+	}
+}
+
+func TestTruncatingReader_NoLimit(t *testing.T) {
+	input := strings.Repeat("a", 2048)
+	reader := NewTruncatingReader(strings.NewReader(input), 0)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	assert.False(t, reader.Truncated())
+	assert.Equal(t, input, string(data))
+}
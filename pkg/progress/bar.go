@@ -0,0 +1,72 @@
+// Package progress contains the ways of showing the progress of a report run
+package progress
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/schollz/progressbar/v3"
+)
+
+// Bar shows the progress of a report run as a progress bar, for an
+// interactive terminal
+type Bar struct {
+	writer io.Writer
+	bar    *progressbar.ProgressBar
+}
+
+// NewBar returns a progress bar that is written to the given writer
+func NewBar(writer io.Writer) *Bar {
+	return &Bar{writer: writer}
+}
+
+// RunStarted creates the progress bar, sized for the number of checks
+func (bar *Bar) RunStarted(checkCount int) {
+	bar.bar = progressbar.NewOptions(
+		checkCount,
+		progressbar.OptionSetWriter(bar.writer),
+		progressbar.OptionClearOnFinish(),
+		progressbar.OptionShowCount(),
+		progressbar.OptionSetElapsedTime(true),
+		progressbar.OptionSetPredictTime(false),
+	)
+}
+
+// CheckStarted describes the running check
+func (bar *Bar) CheckStarted(currentCheck check.Check) {
+	bar.bar.Describe(fmt.Sprintf("Checking %s...", currentCheck.Describe()))
+}
+
+// CheckFinished advances the progress bar
+func (bar *Bar) CheckFinished(check.Check, time.Duration, []check.Result) {
+	bar.bar.Add(1)
+}
+
+// ArchiveWritten isn't shown on the progress bar
+func (*Bar) ArchiveWritten(string) {}
+
+// RunFinished clears the progress bar
+func (bar *Bar) RunFinished(*report.Result) {
+	bar.bar.Finish()
+}
+
+// None doesn't show the progress of a report run
+type None struct{}
+
+// RunStarted does nothing
+func (None) RunStarted(int) {}
+
+// CheckStarted does nothing
+func (None) CheckStarted(check.Check) {}
+
+// CheckFinished does nothing
+func (None) CheckFinished(check.Check, time.Duration, []check.Result) {}
+
+// ArchiveWritten does nothing
+func (None) ArchiveWritten(string) {}
+
+// RunFinished does nothing
+func (None) RunFinished(*report.Result) {}
@@ -0,0 +1,26 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+func TestBar(t *testing.T) {
+	var buffer bytes.Buffer
+	bar := NewBar(&buffer)
+
+	bar.RunStarted(1)
+	bar.CheckStarted(&testCheck{})
+	assert.Contains(t, buffer.String(), "Checking Test...")
+
+	bar.CheckFinished(&testCheck{}, 0, nil)
+	bar.RunFinished(&report.Result{})
+
+	// The bar is cleared once the run finishes
+	assert.True(t, strings.HasSuffix(buffer.String(), "\r"))
+}
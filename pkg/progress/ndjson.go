@@ -0,0 +1,171 @@
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// Kinds of NDJSON progress event
+const (
+	EventRunStarted     = "run_started"
+	EventCheckStarted   = "check_started"
+	EventCheckFinished  = "check_finished"
+	EventArchiveWritten = "archive_written"
+	EventRunFinished    = "run_finished"
+	EventLog            = "log"
+)
+
+// Event is a line of NDJSON progress. Only the fields that apply to the kind
+// of event are set.
+type Event struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+
+	// CheckCount is the number of checks in the run, for run_started
+	CheckCount int `json:"check_count,omitempty"`
+
+	CheckID     string `json:"check_id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// DurationMS is how long the check or run took, in milliseconds
+	DurationMS *int64 `json:"duration_ms,omitempty"`
+
+	// StatusCounts is the number of results with each status, from the
+	// check for check_finished or from the report for run_finished
+	StatusCounts map[string]int `json:"status_counts,omitempty"`
+
+	// Path is the location of the raw data archive, for archive_written
+	Path string `json:"path,omitempty"`
+
+	// Interrupted and Verdict describe the result, for run_finished
+	Interrupted bool   `json:"interrupted,omitempty"`
+	Verdict     string `json:"verdict,omitempty"`
+
+	// Level and Message are a log message written during the run, for log
+	Level   string `json:"level,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// NDJSON writes the progress of a report run as newline-delimited JSON
+// events, so that other tools can follow it
+type NDJSON struct {
+	// mutex serializes events, since log messages are written from the
+	// checks' goroutines
+	mutex   sync.Mutex
+	encoder *json.Encoder
+	now     func() time.Time
+	started time.Time
+}
+
+// NewNDJSON returns progress that writes NDJSON events to the given writer
+func NewNDJSON(writer io.Writer) *NDJSON {
+	return &NDJSON{
+		encoder: json.NewEncoder(writer),
+		now:     time.Now,
+	}
+}
+
+// RunStarted writes a run_started event
+func (ndjson *NDJSON) RunStarted(checkCount int) {
+	ndjson.started = ndjson.now()
+
+	ndjson.write(Event{
+		Event:      EventRunStarted,
+		Time:       ndjson.started,
+		CheckCount: checkCount,
+	})
+}
+
+// CheckStarted writes a check_started event
+func (ndjson *NDJSON) CheckStarted(currentCheck check.Check) {
+	ndjson.write(Event{
+		Event:       EventCheckStarted,
+		Time:        ndjson.now(),
+		CheckID:     currentCheck.ID(),
+		Description: currentCheck.Describe(),
+	})
+}
+
+// CheckFinished writes a check_finished event
+func (ndjson *NDJSON) CheckFinished(
+	currentCheck check.Check,
+	duration time.Duration,
+	results []check.Result,
+) {
+	statusCounts := map[string]int{}
+	for _, result := range check.Unsuppressed(results) {
+		statusCounts[result.Status]++
+	}
+
+	ndjson.write(Event{
+		Event:        EventCheckFinished,
+		Time:         ndjson.now(),
+		CheckID:      currentCheck.ID(),
+		Description:  currentCheck.Describe(),
+		DurationMS:   durationMS(duration),
+		StatusCounts: statusCounts,
+	})
+}
+
+// ArchiveWritten writes an archive_written event
+func (ndjson *NDJSON) ArchiveWritten(path string) {
+	ndjson.write(Event{
+		Event: EventArchiveWritten,
+		Time:  ndjson.now(),
+		Path:  path,
+	})
+}
+
+// RunFinished writes a run_finished event
+func (ndjson *NDJSON) RunFinished(result *report.Result) {
+	now := ndjson.now()
+	summary := result.Summarize()
+
+	ndjson.write(Event{
+		Event:        EventRunFinished,
+		Time:         now,
+		DurationMS:   durationMS(now.Sub(ndjson.started)),
+		StatusCounts: summary.StatusCounts,
+		Interrupted:  result.Interrupted,
+		Verdict:      summary.Verdict,
+	})
+}
+
+// Log writes a log event. It may be used as the log handler, so that log
+// messages written to the same stream as the events don't corrupt it.
+func (ndjson *NDJSON) Log(level string, message string) {
+	ndjson.mutex.Lock()
+	defer ndjson.mutex.Unlock()
+
+	// The error isn't logged, since that would write another log event
+	_ = ndjson.encoder.Encode(&Event{
+		Event:   EventLog,
+		Time:    ndjson.now(),
+		Level:   level,
+		Message: message,
+	})
+}
+
+func (ndjson *NDJSON) write(event Event) {
+	ndjson.mutex.Lock()
+	err := ndjson.encoder.Encode(&event)
+	ndjson.mutex.Unlock()
+
+	// Progress is best effort, so it doesn't interrupt the report
+	if err != nil {
+		log.Debug("Failed to write progress event: %s", err)
+	}
+}
+
+// durationMS returns the duration in milliseconds. It's a pointer, so that a
+// duration of zero is still included in the event.
+func durationMS(duration time.Duration) *int64 {
+	milliseconds := duration.Milliseconds()
+	return &milliseconds
+}
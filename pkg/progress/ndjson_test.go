@@ -0,0 +1,63 @@
+package progress
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+type testCheck struct{}
+
+func (*testCheck) Describe() string { return "Test" }
+
+func (*testCheck) ID() string { return "test.check" }
+
+func (*testCheck) Run(*check.RunContext) []check.Result { return nil }
+
+func TestNDJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	ndjson := NewNDJSON(&buffer)
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ndjson.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	ndjson.RunStarted(1)
+	ndjson.CheckStarted(&testCheck{})
+	ndjson.CheckFinished(
+		&testCheck{},
+		0,
+		[]check.Result{
+			{Status: check.StatusPass},
+			{Status: check.StatusWarn},
+			{Status: check.StatusError, Suppressed: true},
+		},
+	)
+	ndjson.Log("WARN", "Received interrupt")
+	ndjson.ArchiveWritten("standby.tar.gz")
+	ndjson.RunFinished(&report.Result{
+		Interrupted: true,
+		Sections: []report.ResultSection{
+			{Results: []check.Result{{Status: check.StatusPass}}},
+		},
+	})
+
+	assert.Equal(
+		t,
+		`{"event":"run_started","time":"2026-01-02T03:04:06Z","check_count":1}
+{"event":"check_started","time":"2026-01-02T03:04:07Z","check_id":"test.check","description":"Test"}
+{"event":"check_finished","time":"2026-01-02T03:04:08Z","check_id":"test.check","description":"Test","duration_ms":0,"status_counts":{"PASS":1,"WARN":1}}
+{"event":"log","time":"2026-01-02T03:04:09Z","level":"WARN","message":"Received interrupt"}
+{"event":"archive_written","time":"2026-01-02T03:04:10Z","path":"standby.tar.gz"}
+{"event":"run_finished","time":"2026-01-02T03:04:11Z","duration_ms":5000,"status_counts":{"PASS":1},"interrupted":true,"verdict":"at risk"}
+`,
+		buffer.String(),
+	)
+}
@@ -0,0 +1,92 @@
+// Package provenance records how a check produced its raw outputs, such as
+// the commands it ran, so they can be described in the archive manifest
+package provenance
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Command is a command run by a check
+type Command struct {
+	Args       []string
+	ExitCode   int
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Recorder collects the provenance of a single check's outputs. A nil
+// Recorder ignores everything recorded, so callers don't need to check
+// whether one was provided.
+type Recorder struct {
+	mutex       sync.Mutex
+	lastCommand *Command
+	redactions  int
+}
+
+type contextKey struct{}
+
+// WithRecorder returns a context that carries the given recorder to the
+// commands run with it
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, contextKey{}, recorder)
+}
+
+// FromContext returns the recorder carried by the context, or nil if there
+// is none
+func FromContext(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+
+	recorder, _ := ctx.Value(contextKey{}).(*Recorder)
+	return recorder
+}
+
+// RecordCommand records a command that finished running
+func (recorder *Recorder) RecordCommand(command Command) {
+	if recorder == nil {
+		return
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	command.Args = slices.Clone(command.Args)
+	recorder.lastCommand = &command
+}
+
+// RecordRedactions records the number of sensitive values redacted from the
+// next output to be saved
+func (recorder *Recorder) RecordRedactions(count int) {
+	if recorder == nil {
+		return
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.redactions += count
+}
+
+// TakeOutput returns the provenance of an output being saved: the command
+// run since the last output was saved, if any, and the number of redactions
+// recorded since then
+func (recorder *Recorder) TakeOutput() (*Command, int) {
+	if recorder == nil {
+		return nil, 0
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	command := recorder.lastCommand
+	recorder.lastCommand = nil
+
+	redactions := recorder.redactions
+	recorder.redactions = 0
+
+	return command, redactions
+}
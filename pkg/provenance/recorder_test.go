@@ -0,0 +1,65 @@
+package provenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	recorder := &Recorder{}
+	ctx := WithRecorder(context.Background(), recorder)
+	assert.Same(t, recorder, FromContext(ctx))
+
+	command, redactions := recorder.TakeOutput()
+	assert.Nil(t, command)
+	assert.Zero(t, redactions)
+
+	args := []string{"docker", "logs"}
+	started := time.Now()
+	FromContext(ctx).RecordCommand(Command{Args: args, ExitCode: 1, StartedAt: started})
+	FromContext(ctx).RecordRedactions(2)
+	FromContext(ctx).RecordRedactions(1)
+
+	// The recorded command doesn't change with the caller's arguments
+	args[0] = "podman"
+
+	command, redactions = recorder.TakeOutput()
+	assert.Equal(t, &Command{Args: []string{"docker", "logs"}, ExitCode: 1, StartedAt: started}, command)
+	assert.Equal(t, 3, redactions)
+
+	// The command and redactions only apply to the next output
+	command, redactions = recorder.TakeOutput()
+	assert.Nil(t, command)
+	assert.Zero(t, redactions)
+}
+
+func TestRecorderTwoOutputsAfterOneCommand(t *testing.T) {
+	recorder := &Recorder{}
+	recorder.RecordCommand(Command{Args: []string{"docker", "inspect"}})
+	recorder.RecordRedactions(1)
+
+	command, redactions := recorder.TakeOutput()
+	assert.Equal(t, []string{"docker", "inspect"}, command.Args)
+	assert.Equal(t, 1, redactions)
+
+	// An output saved without running another command has no command
+	command, redactions = recorder.TakeOutput()
+	assert.Nil(t, command)
+	assert.Zero(t, redactions)
+}
+
+func TestRecorderNil(t *testing.T) {
+	recorder := FromContext(context.Background())
+	assert.Nil(t, recorder)
+
+	// A nil recorder ignores everything
+	recorder.RecordCommand(Command{Args: []string{"ls"}})
+	recorder.RecordRedactions(1)
+
+	command, redactions := recorder.TakeOutput()
+	assert.Nil(t, command)
+	assert.Zero(t, redactions)
+}
@@ -0,0 +1,187 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+)
+
+// Kinds of change between two reports
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Diff contains the differences between two inspection reports
+type Diff struct {
+	Old DiffSource `json:"old"`
+	New DiffSource `json:"new"`
+
+	Results    []ResultChange    `json:"results"`
+	RawOutputs []RawOutputChange `json:"raw_outputs"`
+}
+
+// DiffSource identifies one of the reports being compared
+type DiffSource struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ResultChange is a check result that was added, removed or changed between
+// two reports. Old is nil for added results and New is nil for removed results.
+type ResultChange struct {
+	Change  string        `json:"change"`
+	Section string        `json:"section"`
+	Old     *check.Result `json:"old,omitempty"`
+	New     *check.Result `json:"new,omitempty"`
+}
+
+// RawOutputChange is a raw output file that was added, removed or changed
+// between two reports
+type RawOutputChange struct {
+	Change string `json:"change"`
+	Name   string `json:"name"`
+}
+
+// sectionResult is a check result with the title of its report section
+type sectionResult struct {
+	section string
+	result  check.Result
+}
+
+// CompareResults returns the check results that were added, removed or whose
+// status or value changed between two reports. Results are matched by their
+// check ID and title. If either report predates check IDs, all results are
+// matched by section and title instead, so reports from before and after an
+// upgrade can be compared. Suppressed results are compared like any other,
+// so callers should remove them first unless they are wanted. Changed and
+// removed results are listed in the order of the old report, followed by the
+// added results in the order of the new report.
+func CompareResults(oldResult, newResult *Result) []ResultChange {
+	useCheckIDs := hasCheckIDs(oldResult) && hasCheckIDs(newResult)
+
+	oldKeys, oldResults := keyedResults(oldResult, useCheckIDs)
+	newKeys, newResults := keyedResults(newResult, useCheckIDs)
+
+	changes := []ResultChange{}
+
+	for _, key := range oldKeys {
+		old := oldResults[key]
+		current, ok := newResults[key]
+
+		switch {
+		case !ok:
+			changes = append(changes, ResultChange{
+				Change:  ChangeRemoved,
+				Section: old.section,
+				Old:     &old.result,
+			})
+		case old.result.Status != current.result.Status ||
+			old.result.Value != current.result.Value:
+			changes = append(changes, ResultChange{
+				Change:  ChangeChanged,
+				Section: current.section,
+				Old:     &old.result,
+				New:     &current.result,
+			})
+		}
+	}
+
+	for _, key := range newKeys {
+		if _, ok := oldResults[key]; ok {
+			continue
+		}
+
+		current := newResults[key]
+		changes = append(changes, ResultChange{
+			Change:  ChangeAdded,
+			Section: current.section,
+			New:     &current.result,
+		})
+	}
+
+	return changes
+}
+
+// CompareRawOutputs returns the raw output files that were added, removed or
+// changed between two reports, given a hash of the contents of each file by
+// name. The changes are sorted by file name.
+func CompareRawOutputs(oldFiles, newFiles map[string]string) []RawOutputChange {
+	names := []string{}
+	for name := range oldFiles {
+		names = append(names, name)
+	}
+	for name := range newFiles {
+		if _, ok := oldFiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	changes := []RawOutputChange{}
+	for _, name := range names {
+		oldHash, inOld := oldFiles[name]
+		newHash, inNew := newFiles[name]
+
+		switch {
+		case !inOld:
+			changes = append(changes, RawOutputChange{Change: ChangeAdded, Name: name})
+		case !inNew:
+			changes = append(changes, RawOutputChange{Change: ChangeRemoved, Name: name})
+		case oldHash != newHash:
+			changes = append(changes, RawOutputChange{Change: ChangeChanged, Name: name})
+		}
+	}
+
+	return changes
+}
+
+// hasCheckIDs returns whether the report records the check ID of its results,
+// which reports from before check IDs were introduced don't
+func hasCheckIDs(result *Result) bool {
+	for _, section := range result.Sections {
+		for _, checkResult := range section.Results {
+			if checkResult.CheckID != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// keyedResults returns the results of a report by their matching key, along
+// with the keys in report order. Results are keyed by check ID when useCheckIDs
+// is set and they have one, or by section otherwise. Checks may return several
+// results with the same title, so repeated titles are numbered by occurrence.
+func keyedResults(
+	result *Result,
+	useCheckIDs bool,
+) ([]string, map[string]sectionResult) {
+	keys := []string{}
+	results := map[string]sectionResult{}
+	occurrences := map[string]int{}
+
+	for _, section := range result.Sections {
+		for _, checkResult := range section.Results {
+			scope := checkResult.CheckID
+			if !useCheckIDs || scope == "" {
+				scope = section.Title
+			}
+
+			baseKey := fmt.Sprintf("%s\x00%s", scope, checkResult.Title)
+			key := fmt.Sprintf("%s\x00%d", baseKey, occurrences[baseKey])
+			occurrences[baseKey]++
+
+			keys = append(keys, key)
+			results[key] = sectionResult{
+				section: section.Title,
+				result:  checkResult,
+			}
+		}
+	}
+
+	return keys, results
+}
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareResults(t *testing.T) {
+	oldResult := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{CheckID: "disk.fio.iops", Title: "Read IOPs", Status: check.StatusPass, Value: "120"},
+					{CheckID: "disk.fio.iops", Title: "Write IOPs", Status: check.StatusPass, Value: "100"},
+					{CheckID: "disk.space", Title: "Disk Space (/)", Status: check.StatusPass, Value: "50%"},
+				},
+			},
+		},
+	}
+	newResult := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{CheckID: "disk.fio.iops", Title: "Read IOPs", Status: check.StatusWarn, Value: "40"},
+					{CheckID: "disk.fio.iops", Title: "Write IOPs", Status: check.StatusPass, Value: "100"},
+					{CheckID: "disk.space", Title: "Disk Space (/data)", Status: check.StatusPass, Value: "20%"},
+				},
+			},
+		},
+	}
+
+	changes := CompareResults(&oldResult, &newResult)
+
+	assert.Len(t, changes, 3)
+
+	assert.Equal(t, ChangeChanged, changes[0].Change)
+	assert.Equal(t, "Disk", changes[0].Section)
+	assert.Equal(t, check.StatusPass, changes[0].Old.Status)
+	assert.Equal(t, check.StatusWarn, changes[0].New.Status)
+
+	assert.Equal(t, ChangeRemoved, changes[1].Change)
+	assert.Equal(t, "Disk Space (/)", changes[1].Old.Title)
+	assert.Nil(t, changes[1].New)
+
+	assert.Equal(t, ChangeAdded, changes[2].Change)
+	assert.Equal(t, "Disk Space (/data)", changes[2].New.Title)
+	assert.Nil(t, changes[2].Old)
+}
+
+func TestCompareResultsRepeatedTitles(t *testing.T) {
+	// Results without check IDs are matched by section, and results with the
+	// same title are matched in order
+	oldResult := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Etcd",
+				Results: []check.Result{
+					{Title: "Etcd Performance", Status: check.StatusPass, Value: "GOOD"},
+					{Title: "Etcd Performance", Status: check.StatusPass, Value: "GOOD"},
+				},
+			},
+		},
+	}
+	newResult := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Etcd",
+				Results: []check.Result{
+					{Title: "Etcd Performance", Status: check.StatusPass, Value: "GOOD"},
+					{Title: "Etcd Performance", Status: check.StatusFail, Value: "BAD"},
+				},
+			},
+		},
+	}
+
+	changes := CompareResults(&oldResult, &newResult)
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, ChangeChanged, changes[0].Change)
+	assert.Equal(t, "BAD", changes[0].New.Value)
+}
+
+func TestCompareResultsWithoutCheckIDs(t *testing.T) {
+	// An archive from before check IDs were introduced
+	oldResult := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{Title: "Read IOPs", Status: check.StatusPass, Value: "120"},
+					{Title: "Write IOPs", Status: check.StatusPass, Value: "100"},
+				},
+			},
+		},
+	}
+	newResult := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{CheckID: "disk.fio.iops", Title: "Read IOPs", Status: check.StatusWarn, Value: "40"},
+					{CheckID: "disk.fio.iops", Title: "Write IOPs", Status: check.StatusPass, Value: "100"},
+				},
+			},
+		},
+	}
+
+	// Results are matched by section and title in either direction
+	for _, results := range [][2]*Result{
+		{&oldResult, &newResult},
+		{&newResult, &oldResult},
+	} {
+		changes := CompareResults(results[0], results[1])
+
+		require.Len(t, changes, 1)
+		assert.Equal(t, ChangeChanged, changes[0].Change)
+		assert.Equal(t, "Read IOPs", changes[0].Old.Title)
+		assert.Equal(t, "Read IOPs", changes[0].New.Title)
+	}
+}
+
+func TestCompareRawOutputs(t *testing.T) {
+	changes := CompareRawOutputs(
+		map[string]string{
+			"conjur.yml":      "a",
+			"postgresql.conf": "b",
+			"removed.log":     "c",
+		},
+		map[string]string{
+			"added.log":       "d",
+			"conjur.yml":      "changed",
+			"postgresql.conf": "b",
+		},
+	)
+
+	assert.Equal(
+		t,
+		[]RawOutputChange{
+			{Change: ChangeAdded, Name: "added.log"},
+			{Change: ChangeChanged, Name: "conjur.yml"},
+			{Change: ChangeRemoved, Name: "removed.log"},
+		},
+		changes,
+	)
+}
@@ -0,0 +1,40 @@
+package report
+
+import "time"
+
+// ManifestFileName is the name of the manifest in the raw data archive
+const ManifestFileName = "manifest.json"
+
+// Manifest describes the contents of a raw data archive and the run that
+// produced it, so each file can be traced back to the check and command that
+// wrote it
+type Manifest struct {
+	ReportID   string         `json:"report_id"`
+	Version    string         `json:"version"`
+	Hostname   string         `json:"hostname,omitempty"`
+	User       string         `json:"user,omitempty"`
+	Flags      []string       `json:"flags"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	DurationMS int64          `json:"duration_ms"`
+	Files      []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes a single file in the raw data archive. Files written
+// by the report itself, such as conjur-inspect.json, have no check ID.
+type ManifestFile struct {
+	Name       string     `json:"name"`
+	SHA256     string     `json:"sha256"`
+	Size       int64      `json:"size"`
+	CheckID    string     `json:"check_id,omitempty"`
+	Provider   string     `json:"provider,omitempty"`
+	Command    []string   `json:"command,omitempty"`
+	ExitCode   *int       `json:"exit_code,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Redactions int        `json:"redactions"`
+
+	// Truncated is set when the output was larger than the maximum output
+	// size, so only its beginning was saved
+	Truncated bool `json:"truncated,omitempty"`
+}
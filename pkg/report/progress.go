@@ -0,0 +1,24 @@
+package report
+
+import (
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+)
+
+// Progress is notified as a report runs, so that it can show the progress of
+// the run. The methods are called from a single goroutine, in order.
+type Progress interface {
+	// RunStarted is called before any checks start
+	RunStarted(checkCount int)
+
+	CheckStarted(check check.Check)
+	CheckFinished(check check.Check, duration time.Duration, results []check.Result)
+
+	// ArchiveWritten is called once the raw data archive has been written
+	ArchiveWritten(path string)
+
+	// RunFinished is called with the complete result, including suppressed
+	// results, once the run is over
+	RunFinished(result *Result)
+}
@@ -1,11 +1,17 @@
 package report
 
-import "time"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+)
 
 // Report contains an array of all sections and their reports
 type Report interface {
 	ID() string
-	Run(config RunConfig) Result
+	Run(ctx context.Context, config RunConfig) Result
 }
 
 // RunConfig contains the report run parameters
@@ -13,4 +19,53 @@ type RunConfig struct {
 	ContainerID   string
 	Since         time.Duration
 	VerboseErrors bool
+
+	// Concurrency is the maximum number of checks to run at the same time.
+	// Values less than one run the checks sequentially.
+	Concurrency int
+
+	// CheckTimeout is how long each check may run before it is canceled and
+	// reported as timed out, unless the check provides its own timeout. Zero
+	// disables check timeouts.
+	CheckTimeout time.Duration
+
+	// CheckGracePeriod is how long a check that timed out or was interrupted
+	// may take to stop before the report continues without it. Its cleanup
+	// hooks, and the checks that depend on it, wait for it until then. Zero
+	// uses a default that allows for the commands it runs to be killed.
+	CheckGracePeriod time.Duration
+
+	// Requirements are the thresholds that determine the status of the check
+	// results. If nil, the default requirements are used.
+	Requirements check.Requirements
+
+	// ArchiveCopies are renderings of the report to save in the raw data
+	// archive, alongside the JSON report
+	ArchiveCopies []ArchiveCopy
+
+	// Progress is notified as the report runs. If nil, a progress bar is
+	// written to stderr.
+	Progress Progress
+
+	// MaxOutputSize is the number of bytes of each raw output to save. Larger
+	// outputs are truncated, with a marker saying how much was omitted. Zero
+	// saves outputs in full.
+	MaxOutputSize int64
+
+	// Flags are the command line flags the report was run with, to record in
+	// the archive manifest
+	Flags []string
+}
+
+// ResultWriter renders a report result, for example as text or HTML
+type ResultWriter interface {
+	Write(io.Writer, *Result) error
+}
+
+// ArchiveCopy is a rendering of the report result, as it is displayed, to
+// save in the raw data archive
+type ArchiveCopy struct {
+	// Name is the file name in the raw data archive
+	Name   string
+	Writer ResultWriter
 }
@@ -1,10 +1,28 @@
 package report
 
-import "github.com/cyberark/conjur-inspect/pkg/check"
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+)
 
 // Result contains each sections check result
 type Result struct {
-	Version  string          `json:"version"`
+	Version string `json:"version"`
+
+	// ID is the report ID, which also names the raw data archive. Reports
+	// archived by earlier versions don't include it.
+	ID string `json:"id,omitempty"`
+
+	// Interrupted is set when the run was stopped before all of the checks
+	// finished, so the result only includes the checks that ran.
+	Interrupted bool `json:"interrupted,omitempty"`
+
+	// Summary is set by the JSON writer, so that tools reading the JSON
+	// report don't need to compute it. Use Summarize to compute it otherwise.
+	Summary *Summary `json:"summary,omitempty"`
+
 	Sections []ResultSection `json:"sections"`
 }
 
@@ -12,4 +30,113 @@ type Result struct {
 type ResultSection struct {
 	Title   string         `json:"title"`
 	Results []check.Result `json:"results"`
+
+	// RawOutputs are the names of the raw output files saved by the section's
+	// checks in the raw data archive
+	RawOutputs []string `json:"raw_outputs,omitempty"`
+}
+
+// Unsuppressed returns a copy of the result without the suppressed results
+func (result *Result) Unsuppressed() Result {
+	unsuppressed := Result{
+		Version:     result.Version,
+		ID:          result.ID,
+		Interrupted: result.Interrupted,
+		Sections:    make([]ResultSection, len(result.Sections)),
+	}
+
+	for i, section := range result.Sections {
+		unsuppressed.Sections[i] = ResultSection{
+			Title:      section.Title,
+			Results:    check.Unsuppressed(section.Results),
+			RawOutputs: section.RawOutputs,
+		}
+	}
+
+	return unsuppressed
+}
+
+// StatusCounts returns the number of results with each status. Suppressed
+// results are not counted.
+func (result *Result) StatusCounts() map[string]int {
+	counts := map[string]int{}
+	for _, section := range result.Sections {
+		for _, checkResult := range check.Unsuppressed(section.Results) {
+			counts[checkResult.Status]++
+		}
+	}
+
+	return counts
+}
+
+// WorstStatus returns the most severe status of the results, ignoring
+// suppressed results. If there are no results, it returns StatusInfo.
+func (result *Result) WorstStatus() string {
+	worst := check.StatusInfo
+	for _, section := range result.Sections {
+		for _, checkResult := range check.Unsuppressed(section.Results) {
+			if check.Severity(checkResult.Status) > check.Severity(worst) {
+				worst = checkResult.Status
+			}
+		}
+	}
+
+	return worst
+}
+
+// RecommendedAction is a remediation for one or more results in a report
+type RecommendedAction struct {
+	// Status is the most severe status of the results the action resolves
+	Status      string
+	Remediation string
+	DocURL      string
+
+	// Results identifies each result the action resolves, as
+	// "<section> - <title>"
+	Results []string
+}
+
+// RecommendedActions returns the remediations for the report's results, with
+// results that share a remediation grouped into a single action. The actions
+// are ordered from the most to least severe, and then by their first result
+// in the report. Suppressed results are included, so callers should remove
+// them first unless they are wanted.
+func (result *Result) RecommendedActions() []RecommendedAction {
+	actions := []RecommendedAction{}
+	indexes := map[[2]string]int{}
+
+	for _, section := range result.Sections {
+		for _, checkResult := range section.Results {
+			if checkResult.Remediation == "" {
+				continue
+			}
+
+			resultName := fmt.Sprintf("%s - %s", section.Title, checkResult.Title)
+
+			key := [2]string{checkResult.Remediation, checkResult.DocURL}
+			index, ok := indexes[key]
+			if !ok {
+				indexes[key] = len(actions)
+				actions = append(actions, RecommendedAction{
+					Status:      checkResult.Status,
+					Remediation: checkResult.Remediation,
+					DocURL:      checkResult.DocURL,
+					Results:     []string{resultName},
+				})
+				continue
+			}
+
+			action := &actions[index]
+			action.Results = append(action.Results, resultName)
+			if check.Severity(checkResult.Status) > check.Severity(action.Status) {
+				action.Status = checkResult.Status
+			}
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return check.Severity(actions[i].Status) > check.Severity(actions[j].Status)
+	})
+
+	return actions
 }
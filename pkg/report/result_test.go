@@ -0,0 +1,96 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultWorstStatus(t *testing.T) {
+	result := Result{
+		Sections: []ResultSection{
+			{
+				Results: []check.Result{
+					{Status: check.StatusPass},
+					{Status: check.StatusWarn},
+				},
+			},
+			{
+				Results: []check.Result{
+					{Status: check.StatusInfo},
+					// Suppressed results are ignored
+					{Status: check.StatusError, Suppressed: true},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, check.StatusWarn, result.WorstStatus())
+	assert.Equal(
+		t,
+		map[string]int{
+			check.StatusPass: 1,
+			check.StatusWarn: 1,
+			check.StatusInfo: 1,
+		},
+		result.StatusCounts(),
+	)
+
+	assert.Equal(t, check.StatusInfo, (&Result{}).WorstStatus())
+}
+
+func TestResultRecommendedActions(t *testing.T) {
+	result := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{Title: "Read IOPs", Status: check.StatusPass},
+					{
+						Title:       "Write IOPs",
+						Status:      check.StatusWarn,
+						Remediation: "Use faster storage",
+						DocURL:      "https://example.com/storage",
+					},
+					{
+						Title:       "Disk Space (/)",
+						Status:      check.StatusWarn,
+						Remediation: "Free up space",
+					},
+				},
+			},
+			{
+				Title: "Etcd",
+				Results: []check.Result{
+					{
+						Title:       "Etcd Performance",
+						Status:      check.StatusFail,
+						Remediation: "Use faster storage",
+						DocURL:      "https://example.com/storage",
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(
+		t,
+		[]RecommendedAction{
+			{
+				Status:      check.StatusFail,
+				Remediation: "Use faster storage",
+				DocURL:      "https://example.com/storage",
+				Results:     []string{"Disk - Write IOPs", "Etcd - Etcd Performance"},
+			},
+			{
+				Status:      check.StatusWarn,
+				Remediation: "Free up space",
+				Results:     []string{"Disk - Disk Space (/)"},
+			},
+		},
+		result.RecommendedActions(),
+	)
+
+	assert.Empty(t, (&Result{}).RecommendedActions())
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+)
+
+// Selection determines which checks in a report are run. Each selector is a
+// check ID (e.g. "disk.fio.iops"), a prefix of check IDs (e.g. "disk.fio"), a
+// glob matched against check IDs (e.g. "conjur.*") or a section title (e.g.
+// "Container", matched case-insensitively).
+type Selection struct {
+	// Only limits the report to checks matching any of these selectors. If
+	// empty, every check is selected.
+	Only []string
+
+	// Skip excludes checks matching any of these selectors, even if they are
+	// also matched by Only.
+	Skip []string
+}
+
+// Validate returns an error if any of the selectors is not a valid glob.
+func (selection Selection) Validate() error {
+	for _, selector := range append(selection.Only, selection.Skip...) {
+		_, err := path.Match(selector, "")
+		if err != nil {
+			return fmt.Errorf("invalid check selector '%s': %w", selector, err)
+		}
+	}
+
+	return nil
+}
+
+// Filter returns the given sections with only the selected checks. The checks
+// that selected checks depend on are kept too, even if they are skipped, since
+// the selected checks rely on what they leave in the RunContext. Sections left
+// without any checks are removed.
+func (selection Selection) Filter(sections []Section) []Section {
+	selected := make([][]bool, len(sections))
+	for sectionIndex, section := range sections {
+		selected[sectionIndex] = make([]bool, len(section.Checks))
+
+		for checkIndex, sectionCheck := range section.Checks {
+			selected[sectionIndex][checkIndex] = selection.includes(section, sectionCheck)
+		}
+	}
+
+	selectDependencies(sections, selected)
+
+	filtered := []Section{}
+
+	for sectionIndex, section := range sections {
+		checks := []check.Check{}
+
+		for checkIndex, sectionCheck := range section.Checks {
+			if selected[sectionIndex][checkIndex] {
+				checks = append(checks, sectionCheck)
+			}
+		}
+
+		if len(checks) > 0 {
+			filtered = append(filtered, Section{
+				Title:  section.Title,
+				Checks: checks,
+			})
+		}
+	}
+
+	return filtered
+}
+
+// selectDependencies marks the checks that the selected checks depend on as
+// selected, repeating until the dependencies of those checks are selected too
+func selectDependencies(sections []Section, selected [][]bool) {
+	for added := true; added; {
+		added = false
+
+		for sectionIndex, section := range sections {
+			for checkIndex, sectionCheck := range section.Checks {
+				dependent, ok := sectionCheck.(check.Dependent)
+				if !ok || !selected[sectionIndex][checkIndex] {
+					continue
+				}
+
+				for otherSectionIndex, otherSection := range sections {
+					for otherCheckIndex, otherCheck := range otherSection.Checks {
+						if selected[otherSectionIndex][otherCheckIndex] ||
+							!dependent.DependsOn(otherCheck) {
+							continue
+						}
+
+						selected[otherSectionIndex][otherCheckIndex] = true
+						added = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func (selection Selection) includes(section Section, c check.Check) bool {
+	if len(selection.Only) > 0 && !anySelectorMatches(selection.Only, section, c) {
+		return false
+	}
+
+	return !anySelectorMatches(selection.Skip, section, c)
+}
+
+func anySelectorMatches(
+	selectors []string,
+	section Section,
+	c check.Check,
+) bool {
+	for _, selector := range selectors {
+		if selectorMatches(selector, section, c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func selectorMatches(selector string, section Section, c check.Check) bool {
+	if strings.EqualFold(selector, section.Title) {
+		return true
+	}
+
+	id := c.ID()
+	if id == selector || strings.HasPrefix(id, selector+".") {
+		return true
+	}
+
+	// Invalid patterns are rejected by Validate, so the error is ignored here
+	matched, _ := path.Match(selector, id)
+	return matched
+}
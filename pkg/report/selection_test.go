@@ -0,0 +1,195 @@
+package report
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/stretchr/testify/assert"
+)
+
+type selectionTestCheck struct {
+	id string
+}
+
+func (c *selectionTestCheck) Describe() string {
+	return c.id
+}
+
+func (c *selectionTestCheck) ID() string {
+	return c.id
+}
+
+func (*selectionTestCheck) Run(*check.RunContext) []check.Result {
+	return nil
+}
+
+func selectionTestSections() []Section {
+	return []Section{
+		{
+			Title: "Disk",
+			Checks: []check.Check{
+				&selectionTestCheck{id: "disk.space"},
+				&selectionTestCheck{id: "disk.fio.iops"},
+				&selectionTestCheck{id: "disk.fio.latency"},
+			},
+		},
+		{
+			Title: "Conjur",
+			Checks: []check.Check{
+				&selectionTestCheck{id: "conjur.health.docker"},
+				&selectionTestCheck{id: "conjur.health.podman"},
+				&selectionTestCheck{id: "conjur.info.docker"},
+			},
+		},
+	}
+}
+
+func selectedIDs(sections []Section) []string {
+	ids := []string{}
+	for _, section := range sections {
+		for _, sectionCheck := range section.Checks {
+			ids = append(ids, sectionCheck.ID())
+		}
+	}
+
+	return ids
+}
+
+func TestSelectionFilter(t *testing.T) {
+	testCases := []struct {
+		name      string
+		selection Selection
+		expected  []string
+	}{
+		{
+			name:      "empty selection",
+			selection: Selection{},
+			expected: []string{
+				"disk.space",
+				"disk.fio.iops",
+				"disk.fio.latency",
+				"conjur.health.docker",
+				"conjur.health.podman",
+				"conjur.info.docker",
+			},
+		},
+		{
+			name:      "skip glob",
+			selection: Selection{Skip: []string{"disk.fio.*"}},
+			expected: []string{
+				"disk.space",
+				"conjur.health.docker",
+				"conjur.health.podman",
+				"conjur.info.docker",
+			},
+		},
+		{
+			name:      "only glob",
+			selection: Selection{Only: []string{"*.docker"}},
+			expected:  []string{"conjur.health.docker", "conjur.info.docker"},
+		},
+		{
+			name:      "only ID prefix",
+			selection: Selection{Only: []string{"conjur.health"}},
+			expected:  []string{"conjur.health.docker", "conjur.health.podman"},
+		},
+		{
+			name:      "only section title",
+			selection: Selection{Only: []string{"disk"}},
+			expected:  []string{"disk.space", "disk.fio.iops", "disk.fio.latency"},
+		},
+		{
+			name: "only and skip",
+			selection: Selection{
+				Only: []string{"Disk", "conjur.info.docker"},
+				Skip: []string{"disk.fio"},
+			},
+			expected: []string{"disk.space", "conjur.info.docker"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filtered := testCase.selection.Filter(selectionTestSections())
+			assert.Equal(t, testCase.expected, selectedIDs(filtered))
+		})
+	}
+}
+
+// selectionTestDependentCheck depends on the checks with the given IDs
+type selectionTestDependentCheck struct {
+	selectionTestCheck
+	dependsOn []string
+}
+
+func (c *selectionTestDependentCheck) DependsOn(other check.Check) bool {
+	return slices.Contains(c.dependsOn, other.ID())
+}
+
+func TestSelectionFilterKeepsDependencies(t *testing.T) {
+	sections := []Section{
+		{
+			Title: "Host",
+			Checks: []check.Check{
+				&selectionTestCheck{id: "host.os"},
+				&selectionTestCheck{id: "container.availability"},
+				&selectionTestDependentCheck{
+					selectionTestCheck: selectionTestCheck{id: "container.runtime.docker"},
+					dependsOn:          []string{"container.availability"},
+				},
+			},
+		},
+		{
+			Title: "Conjur",
+			Checks: []check.Check{
+				&selectionTestDependentCheck{
+					selectionTestCheck: selectionTestCheck{id: "conjur.health.docker"},
+					dependsOn:          []string{"container.runtime.docker"},
+				},
+			},
+		},
+	}
+
+	// Dependencies of dependencies are kept, in their own sections
+	filtered := Selection{Only: []string{"conjur.*"}}.Filter(sections)
+	assert.Equal(
+		t,
+		[]string{
+			"container.availability",
+			"container.runtime.docker",
+			"conjur.health.docker",
+		},
+		selectedIDs(filtered),
+	)
+	assert.Equal(t, "Host", filtered[0].Title)
+
+	// Dependencies are kept even when they're skipped
+	filtered = Selection{
+		Only: []string{"conjur.*"},
+		Skip: []string{"container.*"},
+	}.Filter(sections)
+	assert.Equal(
+		t,
+		[]string{
+			"container.availability",
+			"container.runtime.docker",
+			"conjur.health.docker",
+		},
+		selectedIDs(filtered),
+	)
+}
+
+func TestSelectionFilterRemovesEmptySections(t *testing.T) {
+	filtered := Selection{Only: []string{"conjur.*"}}.Filter(
+		selectionTestSections(),
+	)
+
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "Conjur", filtered[0].Title)
+}
+
+func TestSelectionValidate(t *testing.T) {
+	assert.NoError(t, Selection{Only: []string{"conjur.*"}}.Validate())
+	assert.Error(t, Selection{Skip: []string{"disk.["}}.Validate())
+}
@@ -0,0 +1,75 @@
+package report
+
+import "github.com/cyberark/conjur-inspect/pkg/check"
+
+// Overall verdicts for a report
+const (
+	// VerdictQualified means every result meets the production requirements
+	VerdictQualified = "qualified"
+
+	// VerdictAtRisk means no result is unacceptable for production, but some
+	// are at risk or couldn't be obtained
+	VerdictAtRisk = "at risk"
+
+	// VerdictNotQualified means at least one result is unacceptable for
+	// production
+	VerdictNotQualified = "not qualified"
+)
+
+// Summary is an overview of a report's results
+type Summary struct {
+	Verdict string `json:"verdict"`
+
+	// StatusCounts is the number of results with each status
+	StatusCounts map[string]int `json:"status_counts"`
+
+	// Problems are the results with a WARN status or worse, in report order
+	Problems []SummaryProblem `json:"problems"`
+}
+
+// SummaryProblem identifies a result with a WARN status or worse
+type SummaryProblem struct {
+	Section string `json:"section"`
+	CheckID string `json:"check_id"`
+	Title   string `json:"title"`
+	Value   string `json:"value"`
+	Status  string `json:"status"`
+}
+
+// Summarize returns the summary of the result. Suppressed results are not
+// included. The verdict is not qualified if any result is FAIL, and at risk
+// if any is WARN, ERROR or TIMEOUT. An interrupted report is never
+// qualified, since not all of the checks ran.
+func (result *Result) Summarize() Summary {
+	summary := Summary{
+		StatusCounts: result.StatusCounts(),
+		Problems:     []SummaryProblem{},
+	}
+
+	for _, section := range result.Sections {
+		for _, checkResult := range check.Unsuppressed(section.Results) {
+			if check.Severity(checkResult.Status) < check.Severity(check.StatusWarn) {
+				continue
+			}
+
+			summary.Problems = append(summary.Problems, SummaryProblem{
+				Section: section.Title,
+				CheckID: checkResult.CheckID,
+				Title:   checkResult.Title,
+				Value:   checkResult.Value,
+				Status:  checkResult.Status,
+			})
+		}
+	}
+
+	switch {
+	case summary.StatusCounts[check.StatusFail] > 0:
+		summary.Verdict = VerdictNotQualified
+	case len(summary.Problems) > 0, result.Interrupted:
+		summary.Verdict = VerdictAtRisk
+	default:
+		summary.Verdict = VerdictQualified
+	}
+
+	return summary
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultSummarize(t *testing.T) {
+	result := Result{
+		Sections: []ResultSection{
+			{
+				Title: "Disk",
+				Results: []check.Result{
+					{CheckID: "disk.fio.iops", Title: "Read IOPs", Value: "40", Status: check.StatusWarn},
+					{CheckID: "disk.fio.iops", Title: "Write IOPs", Value: "120", Status: check.StatusPass},
+				},
+			},
+			{
+				Title: "Host",
+				Results: []check.Result{
+					{CheckID: "host.os", Title: "OS", Value: "linux", Status: check.StatusInfo},
+					// Suppressed results are ignored
+					{Title: "Docker", Status: check.StatusError, Suppressed: true},
+				},
+			},
+		},
+	}
+
+	assert.Equal(
+		t,
+		Summary{
+			Verdict: VerdictAtRisk,
+			StatusCounts: map[string]int{
+				check.StatusWarn: 1,
+				check.StatusPass: 1,
+				check.StatusInfo: 1,
+			},
+			Problems: []SummaryProblem{
+				{
+					Section: "Disk",
+					CheckID: "disk.fio.iops",
+					Title:   "Read IOPs",
+					Value:   "40",
+					Status:  check.StatusWarn,
+				},
+			},
+		},
+		result.Summarize(),
+	)
+}
+
+func TestResultSummarizeVerdict(t *testing.T) {
+	testCases := []struct {
+		statuses    []string
+		interrupted bool
+		verdict     string
+	}{
+		{statuses: nil, verdict: VerdictQualified},
+		{statuses: []string{check.StatusPass, check.StatusInfo}, verdict: VerdictQualified},
+		{statuses: []string{check.StatusPass}, interrupted: true, verdict: VerdictAtRisk},
+		{statuses: []string{check.StatusPass, check.StatusWarn}, verdict: VerdictAtRisk},
+		{statuses: []string{check.StatusTimeout}, verdict: VerdictAtRisk},
+		{statuses: []string{check.StatusError}, verdict: VerdictAtRisk},
+		// A failure is unacceptable, even if more severe errors hide it from
+		// the worst status
+		{statuses: []string{check.StatusError, check.StatusFail}, verdict: VerdictNotQualified},
+	}
+
+	for _, testCase := range testCases {
+		results := []check.Result{}
+		for _, status := range testCase.statuses {
+			results = append(results, check.Result{Status: status})
+		}
+
+		result := Result{
+			Interrupted: testCase.interrupted,
+			Sections:    []ResultSection{{Title: "Test", Results: results}},
+		}
+
+		assert.Equal(t, testCase.verdict, result.Summarize().Verdict, testCase.statuses)
+	}
+}
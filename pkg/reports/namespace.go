@@ -0,0 +1,40 @@
+package reports
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// namespaceUnsafe matches the characters that aren't kept in output
+// directory names
+var namespaceUnsafe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// outputNamespace returns the directory a check saves its outputs to:
+// section/check-id/provider for checks that run against a container provider,
+// and section/check-id otherwise. The provider is taken from the end of the
+// check ID, e.g. "container/container.logs/docker" for "container.logs.docker".
+func outputNamespace(sectionTitle string, checkID string) string {
+	provider := checkProvider(checkID)
+	if provider == "" {
+		return path.Join(namespaceSegment(sectionTitle), namespaceSegment(checkID))
+	}
+
+	return path.Join(
+		namespaceSegment(sectionTitle),
+		namespaceSegment(strings.TrimSuffix(checkID, "."+provider)),
+		provider,
+	)
+}
+
+// namespaceSegment converts a section title or check ID to a single directory
+// name, e.g. "Conjur Enterprise" to "conjur-enterprise"
+func namespaceSegment(name string) string {
+	segment := namespaceUnsafe.ReplaceAllString(strings.ToLower(name), "-")
+	segment = strings.Trim(segment, "-.")
+	if segment == "" {
+		return "other"
+	}
+
+	return segment
+}
@@ -0,0 +1,31 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputNamespace(t *testing.T) {
+	testCases := []struct {
+		sectionTitle string
+		checkID      string
+		expected     string
+	}{
+		{"Container", "container.logs.docker", "container/container.logs/docker"},
+		{"Conjur", "conjur.config-permissions.podman", "conjur/conjur.config-permissions/podman"},
+		{"Host", "host.os", "host/host.os"},
+		{"My Plugin", "plugin.my/plugin", "my-plugin/plugin.my-plugin"},
+		{"..", "..", "other/other"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.checkID, func(t *testing.T) {
+			assert.Equal(
+				t,
+				testCase.expected,
+				outputNamespace(testCase.sectionTitle, testCase.checkID),
+			)
+		})
+	}
+}
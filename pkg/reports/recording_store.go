@@ -0,0 +1,157 @@
+package reports
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cyberark/conjur-inspect/pkg/output"
+	"github.com/cyberark/conjur-inspect/pkg/provenance"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// manifestProviders are the container providers that a check ID may end with
+var manifestProviders = []string{"docker", "podman"}
+
+// recordingStore wraps an output store to record the outputs saved through it
+// by a single check, so the report can list the raw outputs of each section
+// and describe them in the archive manifest.
+type recordingStore struct {
+	output.Store
+
+	manifest *manifestFiles
+	checkID  string
+
+	// maxSize is the number of bytes of each output to save. Zero saves the
+	// outputs in full.
+	maxSize int64
+
+	// recorder collects the commands the check runs and the values it
+	// redacts, to describe the outputs it saves
+	recorder *provenance.Recorder
+
+	mutex sync.Mutex
+	names []string
+}
+
+// Save saves the output to the wrapped store and records its name and
+// manifest entry
+func (store *recordingStore) Save(
+	name string,
+	reader io.Reader,
+) (output.StoreItem, error) {
+	hash := sha256.New()
+	counter := &byteCounter{}
+	truncatingReader := output.NewTruncatingReader(reader, store.maxSize)
+
+	item, err := store.Store.Save(
+		name,
+		io.TeeReader(truncatingReader, io.MultiWriter(hash, counter)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	file := report.ManifestFile{
+		Name:     name,
+		SHA256:   hex.EncodeToString(hash.Sum(nil)),
+		Size:     counter.count,
+		CheckID:  store.checkID,
+		Provider: checkProvider(store.checkID),
+	}
+
+	command, redactions := store.recorder.TakeOutput()
+	if command != nil {
+		exitCode := command.ExitCode
+		startedAt := command.StartedAt
+		finishedAt := command.FinishedAt
+
+		file.Command = command.Args
+		file.ExitCode = &exitCode
+		file.StartedAt = &startedAt
+		file.FinishedAt = &finishedAt
+	}
+	file.Redactions = redactions
+	file.Truncated = truncatingReader.Truncated()
+
+	store.manifest.add(file)
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if !slices.Contains(store.names, name) {
+		store.names = append(store.names, name)
+	}
+
+	return item, nil
+}
+
+// Names returns the sorted names of the outputs saved through this store
+func (store *recordingStore) Names() []string {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	names := slices.Clone(store.names)
+	slices.Sort(names)
+
+	return names
+}
+
+// manifestFiles collects the manifest entries of the outputs saved by all
+// checks, which may save them concurrently
+type manifestFiles struct {
+	mutex sync.Mutex
+	files []report.ManifestFile
+}
+
+// add records a saved output. An output saved again under the same name
+// replaces the earlier one in the store, so it replaces its entry too.
+func (manifest *manifestFiles) add(file report.ManifestFile) {
+	manifest.mutex.Lock()
+	defer manifest.mutex.Unlock()
+
+	manifest.files = slices.DeleteFunc(
+		manifest.files,
+		func(existing report.ManifestFile) bool {
+			return existing.Name == file.Name
+		},
+	)
+	manifest.files = append(manifest.files, file)
+}
+
+// Files returns the manifest entries, sorted by name
+func (manifest *manifestFiles) Files() []report.ManifestFile {
+	manifest.mutex.Lock()
+	defer manifest.mutex.Unlock()
+
+	files := slices.Clone(manifest.files)
+	slices.SortFunc(files, func(a, b report.ManifestFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return files
+}
+
+// checkProvider returns the container provider of a check, which provider
+// specific checks append to their ID
+func checkProvider(checkID string) string {
+	provider := checkID[strings.LastIndex(checkID, ".")+1:]
+	if slices.Contains(manifestProviders, provider) {
+		return provider
+	}
+
+	return ""
+}
+
+// byteCounter counts the bytes written to it
+type byteCounter struct {
+	count int64
+}
+
+func (counter *byteCounter) Write(data []byte) (int, error) {
+	counter.count += int64(len(data))
+	return len(data), nil
+}
@@ -0,0 +1,143 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// scheduledCheck is a check with its position in the report and the indexes
+// of the scheduled checks that must finish before it may start.
+type scheduledCheck struct {
+	sectionIndex int
+	check        check.Check
+	dependencies []int
+}
+
+// scheduleChecks flattens the report sections into a list of checks in report
+// order and resolves the dependencies between them. A check may only depend on
+// checks that appear before it in the report, which prevents dependency
+// cycles. Exclusive checks act as a barrier: they run after every check before
+// them and before every check after them.
+func scheduleChecks(sections []report.Section) []scheduledCheck {
+	scheduled := []scheduledCheck{}
+
+	// Index of the most recent exclusive check, which every following check
+	// must wait for
+	lastExclusive := -1
+
+	for sectionIndex, section := range sections {
+		for _, currentCheck := range section.Checks {
+			current := scheduledCheck{
+				sectionIndex: sectionIndex,
+				check:        currentCheck,
+				dependencies: []int{},
+			}
+
+			exclusive := isExclusive(currentCheck)
+			dependent, isDependent := currentCheck.(check.Dependent)
+
+			for i, previous := range scheduled {
+				switch {
+				case exclusive, i == lastExclusive:
+					current.dependencies = append(current.dependencies, i)
+				case isDependent && dependent.DependsOn(previous.check):
+					current.dependencies = append(current.dependencies, i)
+				}
+			}
+
+			if exclusive {
+				lastExclusive = len(scheduled)
+			}
+
+			scheduled = append(scheduled, current)
+		}
+	}
+
+	return scheduled
+}
+
+// runScheduled runs the scheduled checks, with at most `concurrency` checks
+// running at the same time. A check is started once all of its dependencies
+// have finished. The results are returned in the same order as the scheduled
+// checks, regardless of the order in which the checks finish. Once the context
+// is done, no more checks are started and the results of the checks that never
+// started are nil. onStart and onFinish are called from the calling goroutine.
+func runScheduled(
+	ctx context.Context,
+	scheduled []scheduledCheck,
+	concurrency int,
+	runCheck func(scheduledCheck) []check.Result,
+	onStart func(check.Check),
+	onFinish func(check.Check, []check.Result),
+) [][]check.Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([][]check.Result, len(scheduled))
+	started := make([]bool, len(scheduled))
+	finished := make([]bool, len(scheduled))
+
+	// Each check sends its index on this channel when it finishes
+	finishedChan := make(chan int)
+
+	running := 0
+	finishedCount := 0
+
+	for finishedCount < len(scheduled) {
+		// Start every check that is ready, in report order, until we reach the
+		// concurrency limit
+		for i := range scheduled {
+			if running >= concurrency || ctx.Err() != nil {
+				break
+			}
+
+			if started[i] || !dependenciesFinished(scheduled[i], finished) {
+				continue
+			}
+
+			started[i] = true
+			running++
+
+			onStart(scheduled[i].check)
+
+			go func(index int) {
+				results[index] = runCheck(scheduled[index])
+				finishedChan <- index
+			}(i) // async
+		}
+
+		// Nothing is left running only if the run was canceled, in which case
+		// the remaining checks are never started
+		if running == 0 {
+			break
+		}
+
+		// Wait for a running check to finish before scheduling more
+		index := <-finishedChan
+		finished[index] = true
+		running--
+		finishedCount++
+
+		onFinish(scheduled[index].check, results[index])
+	}
+
+	return results
+}
+
+func dependenciesFinished(scheduled scheduledCheck, finished []bool) bool {
+	for _, dependency := range scheduled.dependencies {
+		if !finished[dependency] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isExclusive(c check.Check) bool {
+	exclusive, ok := c.(check.Exclusive)
+	return ok && exclusive.Exclusive()
+}
@@ -0,0 +1,239 @@
+package reports
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/stretchr/testify/assert"
+)
+
+// schedulerTestCheck records when it runs, so tests can assert on the order
+// and overlap of check execution.
+type schedulerTestCheck struct {
+	name      string
+	exclusive bool
+	after     check.Check
+	tracker   *runTracker
+}
+
+func (c *schedulerTestCheck) Describe() string {
+	return c.name
+}
+
+func (c *schedulerTestCheck) ID() string {
+	return "test." + c.name
+}
+
+func (c *schedulerTestCheck) Exclusive() bool {
+	return c.exclusive
+}
+
+func (c *schedulerTestCheck) DependsOn(other check.Check) bool {
+	return c.after != nil && other == c.after
+}
+
+func (c *schedulerTestCheck) Run(*check.RunContext) []check.Result {
+	c.tracker.start(c.name)
+	time.Sleep(10 * time.Millisecond)
+	c.tracker.finish(c.name)
+
+	return []check.Result{{Title: c.name}}
+}
+
+type runTracker struct {
+	mutex sync.Mutex
+
+	running    map[string]bool
+	maxRunning int
+
+	// overlaps records the names of the checks running when each check started
+	overlaps map[string][]string
+	finished []string
+}
+
+func newRunTracker() *runTracker {
+	return &runTracker{
+		running:  map[string]bool{},
+		overlaps: map[string][]string{},
+	}
+}
+
+func (tracker *runTracker) start(name string) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	for other := range tracker.running {
+		tracker.overlaps[name] = append(tracker.overlaps[name], other)
+		tracker.overlaps[other] = append(tracker.overlaps[other], name)
+	}
+
+	tracker.running[name] = true
+	if len(tracker.running) > tracker.maxRunning {
+		tracker.maxRunning = len(tracker.running)
+	}
+}
+
+func (tracker *runTracker) finish(name string) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	delete(tracker.running, name)
+	tracker.finished = append(tracker.finished, name)
+}
+
+func TestScheduleChecks(t *testing.T) {
+	tracker := newRunTracker()
+	first := &schedulerTestCheck{name: "first", tracker: tracker}
+	second := &schedulerTestCheck{name: "second", tracker: tracker, after: first}
+	exclusive := &schedulerTestCheck{name: "exclusive", tracker: tracker, exclusive: true}
+	last := &schedulerTestCheck{name: "last", tracker: tracker}
+
+	scheduled := scheduleChecks([]report.Section{
+		{Title: "A", Checks: []check.Check{first, second}},
+		{Title: "B", Checks: []check.Check{exclusive, last}},
+	})
+
+	assert.Len(t, scheduled, 4)
+
+	assert.Equal(t, 0, scheduled[0].sectionIndex)
+	assert.Empty(t, scheduled[0].dependencies)
+
+	assert.Equal(t, 0, scheduled[1].sectionIndex)
+	assert.Equal(t, []int{0}, scheduled[1].dependencies)
+
+	// Exclusive checks wait for every check before them...
+	assert.Equal(t, 1, scheduled[2].sectionIndex)
+	assert.Equal(t, []int{0, 1}, scheduled[2].dependencies)
+
+	// ...and every check after them waits for the exclusive check
+	assert.Equal(t, 1, scheduled[3].sectionIndex)
+	assert.Equal(t, []int{2}, scheduled[3].dependencies)
+}
+
+func TestRunScheduledPreservesOrder(t *testing.T) {
+	tracker := newRunTracker()
+	checks := []check.Check{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		checks = append(checks, &schedulerTestCheck{name: name, tracker: tracker})
+	}
+
+	results := runScheduled(
+		context.Background(),
+		scheduleChecks([]report.Section{{Title: "Test", Checks: checks}}),
+		3,
+		func(s scheduledCheck) []check.Result { return s.check.Run(nil) },
+		func(check.Check) {},
+		func(check.Check, []check.Result) {},
+	)
+
+	assert.Len(t, results, 5)
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		assert.Equal(t, name, results[i][0].Title)
+	}
+
+	// Independent checks run concurrently, up to the concurrency limit
+	assert.Equal(t, 3, tracker.maxRunning)
+}
+
+func TestRunScheduledSequential(t *testing.T) {
+	tracker := newRunTracker()
+	checks := []check.Check{
+		&schedulerTestCheck{name: "a", tracker: tracker},
+		&schedulerTestCheck{name: "b", tracker: tracker},
+	}
+
+	startCount := 0
+	finishCount := 0
+	runScheduled(
+		context.Background(),
+		scheduleChecks([]report.Section{{Title: "Test", Checks: checks}}),
+		0,
+		func(s scheduledCheck) []check.Result { return s.check.Run(nil) },
+		func(check.Check) { startCount++ },
+		func(check.Check, []check.Result) { finishCount++ },
+	)
+
+	assert.Equal(t, 1, tracker.maxRunning)
+	assert.Equal(t, []string{"a", "b"}, tracker.finished)
+	assert.Equal(t, 2, startCount)
+	assert.Equal(t, 2, finishCount)
+}
+
+func TestRunScheduledDependencies(t *testing.T) {
+	tracker := newRunTracker()
+	first := &schedulerTestCheck{name: "first", tracker: tracker}
+	second := &schedulerTestCheck{name: "second", tracker: tracker, after: first}
+	independent := &schedulerTestCheck{name: "independent", tracker: tracker}
+
+	runScheduled(
+		context.Background(),
+		scheduleChecks([]report.Section{
+			{Title: "Test", Checks: []check.Check{first, second, independent}},
+		}),
+		3,
+		func(s scheduledCheck) []check.Result { return s.check.Run(nil) },
+		func(check.Check) {},
+		func(check.Check, []check.Result) {},
+	)
+
+	// The dependent check never overlaps with its dependency
+	assert.NotContains(t, tracker.overlaps["second"], "first")
+	assert.Contains(t, tracker.overlaps["first"], "independent")
+}
+
+func TestRunScheduledCanceled(t *testing.T) {
+	tracker := newRunTracker()
+	checks := []check.Check{
+		&schedulerTestCheck{name: "a", tracker: tracker},
+		&schedulerTestCheck{name: "b", tracker: tracker},
+		&schedulerTestCheck{name: "c", tracker: tracker},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := runScheduled(
+		ctx,
+		scheduleChecks([]report.Section{{Title: "Test", Checks: checks}}),
+		1,
+		func(s scheduledCheck) []check.Result { return s.check.Run(nil) },
+		// Cancel the run once the first check has started
+		func(check.Check) { cancel() },
+		func(check.Check, []check.Result) {},
+	)
+
+	// The running check finishes, but no more checks are started
+	assert.Equal(t, []string{"a"}, tracker.finished)
+	assert.Len(t, results, 3)
+	assert.NotNil(t, results[0])
+	assert.Nil(t, results[1])
+	assert.Nil(t, results[2])
+}
+
+func TestRunScheduledExclusive(t *testing.T) {
+	tracker := newRunTracker()
+	checks := []check.Check{
+		&schedulerTestCheck{name: "a", tracker: tracker},
+		&schedulerTestCheck{name: "b", tracker: tracker},
+		&schedulerTestCheck{name: "exclusive", tracker: tracker, exclusive: true},
+		&schedulerTestCheck{name: "c", tracker: tracker},
+		&schedulerTestCheck{name: "d", tracker: tracker},
+	}
+
+	runScheduled(
+		context.Background(),
+		scheduleChecks([]report.Section{{Title: "Test", Checks: checks}}),
+		4,
+		func(s scheduledCheck) []check.Result { return s.check.Run(nil) },
+		func(check.Check) {},
+		func(check.Check, []check.Result) {},
+	)
+
+	// The exclusive check runs alone
+	assert.Empty(t, tracker.overlaps["exclusive"])
+	assert.Equal(t, "exclusive", tracker.finished[2])
+}
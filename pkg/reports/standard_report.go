@@ -3,19 +3,33 @@ package reports
 
 import (
 	"bytes"
-	"fmt"
-	"io"
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
+	"os/user"
+	"slices"
+	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/formatting"
 	"github.com/cyberark/conjur-inspect/pkg/log"
 	"github.com/cyberark/conjur-inspect/pkg/output"
+	"github.com/cyberark/conjur-inspect/pkg/progress"
+	"github.com/cyberark/conjur-inspect/pkg/provenance"
 	"github.com/cyberark/conjur-inspect/pkg/report"
+	"github.com/cyberark/conjur-inspect/pkg/shell"
 	"github.com/cyberark/conjur-inspect/pkg/version"
-	"github.com/schollz/progressbar/v3"
 )
 
+// cleanupTimeout bounds how long the cleanup hooks of each check may run
+const cleanupTimeout = 30 * time.Second
+
+// defaultCheckGracePeriod bounds how long we wait for a canceled check to
+// stop. It allows for the commands the check runs to be killed and their
+// output to close, and a little longer for the check to return.
+const defaultCheckGracePeriod = shell.WaitDelay + 5*time.Second
+
 // StandardReport is a report that runs a series of checks and reports the
 // results.
 type StandardReport struct {
@@ -46,97 +60,165 @@ func (sr *StandardReport) ID() string {
 	return sr.id
 }
 
-// Run starts each check and returns a report of the results
-func (sr *StandardReport) Run(config report.RunConfig) report.Result {
+// Run starts each check and returns a report of the results. Each check is
+// canceled if it runs longer than its timeout. If the given context is done
+// (e.g. the user pressed Ctrl-C), the running checks are canceled, no more
+// checks are started, and the results collected so far are archived and
+// returned, marked as interrupted.
+func (sr *StandardReport) Run(
+	ctx context.Context,
+	config report.RunConfig,
+) report.Result {
 	defer sr.outputStore.Cleanup()
 
-	result := report.Result{
-		Version:  version.FullVersionName,
-		Sections: make([]report.ResultSection, len(sr.sections)),
-	}
+	startedAt := time.Now()
 
-	// archiveResult stores all results for archiving, including suppressed errors
+	// archiveResult stores all results for archiving, including suppressed
+	// errors. The displayed result is derived from it after the run, so each
+	// check only runs once.
 	archiveResult := report.Result{
 		Version:  version.FullVersionName,
+		ID:       sr.id,
 		Sections: make([]report.ResultSection, len(sr.sections)),
 	}
 
-	// Initialize the progress indicator
-	progress := newProgress(sr.checkCount(), os.Stderr)
+	// Show a progress bar unless the caller wants another way of following the
+	// run
+	runProgress := config.Progress
+	if runProgress == nil {
+		runProgress = progress.NewBar(os.Stderr)
+	}
+	runProgress.RunStarted(sr.checkCount())
 
-	// Initialize the container runtime availability cache for the entire report run
-	containerRuntimeAvailability := make(map[string]check.RuntimeAvailability)
+	gracePeriod := config.CheckGracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultCheckGracePeriod
+	}
 
-	for i, section := range sr.sections {
+	// When each running check started, to report how long it took
+	checkStarts := map[check.Check]time.Time{}
 
-		sectionResults := []check.Result{}
-		archivedSectionResults := []check.Result{}
-
-		for _, currentCheck := range section.Checks {
-			// Update text in progress display
-			progress.Describe(fmt.Sprintf("Checking %s...", currentCheck.Describe()))
-
-			// Create a channel to receive the results of the check
-			resultsChan := make(chan []check.Result)
-
-			// Run the check asynchronously so the main thread isn't blocked. This
-			// allows the progress indicator to continue working as expected
-			go func() {
-				resultsChan <- currentCheck.Run(
-					&check.RunContext{
-						ContainerID:                  config.ContainerID,
-						Since:                        config.Since,
-						OutputStore:                  sr.outputStore,
-						ContainerRuntimeAvailability: containerRuntimeAvailability,
-						VerboseErrors:                config.VerboseErrors,
-					},
-				)
-			}() // async
-
-			// Add the results to the report section
-			checkResults := <-resultsChan
-			sectionResults = append(sectionResults, checkResults...)
-
-			// For the archive, always run with VerboseErrors=true to get all errors
-			resultsChan = make(chan []check.Result)
-			go func() {
-				resultsChan <- currentCheck.Run(
-					&check.RunContext{
-						ContainerID:                  config.ContainerID,
-						Since:                        config.Since,
-						OutputStore:                  sr.outputStore,
-						ContainerRuntimeAvailability: containerRuntimeAvailability,
-						VerboseErrors:                true,
-					},
-				)
-			}() // async
-
-			archivedCheckResults := <-resultsChan
-			archivedSectionResults = append(archivedSectionResults, archivedCheckResults...)
-
-			// Increment progress
-			progress.Add(1)
+	// Initialize the container runtime availability cache for the entire report
+	// run. Checks that read it declare a dependency on the check that writes it,
+	// so it is never written while being read.
+	containerRuntimeAvailability := make(map[string]check.RuntimeAvailability)
+
+	// Record the raw outputs saved by each check, and how they were produced,
+	// for the archive manifest
+	manifest := &manifestFiles{}
+	checkStores := map[check.Check]*recordingStore{}
+	for _, section := range sr.sections {
+		for _, sectionCheck := range section.Checks {
+			checkStores[sectionCheck] = &recordingStore{
+				Store:    sr.outputStore,
+				manifest: manifest,
+				checkID:  sectionCheck.ID(),
+				maxSize:  config.MaxOutputSize,
+				recorder: &provenance.Recorder{},
+			}
 		}
+	}
+
+	// Run the checks asynchronously so the main thread isn't blocked. This
+	// allows the progress to continue being reported as expected
+	checkResults := runScheduled(
+		ctx,
+		scheduleChecks(sr.sections),
+		config.Concurrency,
+		func(scheduled scheduledCheck) []check.Result {
+			checkStore := checkStores[scheduled.check]
+
+			// Each check saves its outputs to its own directory, so checks
+			// don't need to make their output names unique
+			checkOutputStore := output.NewScopedStore(
+				checkStore,
+				outputNamespace(
+					sr.sections[scheduled.sectionIndex].Title,
+					scheduled.check.ID(),
+				),
+			)
+
+			return runCheck(
+				provenance.WithRecorder(ctx, checkStore.recorder),
+				scheduled.check,
+				checkTimeout(scheduled.check, config.CheckTimeout),
+				gracePeriod,
+				&check.RunContext{
+					ContainerID:                  config.ContainerID,
+					Since:                        config.Since,
+					Requirements:                 config.Requirements,
+					OutputStore:                  checkOutputStore,
+					ContainerRuntimeAvailability: containerRuntimeAvailability,
+				},
+			)
+		},
+		func(currentCheck check.Check) {
+			checkStarts[currentCheck] = time.Now()
+			runProgress.CheckStarted(currentCheck)
+		},
+		func(currentCheck check.Check, results []check.Result) {
+			runProgress.CheckFinished(
+				currentCheck,
+				time.Since(checkStarts[currentCheck]),
+				results,
+			)
+		},
+	)
 
-		result.Sections[i] = report.ResultSection{
-			Title:   section.Title,
-			Results: sectionResults,
+	// Add the results to the report sections, in report order
+	checkIndex := 0
+	for i, section := range sr.sections {
+		sectionResults := []check.Result{}
+		rawOutputs := []string{}
+
+		for _, sectionCheck := range section.Checks {
+			rawOutputs = append(rawOutputs, checkStores[sectionCheck].Names()...)
+
+			// Identify the results by the check that produced them, so that
+			// checks don't need to set it on each result themselves
+			for _, result := range checkResults[checkIndex] {
+				result.CheckID = sectionCheck.ID()
+				sectionResults = append(sectionResults, result)
+			}
+			checkIndex++
 		}
 
 		archiveResult.Sections[i] = report.ResultSection{
-			Title:   section.Title,
-			Results: archivedSectionResults,
+			Title:      section.Title,
+			Results:    sectionResults,
+			RawOutputs: slices.Compact(slices.Sorted(slices.Values(rawOutputs))),
 		}
 	}
 
-	progress.Finish()
+	// Mark the result, so that it's clear from the archive alone that it is
+	// incomplete
+	archiveResult.Interrupted = ctx.Err() != nil
+
+	// The report's own files are listed in the manifest too, without a check
+	reportStore := &recordingStore{Store: sr.outputStore, manifest: manifest}
 
 	// Write the unfiltered report result to the output archive
-	err := sr.archiveReport(&archiveResult)
+	err := sr.archiveReport(reportStore, &archiveResult)
 	if err != nil {
 		log.Error("Failed to archive report: %s", err)
 	}
 
+	// Save the requested copies of the report as it is displayed
+	copyResult := displayResult(&archiveResult, config.VerboseErrors)
+	for _, archiveCopy := range config.ArchiveCopies {
+		err = sr.archiveCopy(reportStore, archiveCopy, &copyResult)
+		if err != nil {
+			log.Error("Failed to archive %s: %s", archiveCopy.Name, err)
+		}
+	}
+
+	// Describe everything saved so far, so the archive can be verified and
+	// each file traced back to the check that produced it
+	err = sr.archiveManifest(config.Flags, startedAt, manifest.Files())
+	if err != nil {
+		log.Error("Failed to archive manifest: %s", err)
+	}
+
 	// Archive the raw outputs
 	err = sr.outputArchive.Archive(
 		sr.ID(),
@@ -144,12 +226,133 @@ func (sr *StandardReport) Run(config report.RunConfig) report.Result {
 	)
 	if err != nil {
 		log.Error("Failed to save raw output: %s", err)
+	} else {
+		runProgress.ArchiveWritten(sr.outputArchive.Path(sr.ID()))
+	}
+
+	runProgress.RunFinished(&archiveResult)
+
+	return displayResult(&archiveResult, config.VerboseErrors)
+}
+
+// runCheck runs a single check with the given timeout, which is disabled if it
+// is zero. If the check does not finish in time, or the report is interrupted,
+// it is reported as such rather than blocking the rest of the report. Checks
+// are expected to stop on their own once their context is done, so we wait up
+// to the grace period for the check to return before running its cleanup
+// hooks, and before the checks that depend on it may start. A check that
+// ignores its context for longer is left to finish in the background.
+func runCheck(
+	ctx context.Context,
+	currentCheck check.Check,
+	timeout time.Duration,
+	gracePeriod time.Duration,
+	runContext *check.RunContext,
+) []check.Result {
+	checkCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	runContext.Context = checkCtx
+	runContext.Cleanups = &check.Cleanups{}
+	defer runCleanups(ctx, runContext.Cleanups)
+
+	// Buffered so the check can still deliver its results, and exit, after we
+	// stop waiting for them
+	resultsChan := make(chan []check.Result, 1)
+	go func() {
+		resultsChan <- currentCheck.Run(runContext)
+	}() // async
+
+	select {
+	case results := <-resultsChan:
+		// A check that stops because it was canceled usually reports the
+		// killed command as an error, so we report why it was canceled instead
+		if checkCtx.Err() == nil {
+			return results
+		}
+	case <-checkCtx.Done():
+		waitForCanceledCheck(currentCheck, resultsChan, gracePeriod)
+	}
+
+	if errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+		return check.TimeoutResult(currentCheck, timeout)
+	}
+
+	return check.ErrorResult(
+		currentCheck,
+		errors.New("interrupted before the check finished"),
+	)
+}
+
+// waitForCanceledCheck waits up to the grace period for a canceled check to
+// return, discarding its results
+func waitForCanceledCheck(
+	currentCheck check.Check,
+	resultsChan <-chan []check.Result,
+	gracePeriod time.Duration,
+) {
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-resultsChan:
+	case <-timer.C:
+		log.Warn(
+			"%s is still running %s after it was canceled, continuing without it",
+			currentCheck.Describe(),
+			gracePeriod,
+		)
+	}
+}
+
+// runCleanups runs a check's cleanup hooks. They run even if the report was
+// interrupted, but may not delay it indefinitely.
+func runCleanups(ctx context.Context, cleanups *check.Cleanups) {
+	cleanupCtx, cancel := context.WithTimeout(
+		context.WithoutCancel(ctx),
+		cleanupTimeout,
+	)
+	defer cancel()
+
+	cleanups.Run(cleanupCtx)
+}
+
+// checkTimeout returns how long the given check may run. Checks may override
+// the default timeout, unless timeouts are disabled.
+func checkTimeout(currentCheck check.Check, defaultTimeout time.Duration) time.Duration {
+	if defaultTimeout <= 0 {
+		return 0
 	}
 
-	return result
+	timeoutCheck, ok := currentCheck.(check.Timeout)
+	if ok && timeoutCheck.Timeout() > 0 {
+		return timeoutCheck.Timeout()
+	}
+
+	return defaultTimeout
 }
 
-func (sr *StandardReport) archiveReport(result *report.Result) error {
+// displayResult returns the report result to display to the user. Unless
+// verbose errors are requested, suppressed results are filtered out.
+func displayResult(
+	archiveResult *report.Result,
+	verboseErrors bool,
+) report.Result {
+	if verboseErrors {
+		return *archiveResult
+	}
+
+	return archiveResult.Unsuppressed()
+}
+
+func (sr *StandardReport) archiveReport(
+	store output.Store,
+	result *report.Result,
+) error {
 	var buffer bytes.Buffer
 
 	// Always use JSON for the archived report
@@ -162,7 +365,7 @@ func (sr *StandardReport) archiveReport(result *report.Result) error {
 	}
 
 	// Save the report to the output store
-	_, err = sr.outputStore.Save("conjur-inspect.json", &buffer)
+	_, err = store.Save("conjur-inspect.json", &buffer)
 	if err != nil {
 		return err
 	}
@@ -170,6 +373,80 @@ func (sr *StandardReport) archiveReport(result *report.Result) error {
 	return nil
 }
 
+// archiveCopy renders a copy of the report, such as HTML that can be opened
+// directly from the extracted archive, and saves it to the output store
+func (sr *StandardReport) archiveCopy(
+	store output.Store,
+	archiveCopy report.ArchiveCopy,
+	result *report.Result,
+) error {
+	var buffer bytes.Buffer
+
+	err := archiveCopy.Writer.Write(&buffer, result)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.Save(archiveCopy.Name, &buffer)
+	return err
+}
+
+// archiveManifest saves the manifest of the files in the output store, and
+// of the run that produced them
+func (sr *StandardReport) archiveManifest(
+	flags []string,
+	startedAt time.Time,
+	files []report.ManifestFile,
+) error {
+	finishedAt := time.Now()
+
+	if flags == nil {
+		flags = []string{}
+	}
+
+	// The hostname and user are only for reference, so the manifest is still
+	// written without them
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Warn("Unable to determine hostname for manifest: %s", err)
+	}
+
+	manifest := report.Manifest{
+		ReportID:   sr.id,
+		Version:    version.FullVersionName,
+		Hostname:   hostname,
+		User:       currentUser(),
+		Flags:      flags,
+		StartedAt:  startedAt.UTC(),
+		FinishedAt: finishedAt.UTC(),
+		DurationMS: finishedAt.Sub(startedAt).Milliseconds(),
+		Files:      files,
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = sr.outputStore.Save(
+		report.ManifestFileName,
+		bytes.NewReader(append(manifestJSON, '\n')),
+	)
+	return err
+}
+
+// currentUser returns the name of the user running the report, falling back
+// to the environment when the user can't be looked up (e.g. in a container
+// running as a user that isn't in /etc/passwd)
+func currentUser() string {
+	currentUser, err := user.Current()
+	if err == nil {
+		return currentUser.Username
+	}
+
+	return os.Getenv("USER")
+}
+
 func (sr *StandardReport) checkCount() int {
 	count := 0
 	for _, section := range sr.sections {
@@ -177,14 +454,3 @@ func (sr *StandardReport) checkCount() int {
 	}
 	return count
 }
-
-func newProgress(checkCount int, writer io.Writer) *progressbar.ProgressBar {
-	return progressbar.NewOptions(
-		checkCount,
-		progressbar.OptionSetWriter(writer),
-		progressbar.OptionClearOnFinish(),
-		progressbar.OptionShowCount(),
-		progressbar.OptionSetElapsedTime(true),
-		progressbar.OptionSetPredictTime(false),
-	)
-}
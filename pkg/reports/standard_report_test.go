@@ -1,16 +1,24 @@
 package reports_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/check"
 	"github.com/cyberark/conjur-inspect/pkg/formatting"
+	"github.com/cyberark/conjur-inspect/pkg/output"
+	"github.com/cyberark/conjur-inspect/pkg/provenance"
 	"github.com/cyberark/conjur-inspect/pkg/report"
 	"github.com/cyberark/conjur-inspect/pkg/reports"
 	"github.com/cyberark/conjur-inspect/pkg/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type TestCheck struct{}
@@ -19,6 +27,10 @@ func (*TestCheck) Describe() string {
 	return "Test"
 }
 
+func (*TestCheck) ID() string {
+	return "test"
+}
+
 func (*TestCheck) Run(*check.RunContext) []check.Result {
 	return []check.Result{
 		{
@@ -30,10 +42,221 @@ func (*TestCheck) Run(*check.RunContext) []check.Result {
 	}
 }
 
+// SuppressedCheck returns one regular result and one suppressed result, and
+// counts how many times it is run.
+type SuppressedCheck struct {
+	runCount int
+}
+
+func (*SuppressedCheck) Describe() string {
+	return "Suppressed"
+}
+
+func (*SuppressedCheck) ID() string {
+	return "test.suppressed"
+}
+
+func (sc *SuppressedCheck) Run(*check.RunContext) []check.Result {
+	sc.runCount++
+
+	return []check.Result{
+		{
+			Title:  "Visible",
+			Status: check.StatusInfo,
+			Value:  "Visible Value",
+		},
+		{
+			Title:      "Hidden",
+			Status:     check.StatusError,
+			Value:      "N/A",
+			Message:    "Hidden Message",
+			Suppressed: true,
+		},
+	}
+}
+
+// HangingCheck ignores its context and blocks until released, like a command
+// that never returns. If timeout is set, it overrides the report's default
+// check timeout.
+type HangingCheck struct {
+	release chan struct{}
+	timeout time.Duration
+}
+
+func (*HangingCheck) Describe() string {
+	return "Hanging"
+}
+
+func (*HangingCheck) ID() string {
+	return "test.hanging"
+}
+
+func (hc *HangingCheck) Timeout() time.Duration {
+	return hc.timeout
+}
+
+func (hc *HangingCheck) Run(*check.RunContext) []check.Result {
+	<-hc.release
+
+	return []check.Result{{Title: "Hanging", Status: check.StatusInfo}}
+}
+
+// SlowToStopCheck takes a while to stop once its context is done, like a check
+// waiting for a killed command to exit. It registers a cleanup hook that
+// records whether the check had stopped by the time the hook ran.
+type SlowToStopCheck struct {
+	stopped          atomic.Bool
+	stoppedByCleanup atomic.Bool
+}
+
+func (*SlowToStopCheck) Describe() string {
+	return "Slow to stop"
+}
+
+func (*SlowToStopCheck) ID() string {
+	return "test.slow-to-stop"
+}
+
+func (sc *SlowToStopCheck) Run(runContext *check.RunContext) []check.Result {
+	runContext.Cleanups.Add(func(context.Context) {
+		sc.stoppedByCleanup.Store(sc.stopped.Load())
+	})
+
+	<-runContext.Context.Done()
+	time.Sleep(50 * time.Millisecond)
+	sc.stopped.Store(true)
+
+	return []check.Result{{Title: "Slow to stop", Status: check.StatusError}}
+}
+
+// AfterSlowToStopCheck depends on a SlowToStopCheck, and reports whether it
+// had stopped by the time this check started
+type AfterSlowToStopCheck struct {
+	dependency *SlowToStopCheck
+}
+
+func (*AfterSlowToStopCheck) Describe() string {
+	return "After slow to stop"
+}
+
+func (*AfterSlowToStopCheck) ID() string {
+	return "test.after-slow-to-stop"
+}
+
+func (ac *AfterSlowToStopCheck) DependsOn(other check.Check) bool {
+	return other == ac.dependency
+}
+
+func (ac *AfterSlowToStopCheck) Run(*check.RunContext) []check.Result {
+	return []check.Result{
+		{
+			Title: "After slow to stop",
+			Value: fmt.Sprintf("%t", ac.dependency.stopped.Load()),
+		},
+	}
+}
+
+// DeadlineCheck reports whether its context has a deadline
+type DeadlineCheck struct{}
+
+func (*DeadlineCheck) Describe() string {
+	return "Deadline"
+}
+
+func (*DeadlineCheck) ID() string {
+	return "test.deadline"
+}
+
+func (*DeadlineCheck) Run(runContext *check.RunContext) []check.Result {
+	_, hasDeadline := runContext.Context.Deadline()
+
+	return []check.Result{
+		{Title: "Deadline", Value: fmt.Sprintf("%t", hasDeadline)},
+	}
+}
+
+// InterruptingCheck interrupts the report while it runs, as if the user
+// pressed Ctrl-C, and registers a cleanup hook.
+type InterruptingCheck struct {
+	interrupt func()
+	cleanedUp bool
+}
+
+func (*InterruptingCheck) Describe() string {
+	return "Interrupting"
+}
+
+func (*InterruptingCheck) ID() string {
+	return "test.interrupting"
+}
+
+func (ic *InterruptingCheck) Run(runContext *check.RunContext) []check.Result {
+	runContext.Cleanups.Add(func(context.Context) {
+		ic.cleanedUp = true
+	})
+
+	ic.interrupt()
+	<-runContext.Context.Done()
+
+	return []check.Result{{Title: "Interrupting", Status: check.StatusError}}
+}
+
+// OutputCheck saves a raw output with the given name
+type OutputCheck struct {
+	name string
+}
+
+func (*OutputCheck) Describe() string {
+	return "Output"
+}
+
+func (*OutputCheck) ID() string {
+	return "test.output"
+}
+
+func (oc *OutputCheck) Run(runContext *check.RunContext) []check.Result {
+	_, err := runContext.OutputStore.Save(oc.name, strings.NewReader("output"))
+	if err != nil {
+		return check.ErrorResult(oc, err)
+	}
+
+	return []check.Result{{Title: "Output", Status: check.StatusInfo}}
+}
+
+// ProvenanceCheck runs a command, redacts a value from its output and saves it,
+// recording each step like the real checks do
+type ProvenanceCheck struct{}
+
+func (*ProvenanceCheck) Describe() string {
+	return "Provenance"
+}
+
+func (*ProvenanceCheck) ID() string {
+	return "test.provenance.docker"
+}
+
+func (pc *ProvenanceCheck) Run(runContext *check.RunContext) []check.Result {
+	recorder := provenance.FromContext(runContext.Context)
+	recorder.RecordCommand(provenance.Command{
+		Args:       []string{"docker", "inspect", "test"},
+		ExitCode:   0,
+		StartedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		FinishedAt: time.Date(2026, 1, 2, 3, 4, 6, 0, time.UTC),
+	})
+	recorder.RecordRedactions(2)
+
+	_, err := runContext.OutputStore.Save("inspect.json", strings.NewReader("output"))
+	if err != nil {
+		return check.ErrorResult(pc, err)
+	}
+
+	return []check.Result{{Title: "Provenance", Status: check.StatusInfo}}
+}
+
 func TestReport(t *testing.T) {
 	testReport, outputStore, outputArchive := newTestReport()
 
-	testReportResult := testReport.Run(report.RunConfig{
+	testReportResult := testReport.Run(context.Background(), report.RunConfig{
 		ContainerID: "",
 	})
 
@@ -43,12 +266,10 @@ func TestReport(t *testing.T) {
 	// Assert that the output store contains the report JSON
 	outputStoreItems, err := outputStore.Items()
 	assert.NoError(t, err)
-	assert.Len(t, outputStoreItems, 1)
+	assert.Len(t, outputStoreItems, 2)
 
-	outputStoreItem := outputStoreItems[0]
-	outputStoreItemInfo, err := outputStoreItem.Info()
-	assert.NoError(t, err)
-	assert.Equal(t, "conjur-inspect.json", outputStoreItemInfo.Name())
+	findOutputStoreItem(t, outputStore, "conjur-inspect.json")
+	findOutputStoreItem(t, outputStore, report.ManifestFileName)
 
 	// Assert that the output store was archived
 	assert.True(t, outputArchive.IsArchived())
@@ -62,6 +283,7 @@ func TestReport(t *testing.T) {
 	assert.Equal(
 		t,
 		check.Result{
+			CheckID: "test",
 			Title:   "Test Check",
 			Status:  "Test Status",
 			Value:   "Test Value",
@@ -88,6 +310,10 @@ func TestReport(t *testing.T) {
 			"Conjur Enterprise Inspection Report\n"+
 			"Version: unset-unset (Build unset)\n"+
 			"========================================\033[0m\n\n"+
+			"\033[1mSummary\n"+
+			"-------\033[0m\n"+
+			"\033[1m\033[32mVerdict: QUALIFIED\033[0m\033[0m\n"+
+			"Results: 1 Test Status\n\n"+
 			"\033[1mTest section\n"+
 			"------------\033[0m\n"+
 			"Test Status - Test Check: Test Value (Test Message)\033[0m\n",
@@ -98,7 +324,7 @@ func TestReport(t *testing.T) {
 func TestJSONReport(t *testing.T) {
 	testReport, _, _ := newTestReport()
 
-	testReportResult := testReport.Run(report.RunConfig{
+	testReportResult := testReport.Run(context.Background(), report.RunConfig{
 		ContainerID: "",
 	})
 
@@ -112,6 +338,7 @@ func TestJSONReport(t *testing.T) {
 	assert.Equal(
 		t,
 		check.Result{
+			CheckID: "test",
 			Title:   "Test Check",
 			Status:  "Test Status",
 			Value:   "Test Value",
@@ -134,11 +361,18 @@ func TestJSONReport(t *testing.T) {
 	assert.JSONEq(t,
 		`{
             "version": "unset-unset (Build unset)",
+            "id": "test",
+            "summary": {
+                "verdict": "qualified",
+                "status_counts": {"Test Status": 1},
+                "problems": []
+            },
             "sections": [
             {
                 "title": "Test section",
                 "results": [
                 {
+                    "check_id": "test",
                     "title": "Test Check",
                     "value": "Test Value",
                     "status": "Test Status",
@@ -152,6 +386,448 @@ func TestJSONReport(t *testing.T) {
 	)
 }
 
+func TestReportSuppressedResults(t *testing.T) {
+	suppressedCheck := &SuppressedCheck{}
+	outputStore := test.NewOutputStore()
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title:  "Test section",
+				Checks: []check.Check{suppressedCheck},
+			},
+		},
+		outputStore,
+		&test.OutputArchive{},
+	)
+
+	testReportResult := testReport.Run(
+		context.Background(),
+		report.RunConfig{VerboseErrors: false},
+	)
+
+	// The check only runs once for both the displayed and archived results
+	assert.Equal(t, 1, suppressedCheck.runCount)
+
+	// Suppressed results are filtered from the displayed result
+	displayedResults := testReportResult.Sections[0].Results
+	assert.Len(t, displayedResults, 1)
+	assert.Equal(t, "Visible", displayedResults[0].Title)
+
+	// The archived report includes the suppressed results
+	reader, cleanup, err := findOutputStoreItem(
+		t,
+		outputStore,
+		"conjur-inspect.json",
+	).Open()
+	assert.NoError(t, err)
+	defer cleanup()
+
+	archivedJSON, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Contains(t, string(archivedJSON), `"title": "Hidden"`)
+	assert.Contains(t, string(archivedJSON), `"suppressed": true`)
+}
+
+func TestReportSuppressedResultsVerboseErrors(t *testing.T) {
+	suppressedCheck := &SuppressedCheck{}
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title:  "Test section",
+				Checks: []check.Check{suppressedCheck},
+			},
+		},
+		test.NewOutputStore(),
+		&test.OutputArchive{},
+	)
+
+	testReportResult := testReport.Run(
+		context.Background(),
+		report.RunConfig{VerboseErrors: true},
+	)
+
+	assert.Equal(t, 1, suppressedCheck.runCount)
+
+	// Suppressed results are displayed with verbose errors
+	displayedResults := testReportResult.Sections[0].Results
+	assert.Len(t, displayedResults, 2)
+	assert.True(t, displayedResults[1].Suppressed)
+}
+
+func TestReportCheckTimeout(t *testing.T) {
+	hangingCheck := &HangingCheck{release: make(chan struct{})}
+	defer close(hangingCheck.release)
+
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title:  "Test section",
+				Checks: []check.Check{hangingCheck, &TestCheck{}},
+			},
+		},
+		test.NewOutputStore(),
+		&test.OutputArchive{},
+	)
+
+	testReportResult := testReport.Run(
+		context.Background(),
+		report.RunConfig{
+			CheckTimeout:     50 * time.Millisecond,
+			CheckGracePeriod: 50 * time.Millisecond,
+		},
+	)
+
+	// The hanging check is reported as timed out and doesn't block the report
+	// for longer than its grace period
+	results := testReportResult.Sections[0].Results
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Hanging", results[0].Title)
+	assert.Equal(t, check.StatusTimeout, results[0].Status)
+	assert.Contains(t, results[0].Message, "50ms")
+	assert.Equal(t, "Test Check", results[1].Title)
+}
+
+func TestReportCheckTimeoutWaitsForCheckToStop(t *testing.T) {
+	slowCheck := &SlowToStopCheck{}
+	afterCheck := &AfterSlowToStopCheck{dependency: slowCheck}
+
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title:  "Test section",
+				Checks: []check.Check{slowCheck, afterCheck},
+			},
+		},
+		test.NewOutputStore(),
+		&test.OutputArchive{},
+	)
+
+	testReportResult := testReport.Run(
+		context.Background(),
+		report.RunConfig{
+			CheckTimeout:     20 * time.Millisecond,
+			CheckGracePeriod: time.Minute,
+			Concurrency:      2,
+		},
+	)
+
+	results := testReportResult.Sections[0].Results
+	require.Len(t, results, 2)
+	assert.Equal(t, check.StatusTimeout, results[0].Status)
+
+	// The cleanup hooks, and the dependent check, wait for the timed out check
+	// to stop
+	assert.True(t, slowCheck.stoppedByCleanup.Load())
+	assert.Equal(t, "true", results[1].Value)
+}
+
+func TestReportCheckTimeoutOverride(t *testing.T) {
+	hangingCheck := &HangingCheck{
+		release: make(chan struct{}),
+		timeout: 50 * time.Millisecond,
+	}
+	defer close(hangingCheck.release)
+
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title:  "Test section",
+				Checks: []check.Check{hangingCheck},
+			},
+		},
+		test.NewOutputStore(),
+		&test.OutputArchive{},
+	)
+
+	testReportResult := testReport.Run(
+		context.Background(),
+		report.RunConfig{
+			CheckTimeout:     time.Hour,
+			CheckGracePeriod: 50 * time.Millisecond,
+		},
+	)
+
+	results := testReportResult.Sections[0].Results
+	assert.Len(t, results, 1)
+	assert.Equal(t, check.StatusTimeout, results[0].Status)
+	assert.Contains(t, results[0].Message, "50ms")
+}
+
+func TestReportCheckTimeoutDisabled(t *testing.T) {
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title:  "Test section",
+				Checks: []check.Check{&DeadlineCheck{}},
+			},
+		},
+		test.NewOutputStore(),
+		&test.OutputArchive{},
+	)
+
+	withTimeout := testReport.Run(
+		context.Background(),
+		report.RunConfig{CheckTimeout: time.Minute},
+	)
+	assert.Equal(t, "true", withTimeout.Sections[0].Results[0].Value)
+
+	withoutTimeout := testReport.Run(context.Background(), report.RunConfig{})
+	assert.Equal(t, "false", withoutTimeout.Sections[0].Results[0].Value)
+}
+
+func TestReportInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interruptingCheck := &InterruptingCheck{interrupt: cancel}
+	outputStore := test.NewOutputStore()
+	outputArchive := &test.OutputArchive{}
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title:  "Test section",
+				Checks: []check.Check{interruptingCheck, &TestCheck{}},
+			},
+		},
+		outputStore,
+		outputArchive,
+	)
+
+	testReportResult := testReport.Run(ctx, report.RunConfig{})
+
+	// The running check is reported as interrupted and its cleanup hooks run
+	assert.True(t, testReportResult.Interrupted)
+	assert.True(t, interruptingCheck.cleanedUp)
+
+	results := testReportResult.Sections[0].Results
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Interrupting", results[0].Title)
+	assert.Equal(t, check.StatusError, results[0].Status)
+	assert.Contains(t, results[0].Message, "interrupted")
+
+	// The partial results are still archived, with the interrupted marker
+	assert.True(t, outputArchive.IsArchived())
+
+	reader, cleanup, err := findOutputStoreItem(
+		t,
+		outputStore,
+		"conjur-inspect.json",
+	).Open()
+	assert.NoError(t, err)
+	defer cleanup()
+
+	archivedJSON, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Contains(t, string(archivedJSON), `"interrupted": true`)
+}
+
+func TestReportRawOutputs(t *testing.T) {
+	outputStore := test.NewOutputStore()
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title: "First",
+				Checks: []check.Check{
+					&OutputCheck{name: "b.txt"},
+					&OutputCheck{name: "a.txt"},
+				},
+			},
+			{Title: "Second", Checks: []check.Check{&TestCheck{}}},
+		},
+		outputStore,
+		&test.OutputArchive{},
+	)
+
+	result := testReport.Run(context.Background(), report.RunConfig{})
+
+	// Each section lists the raw outputs saved by its checks, in the
+	// directory of the check that saved them
+	assert.Equal(
+		t,
+		[]string{"first/test.output/a.txt", "first/test.output/b.txt"},
+		result.Sections[0].RawOutputs,
+	)
+	assert.Empty(t, result.Sections[1].RawOutputs)
+}
+
+func TestReportArchiveCopies(t *testing.T) {
+	testReport, outputStore, _ := newTestReport()
+
+	testReport.Run(context.Background(), report.RunConfig{
+		ArchiveCopies: []report.ArchiveCopy{
+			{
+				Name:   "conjur-inspect.html",
+				Writer: &formatting.HTML{ShowRawOutputs: true},
+			},
+		},
+	})
+
+	outputStoreItems, err := outputStore.Items()
+	assert.NoError(t, err)
+	assert.Len(t, outputStoreItems, 3)
+
+	htmlItem := findOutputStoreItem(t, outputStore, "conjur-inspect.html")
+
+	reader, cleanup, err := htmlItem.Open()
+	require.NoError(t, err)
+	defer cleanup()
+
+	contents, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Contains(t, string(contents), "Report ID: test")
+	assert.Contains(t, string(contents), "<td>Test Check</td>")
+}
+
+func TestReportProgress(t *testing.T) {
+	testReport, _, _ := newTestReport()
+	progress := &test.Progress{}
+
+	testReport.Run(context.Background(), report.RunConfig{Progress: progress})
+
+	assert.Equal(
+		t,
+		[]string{
+			"run started: 1",
+			"check started: test",
+			"check finished: test (1 results)",
+			"archive written: test.tar.gz",
+			"run finished: interrupted=false",
+		},
+		progress.Events,
+	)
+}
+
+func TestReportManifest(t *testing.T) {
+	outputStore := test.NewOutputStore()
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{
+				Title: "Test section",
+				Checks: []check.Check{
+					&ProvenanceCheck{},
+					&OutputCheck{name: "output.txt"},
+				},
+			},
+		},
+		outputStore,
+		&test.OutputArchive{},
+	)
+
+	testReport.Run(context.Background(), report.RunConfig{
+		Flags: []string{"--container-id=test"},
+	})
+
+	manifestItem := findOutputStoreItem(t, outputStore, report.ManifestFileName)
+	reader, cleanup, err := manifestItem.Open()
+	require.NoError(t, err)
+	defer cleanup()
+
+	var manifest report.Manifest
+	require.NoError(t, json.NewDecoder(reader).Decode(&manifest))
+
+	assert.Equal(t, "test", manifest.ReportID)
+	assert.Equal(t, []string{"--container-id=test"}, manifest.Flags)
+	assert.False(t, manifest.StartedAt.IsZero())
+	assert.False(t, manifest.FinishedAt.Before(manifest.StartedAt))
+
+	// Files are listed by name, including the report's own files
+	require.Len(t, manifest.Files, 3)
+	assert.Equal(t, "conjur-inspect.json", manifest.Files[0].Name)
+	assert.Empty(t, manifest.Files[0].CheckID)
+
+	inspectFile := manifest.Files[2]
+	assert.Equal(t, "test-section/test.provenance/docker/inspect.json", inspectFile.Name)
+	assert.Equal(t, "test.provenance.docker", inspectFile.CheckID)
+	assert.Equal(t, "docker", inspectFile.Provider)
+	assert.Equal(t, []string{"docker", "inspect", "test"}, inspectFile.Command)
+	require.NotNil(t, inspectFile.ExitCode)
+	assert.Equal(t, 0, *inspectFile.ExitCode)
+	require.NotNil(t, inspectFile.StartedAt)
+	assert.Equal(t, 2026, inspectFile.StartedAt.Year())
+	assert.Equal(t, 2, inspectFile.Redactions)
+	assert.Equal(t, int64(len("output")), inspectFile.Size)
+	// sha256 of "output"
+	assert.Equal(
+		t,
+		"e0ee8bb50685e05fa0f47ed04203ae953fdfd055f5bd2892ea186504254f8c3a",
+		inspectFile.SHA256,
+	)
+
+	// Outputs saved without running a command have no command details
+	outputFile := manifest.Files[1]
+	assert.Equal(t, "test-section/test.output/output.txt", outputFile.Name)
+	assert.Equal(t, "test.output", outputFile.CheckID)
+	assert.Empty(t, outputFile.Provider)
+	assert.Nil(t, outputFile.Command)
+	assert.Nil(t, outputFile.ExitCode)
+}
+
+func TestReportMaxOutputSize(t *testing.T) {
+	outputStore := test.NewOutputStore()
+	testReport := reports.NewStandardReport(
+		"test",
+		[]report.Section{
+			{Title: "Test section", Checks: []check.Check{&OutputCheck{name: "output.txt"}}},
+		},
+		outputStore,
+		&test.OutputArchive{},
+	)
+
+	testReport.Run(context.Background(), report.RunConfig{MaxOutputSize: 3})
+
+	outputItem := findOutputStoreItem(t, outputStore, "test-section/test.output/output.txt")
+	reader, cleanup, err := outputItem.Open()
+	require.NoError(t, err)
+	defer cleanup()
+
+	contents, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(contents), "out\n[TRUNCATED]"))
+
+	// The manifest notes the truncation, while the report's own files are
+	// never truncated
+	manifestItem := findOutputStoreItem(t, outputStore, report.ManifestFileName)
+	manifestReader, manifestCleanup, err := manifestItem.Open()
+	require.NoError(t, err)
+	defer manifestCleanup()
+
+	var manifest report.Manifest
+	require.NoError(t, json.NewDecoder(manifestReader).Decode(&manifest))
+	require.Len(t, manifest.Files, 2)
+	assert.False(t, manifest.Files[0].Truncated)
+	assert.True(t, manifest.Files[1].Truncated)
+	assert.Equal(t, int64(len(contents)), manifest.Files[1].Size)
+}
+
+// findOutputStoreItem returns the item with the given name. The store doesn't
+// keep the items in order.
+func findOutputStoreItem(
+	t *testing.T,
+	outputStore *test.OutputStore,
+	name string,
+) output.StoreItem {
+	items, err := outputStore.Items()
+	require.NoError(t, err)
+
+	for _, item := range items {
+		if item.Name() == name {
+			return item
+		}
+	}
+
+	require.FailNow(t, "output not found", name)
+	return nil
+}
+
 func newTestReport() (report.Report, *test.OutputStore, *test.OutputArchive) {
 	outputStore := test.NewOutputStore()
 	outputArchive := &test.OutputArchive{}
@@ -2,13 +2,24 @@ package shell
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/log"
+	"github.com/cyberark/conjur-inspect/pkg/provenance"
 )
 
+// WaitDelay bounds how long we wait for a command's output to close after it
+// is killed because its context is done. Without it, a process that leaves
+// children holding the output streams open (e.g. `docker exec`) could still
+// block the caller indefinitely.
+const WaitDelay = 5 * time.Second
+
 // CommandWrapper represents a wrapper around an executable command
 type CommandWrapper struct {
 	name string
@@ -25,46 +36,84 @@ func NewCommandWrapper(name string, args ...string) *CommandWrapper {
 }
 
 // Run executes the command and returns its output as readers for stdout and
-// stderr, along with any error that occurred.
+// stderr, along with any error that occurred. The command is killed if the
+// given context is done before it completes.
 // It returns three values, which are based on the results of the command execution:
 // stdout io.Reader: The standard output generated by the command.
 // stderr io.Reader: The standard error generated by the command.
 // err error: An error, if one occurred while executing the command.
-func (wrapper *CommandWrapper) Run() (stdout, stderr io.Reader, err error) {
+func (wrapper *CommandWrapper) Run(
+	ctx context.Context,
+) (stdout, stderr io.Reader, err error) {
+	return wrapper.RunWithInput(ctx, nil)
+}
+
+// RunWithInput executes the command like Run, with the given reader as its
+// standard input. If stdin is nil, the command reads from the null device.
+func (wrapper *CommandWrapper) RunWithInput(
+	ctx context.Context,
+	stdin io.Reader,
+) (stdout, stderr io.Reader, err error) {
 	outBuffer := new(bytes.Buffer)
 	errBuffer := new(bytes.Buffer)
 
-	cmdPath, err := exec.LookPath(wrapper.name)
-	if err != nil {
-		return outBuffer, errBuffer, err
-	}
-
-	log.Debug(
-		"Executing command: %s",
-		strings.Join(append([]string{cmdPath}, wrapper.args...), " "),
-	)
+	err = wrapper.run(ctx, stdin, outBuffer, errBuffer)
 
-	exec := exec.Command(cmdPath, wrapper.args...)
+	return outBuffer, errBuffer, err
+}
 
-	exec.Stdout = outBuffer
-	exec.Stderr = errBuffer
+// RunTo executes the command like Run, but writes its standard output to the
+// given writer as it is produced, rather than collecting it in memory. This
+// allows large outputs, such as container logs, to be saved directly to an
+// output store. Standard error is still collected and returned.
+func (wrapper *CommandWrapper) RunTo(
+	ctx context.Context,
+	stdout io.Writer,
+) (stderr io.Reader, err error) {
+	errBuffer := new(bytes.Buffer)
 
-	err = exec.Run() // and wait
+	err = wrapper.run(ctx, nil, stdout, errBuffer)
 
-	return outBuffer, errBuffer, err
+	return errBuffer, err
 }
 
 // RunCombinedOutput executes the command and returns its combined standard
 // output and error streams as a single reader, along with any error that occurred.
+// The command is killed if the given context is done before it completes.
 // It returns two values, which are based on the results of the command execution:
 // output []byte: The standard output and error generated by the command.
 // err error: An error, if one occurred while executing the command.
-func (wrapper *CommandWrapper) RunCombinedOutput() (io.Reader, error) {
+func (wrapper *CommandWrapper) RunCombinedOutput(
+	ctx context.Context,
+) (io.Reader, error) {
 	outBuffer := new(bytes.Buffer)
 
+	err := wrapper.RunCombinedOutputTo(ctx, outBuffer)
+
+	return outBuffer, err
+}
+
+// RunCombinedOutputTo executes the command like RunCombinedOutput, but writes
+// its combined standard output and error streams to the given writer as they
+// are produced, rather than collecting them in memory.
+func (wrapper *CommandWrapper) RunCombinedOutputTo(
+	ctx context.Context,
+	writer io.Writer,
+) error {
+	return wrapper.run(ctx, nil, writer, writer)
+}
+
+// run executes the command with the given standard streams, and records it
+// for the provenance of the outputs saved from it
+func (wrapper *CommandWrapper) run(
+	ctx context.Context,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) error {
 	cmdPath, err := exec.LookPath(wrapper.name)
 	if err != nil {
-		return outBuffer, err
+		return err
 	}
 
 	log.Debug(
@@ -72,12 +121,47 @@ func (wrapper *CommandWrapper) RunCombinedOutput() (io.Reader, error) {
 		strings.Join(append([]string{cmdPath}, wrapper.args...), " "),
 	)
 
-	exec := exec.Command(cmdPath, wrapper.args...)
+	exec := exec.CommandContext(ctx, cmdPath, wrapper.args...)
+	exec.WaitDelay = WaitDelay
 
-	exec.Stdout = outBuffer
-	exec.Stderr = outBuffer
+	exec.Stdin = stdin
+	exec.Stdout = stdout
+	exec.Stderr = stderr
 
+	startedAt := time.Now()
 	err = exec.Run() // and wait
+	wrapper.record(ctx, startedAt, exec.ProcessState)
 
-	return outBuffer, err
+	return contextError(ctx, err)
+}
+
+// record records the command that ran with the provenance recorder carried by
+// the context, if any, so it can be listed with the outputs saved from it. The
+// exit code is -1 if the command didn't start or was killed.
+func (wrapper *CommandWrapper) record(
+	ctx context.Context,
+	startedAt time.Time,
+	processState *os.ProcessState,
+) {
+	exitCode := -1
+	if processState != nil {
+		exitCode = processState.ExitCode()
+	}
+
+	provenance.FromContext(ctx).RecordCommand(provenance.Command{
+		Args:       append([]string{wrapper.name}, wrapper.args...),
+		ExitCode:   exitCode,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+	})
+}
+
+// contextError reports the reason the context was done, rather than the
+// resulting "signal: killed" error, when a command is stopped by its context.
+func contextError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w (%s)", ctx.Err(), err)
+	}
+
+	return err
 }
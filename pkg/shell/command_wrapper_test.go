@@ -1,16 +1,22 @@
 package shell
 
 import (
+	"context"
+	"errors"
 	"io"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/cyberark/conjur-inspect/pkg/provenance"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommandWrapper_Run(t *testing.T) {
 	cmd := NewCommandWrapper("echo", "hello world")
 
-	stdoutReader, stderrReader, err := cmd.Run()
+	stdoutReader, stderrReader, err := cmd.Run(context.Background())
 	assert.NoError(t, err)
 
 	stderr, err := io.ReadAll(stderrReader)
@@ -22,10 +28,24 @@ func TestCommandWrapper_Run(t *testing.T) {
 	assert.Equal(t, "hello world\n", string(stdout))
 }
 
+func TestCommandWrapper_RunWithInput(t *testing.T) {
+	cmd := NewCommandWrapper("cat")
+
+	stdoutReader, _, err := cmd.RunWithInput(
+		context.Background(),
+		strings.NewReader("hello world"),
+	)
+	assert.NoError(t, err)
+
+	stdout, err := io.ReadAll(stdoutReader)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(stdout))
+}
+
 func TestCommandWrapper_Run_Error(t *testing.T) {
 	cmd := NewCommandWrapper("invalid_command", "hello world")
 
-	stdoutReader, stderrReader, err := cmd.Run()
+	stdoutReader, stderrReader, err := cmd.Run(context.Background())
 	assert.Error(t, err)
 
 	stderr, err := io.ReadAll(stderrReader)
@@ -37,10 +57,66 @@ func TestCommandWrapper_Run_Error(t *testing.T) {
 	assert.Empty(t, stdout)
 }
 
+func TestCommandWrapper_Run_RecordsProvenance(t *testing.T) {
+	recorder := &provenance.Recorder{}
+	ctx := provenance.WithRecorder(context.Background(), recorder)
+
+	_, _, err := NewCommandWrapper("sh", "-c", "exit 3").Run(ctx)
+	assert.Error(t, err)
+
+	command, _ := recorder.TakeOutput()
+	require.NotNil(t, command)
+	assert.Equal(t, []string{"sh", "-c", "exit 3"}, command.Args)
+	assert.Equal(t, 3, command.ExitCode)
+	assert.False(t, command.FinishedAt.Before(command.StartedAt))
+
+	_, err = NewCommandWrapper("echo", "hello").RunCombinedOutput(ctx)
+	assert.NoError(t, err)
+
+	command, _ = recorder.TakeOutput()
+	assert.Equal(t, []string{"echo", "hello"}, command.Args)
+	assert.Equal(t, 0, command.ExitCode)
+}
+
+func TestCommandWrapper_RunTo(t *testing.T) {
+	cmd := NewCommandWrapper("sh", "-c", "echo out; echo err >&2")
+
+	var stdout strings.Builder
+	stderrReader, err := cmd.RunTo(context.Background(), &stdout)
+	assert.NoError(t, err)
+	assert.Equal(t, "out\n", stdout.String())
+
+	stderr, err := io.ReadAll(stderrReader)
+	assert.NoError(t, err)
+	assert.Equal(t, "err\n", string(stderr))
+}
+
+func TestCommandWrapper_RunTo_WriteError(t *testing.T) {
+	cmd := NewCommandWrapper("echo", "hello world")
+
+	// The command fails if its output can't be written, e.g. because the
+	// output store it is streamed to failed
+	reader, writer := io.Pipe()
+	reader.CloseWithError(errors.New("store failed"))
+
+	_, err := cmd.RunTo(context.Background(), writer)
+	assert.ErrorContains(t, err, "store failed")
+}
+
+func TestCommandWrapper_RunCombinedOutputTo(t *testing.T) {
+	cmd := NewCommandWrapper("sh", "-c", "echo out; echo err >&2")
+
+	var output strings.Builder
+	err := cmd.RunCombinedOutputTo(context.Background(), &output)
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "out\n")
+	assert.Contains(t, output.String(), "err\n")
+}
+
 func TestCommandWrapper_RunCombinedOutput(t *testing.T) {
 	cmd := NewCommandWrapper("echo", "hello world")
 
-	stdoutReader, err := cmd.RunCombinedOutput()
+	stdoutReader, err := cmd.RunCombinedOutput(context.Background())
 	assert.NoError(t, err)
 
 	stdout, err := io.ReadAll(stdoutReader)
@@ -51,10 +127,33 @@ func TestCommandWrapper_RunCombinedOutput(t *testing.T) {
 func TestCommandWrapper_RunCombinedOutput_Error(t *testing.T) {
 	cmd := NewCommandWrapper("invalid_command", "hello world")
 
-	stdoutReader, err := cmd.RunCombinedOutput()
+	stdoutReader, err := cmd.RunCombinedOutput(context.Background())
 	assert.Error(t, err)
 
 	stdout, err := io.ReadAll(stdoutReader)
 	assert.NoError(t, err)
 	assert.Empty(t, stdout)
 }
+
+func TestCommandWrapper_Run_ContextTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	cmd := NewCommandWrapper("sleep", "10")
+
+	start := time.Now()
+	_, _, err := cmd.Run(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestCommandWrapper_RunCombinedOutput_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cmd := NewCommandWrapper("sleep", "10")
+
+	_, err := cmd.RunCombinedOutput(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,31 @@
+package signing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
+// Digest returns the hex encoded SHA-256 digest of everything read from the
+// reader, as recorded in a signature statement
+func Digest(reader io.Reader) (string, error) {
+	hash := sha256.New()
+	_, err := io.Copy(hash, reader)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// FileDigest returns the hex encoded SHA-256 digest of a file
+func FileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return Digest(file)
+}
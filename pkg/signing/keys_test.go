@@ -0,0 +1,54 @@
+package signing
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKey(t *testing.T) {
+	publicPEM, privatePEM, err := GenerateKey()
+	require.NoError(t, err)
+
+	publicKey, err := ParsePublicKey(publicPEM)
+	require.NoError(t, err)
+
+	privateKey, err := ParsePrivateKey(privatePEM)
+	require.NoError(t, err)
+
+	assert.True(t, publicKey.Equal(privateKey.Public()))
+	assert.Regexp(t, "^SHA256:[0-9a-f]{64}$", KeyID(publicKey))
+}
+
+func TestParseKeysErrors(t *testing.T) {
+	_, err := ParsePublicKey([]byte("not a key"))
+	assert.EqualError(t, err, "no PEM encoded key found")
+
+	_, err = ParsePrivateKey(
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte{}}),
+	)
+	assert.EqualError(t, err, "unsupported PEM block type: RSA PRIVATE KEY")
+
+	// Encryption keys can't be used for signing
+	x25519Key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(x25519Key.PublicKey())
+	require.NoError(t, err)
+	_, err = ParsePublicKey(
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}),
+	)
+	assert.EqualError(t, err, "unsupported key type *ecdh.PublicKey (must be ed25519)")
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(x25519Key)
+	require.NoError(t, err)
+	_, err = ParsePrivateKey(
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+	)
+	assert.EqualError(t, err, "unsupported key type *ecdh.PrivateKey (must be ed25519)")
+}
@@ -0,0 +1,109 @@
+// Package signing signs raw data archives with ed25519 keys, so that anyone
+// with the public key can prove an archive wasn't altered after it was
+// collected.
+//
+// A signature is written as a JSON file next to the archive. It contains a
+// statement, describing the archive by its SHA-256 digest and the digest of
+// its manifest, and an ed25519 signature over the exact bytes of that
+// statement.
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Extension is added to the archive path to name its signature
+const Extension = ".sig"
+
+// format identifies a signature statement and its version
+const format = "conjur-inspect signature v1"
+
+// ErrInvalidSignature is returned when a signature wasn't made by the given
+// key over the statement it contains
+var ErrInvalidSignature = errors.New("signature is invalid")
+
+// Statement is what a signature vouches for
+type Statement struct {
+	Format string `json:"format"`
+	KeyID  string `json:"key_id"`
+
+	// Archive is the file name of the archive when it was signed
+	Archive       string `json:"archive"`
+	ArchiveSHA256 string `json:"archive_sha256"`
+
+	// ManifestSHA256 is the digest of the manifest in the archive, so the
+	// signature vouches for the checksums of the files it lists
+	ManifestSHA256 string `json:"manifest_sha256"`
+
+	SignedAt time.Time `json:"signed_at"`
+}
+
+// signatureFile is the contents of a signature file. The statement is kept as
+// the raw bytes that were signed, so it's verified exactly as written.
+type signatureFile struct {
+	Statement json.RawMessage `json:"statement"`
+	Signature []byte          `json:"signature"`
+}
+
+// Sign signs the statement with the private key, and returns the contents of
+// the signature file. The statement's format and key ID are set from the key.
+func Sign(statement Statement, privateKey ed25519.PrivateKey) ([]byte, error) {
+	statement.Format = format
+	statement.KeyID = KeyID(privateKey.Public().(ed25519.PublicKey))
+
+	statementJSON, err := json.Marshal(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	// The file isn't indented, since that would reformat the statement
+	signature, err := json.Marshal(signatureFile{
+		Statement: statementJSON,
+		Signature: ed25519.Sign(privateKey, statementJSON),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(signature, '\n'), nil
+}
+
+// Verify checks that the signature file was signed by the public key, and
+// returns the statement it vouches for. The caller must still check that the
+// archive matches the statement.
+func Verify(signature []byte, publicKey ed25519.PublicKey) (*Statement, error) {
+	var file signatureFile
+	err := json.Unmarshal(signature, &file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signature: %w", err)
+	}
+
+	statement := &Statement{}
+	err = json.Unmarshal(file.Statement, statement)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signature statement: %w", err)
+	}
+
+	if !ed25519.Verify(publicKey, file.Statement, file.Signature) {
+		// The key ID isn't trusted until the signature is verified, but it
+		// helps explain why verification failed
+		if statement.KeyID != KeyID(publicKey) {
+			return nil, fmt.Errorf(
+				"%w: it was made by a different key (%s)",
+				ErrInvalidSignature,
+				statement.KeyID,
+			)
+		}
+		return nil, ErrInvalidSignature
+	}
+
+	if statement.Format != format {
+		return nil, fmt.Errorf("unsupported signature format: %s", statement.Format)
+	}
+
+	return statement, nil
+}
@@ -0,0 +1,95 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signedAt := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	signature, err := Sign(
+		Statement{
+			Archive:        "test.tar.gz",
+			ArchiveSHA256:  "archive-digest",
+			ManifestSHA256: "manifest-digest",
+			SignedAt:       signedAt,
+		},
+		privateKey,
+	)
+	require.NoError(t, err)
+
+	statement, err := Verify(signature, publicKey)
+	require.NoError(t, err)
+
+	assert.Equal(
+		t,
+		&Statement{
+			Format:         format,
+			KeyID:          KeyID(publicKey),
+			Archive:        "test.tar.gz",
+			ArchiveSHA256:  "archive-digest",
+			ManifestSHA256: "manifest-digest",
+			SignedAt:       signedAt,
+		},
+		statement,
+	)
+}
+
+func TestVerifyModified(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signature, err := Sign(
+		Statement{Archive: "test.tar.gz", ArchiveSHA256: "archive-digest"},
+		privateKey,
+	)
+	require.NoError(t, err)
+
+	modified := bytes.Replace(signature, []byte("archive-digest"), []byte("altered-digest"), 1)
+	_, err = Verify(modified, publicKey)
+	assert.Equal(t, ErrInvalidSignature, err)
+}
+
+func TestVerifyWrongKey(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signature, err := Sign(Statement{Archive: "test.tar.gz"}, privateKey)
+	require.NoError(t, err)
+
+	_, err = Verify(signature, otherPublicKey)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.ErrorContains(
+		t,
+		err,
+		"made by a different key ("+KeyID(privateKey.Public().(ed25519.PublicKey))+")",
+	)
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, err = Verify([]byte("not a signature"), publicKey)
+	assert.ErrorContains(t, err, "unable to parse signature")
+}
+
+func TestDigest(t *testing.T) {
+	digest, err := Digest(strings.NewReader("output"))
+	require.NoError(t, err)
+	assert.Len(t, digest, 64)
+}
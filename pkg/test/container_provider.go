@@ -2,6 +2,7 @@
 package test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -58,7 +59,9 @@ func (*ContainerProvider) Name() string {
 }
 
 // Info returns the container provider info
-func (cp *ContainerProvider) Info() (container.ContainerProviderInfo, error) {
+func (cp *ContainerProvider) Info(
+	context.Context,
+) (container.ContainerProviderInfo, error) {
 	if cp.InfoError != nil {
 		return nil, cp.InfoError
 	}
@@ -88,7 +91,7 @@ func (cp *ContainerProvider) Container(
 }
 
 // NetworkInspect returns the mock network inspect output
-func (cp *ContainerProvider) NetworkInspect() (io.Reader, error) {
+func (cp *ContainerProvider) NetworkInspect(context.Context) (io.Reader, error) {
 	if cp.NetworkInspectError != nil {
 		return nil, cp.NetworkInspectError
 	}
@@ -120,7 +123,7 @@ func (c *Container) ID() string {
 }
 
 // Inspect returns the JSON output of the mock `inspect` command
-func (c *Container) Inspect() (io.Reader, error) {
+func (c *Container) Inspect(context.Context) (io.Reader, error) {
 	if c.InspectError != nil {
 		return nil, c.InspectError
 	}
@@ -130,6 +133,7 @@ func (c *Container) Inspect() (io.Reader, error) {
 
 // Exec returns the JSON output of the mock `exec` command
 func (c *Container) Exec(
+	_ context.Context,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
 
@@ -147,6 +151,7 @@ func (c *Container) Exec(
 
 // ExecAsUser returns the JSON output of the mock `exec` command as a specific user
 func (c *Container) ExecAsUser(
+	_ context.Context,
 	user string,
 	command ...string,
 ) (stdout, stderr io.Reader, err error) {
@@ -166,6 +171,42 @@ func (c *Container) ExecAsUser(
 }
 
 // Logs returns the output of the mock `logs` command
-func (c *Container) Logs(since time.Duration) (io.Reader, error) {
+func (c *Container) Logs(
+	_ context.Context,
+	since time.Duration,
+) (io.Reader, error) {
 	return c.LogsOutput, c.LogsError
 }
+
+// ExecTo writes the mock `exec` output to the given writer
+func (c *Container) ExecTo(
+	ctx context.Context,
+	stdout io.Writer,
+	command ...string,
+) (stderr io.Reader, err error) {
+	execStdout, execStderr, err := c.Exec(ctx, command...)
+	if execStdout != nil {
+		_, copyErr := io.Copy(stdout, execStdout)
+		if err == nil {
+			err = copyErr
+		}
+	}
+
+	return execStderr, err
+}
+
+// LogsTo writes the mock `logs` output to the given writer
+func (c *Container) LogsTo(
+	_ context.Context,
+	writer io.Writer,
+	since time.Duration,
+) error {
+	if c.LogsOutput != nil {
+		_, err := io.Copy(writer, c.LogsOutput)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.LogsError
+}
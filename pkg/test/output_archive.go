@@ -15,6 +15,11 @@ func (oa *OutputArchive) Archive(name string, store output.Store) error {
 	return nil
 }
 
+// Path returns the name the archive would have, without a directory
+func (oa *OutputArchive) Path(name string) string {
+	return name + ".tar.gz"
+}
+
 // IsArchived returns whether the Archive method was called.
 func (oa *OutputArchive) IsArchived() bool {
 	return oa.archiveCalled
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path"
+	"sync"
 	"time"
 
 	"github.com/cyberark/conjur-inspect/pkg/output"
@@ -12,6 +14,8 @@ import (
 // OutputStore is a mock implementation of the output.Store interface for
 // unit testing purposes.
 type OutputStore struct {
+	// Checks may save outputs concurrently
+	mutex sync.Mutex
 	items map[string]OutputStoreItem
 }
 
@@ -43,6 +47,9 @@ func (store *OutputStore) Save(name string, reader io.Reader) (output.StoreItem,
 		data: buf.Bytes(),
 	}
 
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
 	store.items[name] = newItem
 
 	return &newItem, nil
@@ -50,6 +57,9 @@ func (store *OutputStore) Save(name string, reader io.Reader) (output.StoreItem,
 
 // Items returns the collection of outputs store in this directory
 func (store *OutputStore) Items() ([]output.StoreItem, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
 	items := make([]output.StoreItem, 0, len(store.items))
 
 	for _, value := range store.items {
@@ -66,10 +76,15 @@ func (store *OutputStore) Cleanup() error {
 	return nil
 }
 
+// Name returns the name the item was saved with
+func (item *OutputStoreItem) Name() string {
+	return item.name
+}
+
 // Info returns the stat results for a given item (file) in a DirectoryStore
 func (item *OutputStoreItem) Info() (os.FileInfo, error) {
 	info := &FileInfo{
-		name:    item.name,
+		name:    path.Base(item.name),
 		size:    int64(len(item.data)),
 		isDir:   false,
 		modTime: time.Now(),
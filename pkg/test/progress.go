@@ -0,0 +1,48 @@
+package test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+	"github.com/cyberark/conjur-inspect/pkg/report"
+)
+
+// Progress is a mock implementation of the report.Progress interface that
+// records the events it receives for unit test assertions.
+type Progress struct {
+	Events []string
+}
+
+// RunStarted records the event with the check count
+func (progress *Progress) RunStarted(checkCount int) {
+	progress.record("run started: %d", checkCount)
+}
+
+// CheckStarted records the event with the check ID
+func (progress *Progress) CheckStarted(currentCheck check.Check) {
+	progress.record("check started: %s", currentCheck.ID())
+}
+
+// CheckFinished records the event with the check ID and result count
+func (progress *Progress) CheckFinished(
+	currentCheck check.Check,
+	_ time.Duration,
+	results []check.Result,
+) {
+	progress.record("check finished: %s (%d results)", currentCheck.ID(), len(results))
+}
+
+// ArchiveWritten records the event with the archive path
+func (progress *Progress) ArchiveWritten(path string) {
+	progress.record("archive written: %s", path)
+}
+
+// RunFinished records the event
+func (progress *Progress) RunFinished(result *report.Result) {
+	progress.record("run finished: interrupted=%t", result.Interrupted)
+}
+
+func (progress *Progress) record(format string, args ...any) {
+	progress.Events = append(progress.Events, fmt.Sprintf(format, args...))
+}
@@ -1,11 +1,17 @@
 package test
 
-import "github.com/cyberark/conjur-inspect/pkg/check"
+import (
+	"context"
+
+	"github.com/cyberark/conjur-inspect/pkg/check"
+)
 
 // NewRunContext returns a test run context pre-configured with an in-memory
-// output data store.
+// output data store, a background context and a cleanup hook registry.
 func NewRunContext(containerID string) check.RunContext {
 	return check.RunContext{
+		Context:     context.Background(),
+		Cleanups:    &check.Cleanups{},
 		ContainerID: containerID,
 		OutputStore: NewOutputStore(),
 	}